@@ -2,17 +2,22 @@ package main
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
+	grpcHD "go-app/internal/delivery/grpc"
 	httpHD "go-app/internal/delivery/http"
 	"go-app/internal/infrastructure/config"
 	"go-app/internal/infrastructure/constant"
 	"go-app/internal/infrastructure/database"
 	"go-app/internal/infrastructure/redis"
 	"go-app/internal/infrastructure/registry"
+	"go-app/internal/infrastructure/tracing"
 	"go-app/pkg/errors"
 	"go-app/pkg/logger"
 
@@ -25,6 +30,10 @@ func main() {
 	if err := config.LoadConfig(); err != nil {
 		logger.Error(err)
 	}
+	logConf := config.GetLogConfig()
+	if err := logger.Configure(logConf.Level, logConf.Format); err != nil {
+		logger.Error(err)
+	}
 
 	conf := config.GetAppConfig()
 	// Set timezone
@@ -36,29 +45,64 @@ func main() {
 
 	if err := run(conf); err != nil {
 		logger.Error(err)
+		os.Exit(1)
 	}
 }
 
 // run Application
 func run(conf config.AppConfig) error {
+	shutdownTracing, err := tracing.Setup(context.Background(), config.GetTracingConfig(), conf.AppName)
+	if err != nil {
+		return errors.Throw(err)
+	}
+	// Flush the spans left on exit
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), constant.ConnectTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Error(err)
+		}
+	}()
+
 	dbConf := config.GetDBConfig()
 	var db *gorm.DB
-	var err error
-	for {
+	// Give up after the attempts so the orchestrator restarts the app
+	for attempt := 1; ; attempt++ {
 		db, err = database.NewGormDB(dbConf)
-		if err != nil {
-			logger.Infof("Wait for starting db: %v", err)
-			time.Sleep(constant.ConnectWaitDuration)
-		} else {
+		if err == nil {
 			break
 		}
+		if attempt == constant.ConnectMaxAttempts {
+			return errors.Throw(err)
+		}
+		logger.Infof("Wait for starting db: %v", err)
+		time.Sleep(constant.ConnectWaitDuration)
 	}
 
 	rdb := redis.New(config.GetRedisConfig())
 	e := echo.New()
+	// The client ip limits and locks out the requests, X-Forwarded-For is trusted only from the proxies
+	if e.IPExtractor, err = httpHD.NewIPExtractor(conf.TrustedProxies); err != nil {
+		return errors.Throw(err)
+	}
 
-	reg := registry.NewRegistry(db, rdb)
+	reg, err := registry.NewRegistry(db, rdb)
+	if err != nil {
+		return errors.Throw(err)
+	}
+	defer func() {
+		if err := reg.Close(); err != nil {
+			logger.Error(err)
+		}
+	}()
 	httpHD.NewHTTPHandler(e, reg.JWTSvc, reg)
+	gs := grpcHD.NewGRPCServer(reg.JWTSvc, reg)
+
+	// The app does not start without its grpc port
+	lis, err := net.Listen("tcp", conf.AppGRPCHost)
+	if err != nil {
+		return errors.ErrInternalServerError.Wrap(err)
+	}
 
 	s := &http.Server{
 		Handler:     e,
@@ -77,17 +121,38 @@ func run(conf config.AppConfig) error {
 		}
 	}()
 
+	// Publish the outbox to the subscribers of the event bus and send the webhooks
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	var workers sync.WaitGroup
+	workers.Go(func() { reg.OutboxUc.Run(workerCtx) })
+	workers.Go(func() { reg.WebhookUc.Run(workerCtx) })
+	// The memory queue lives in this process, so its jobs can not be processed by cmd/worker
+	if config.GetQueueConfig().Driver == constant.QueueDriverMemory {
+		workers.Go(func() { reg.JobWorker.Run(workerCtx) })
+	}
+
+	go func() {
+		logger.Infof("Start grpc server: %v", conf.AppGRPCHost)
+		if err := gs.Serve(lis); err != nil {
+			logger.Error(err)
+		}
+	}()
+
 	// Wait for interrupt signal to gracefully shutdown the server with a timeout of 10 seconds.
 	// Use a buffered channel to avoid missing signals as recommended for signal.Notify
 	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 
 	logger.Infof("Signal: %d, received", <-quit)
 	ctx, cancel := context.WithTimeout(context.Background(), constant.ConnectTimeout)
 	defer cancel()
+	gs.GracefulStop()
 	if err := e.Shutdown(ctx); err != nil {
 		return errors.ErrInternalServerError.Wrap(err)
 	}
+	stopWorkers()
+	workers.Wait()
 
 	return nil
 }
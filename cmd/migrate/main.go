@@ -12,6 +12,10 @@ func main() {
 	if err := config.LoadConfig(); err != nil {
 		logger.Error(err)
 	}
+	logConf := config.GetLogConfig()
+	if err := logger.Configure(logConf.Level, logConf.Format); err != nil {
+		logger.Error(err)
+	}
 
 	conf := config.GetAppConfig()
 
@@ -17,8 +17,15 @@ import (
 var pathJSON = "db/seeds/data.json"
 
 type seedData struct {
-	Roles []entity.Role `json:"roles"`
-	Users []entity.User `json:"users"`
+	Roles           []entity.Role       `json:"roles"`
+	Permissions     []entity.Permission `json:"permissions"`
+	RolePermissions []rolePermission    `json:"rolePermissions"`
+	Users           []entity.User       `json:"users"`
+}
+
+type rolePermission struct {
+	RoleID      uint     `json:"roleId"`
+	Permissions []string `json:"permissions"`
 }
 
 func main() {
@@ -26,6 +33,10 @@ func main() {
 	if err := config.LoadConfig(); err != nil {
 		logger.Error(err)
 	}
+	logConf := config.GetLogConfig()
+	if err := logger.Configure(logConf.Level, logConf.Format); err != nil {
+		logger.Error(err)
+	}
 
 	conf := config.GetAppConfig()
 
@@ -52,6 +63,7 @@ func seed(dbConfig config.Database) error {
 	// Registry Repository
 	userRepo := repository.NewUserRepository(db)
 	roleRepo := repository.NewRoleRepository(db)
+	permissionRepo := repository.NewPermissionRepository(db)
 
 	viper.SetConfigFile(pathJSON)
 	if err = viper.ReadInConfig(); err != nil {
@@ -69,7 +81,29 @@ func seed(dbConfig config.Database) error {
 		}
 	}
 
+	// Seed permissionRepo
+	permissionIDs := map[string]uint{}
+	for i := range data.Permissions {
+		if err := permissionRepo.Store(context.Background(), &data.Permissions[i]); err != nil {
+			return errors.ErrInternalServerError.Wrap(err)
+		}
+		permissionIDs[data.Permissions[i].Name] = data.Permissions[i].ID
+	}
+
+	for _, rp := range data.RolePermissions {
+		ids := make([]uint, 0, len(rp.Permissions))
+		for _, name := range rp.Permissions {
+			ids = append(ids, permissionIDs[name])
+		}
+		if err := permissionRepo.SyncRole(context.Background(), rp.RoleID, ids); err != nil {
+			return errors.ErrInternalServerError.Wrap(err)
+		}
+	}
+
+	// Seeded users are trusted
+	verifiedAt := time.Now()
 	for j := range data.Users {
+		data.Users[j].EmailVerifiedAt = &verifiedAt
 		if err := userRepo.Store(context.Background(), &data.Users[j]); err != nil {
 			return errors.ErrInternalServerError.Wrap(err)
 		}
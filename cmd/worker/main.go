@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go-app/internal/infrastructure/config"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/database"
+	"go-app/internal/infrastructure/redis"
+	"go-app/internal/infrastructure/registry"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/pkg/errors"
+	"go-app/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+func main() {
+	logger.Init()
+	if err := config.LoadConfig(); err != nil {
+		logger.Error(err)
+	}
+	logConf := config.GetLogConfig()
+	if err := logger.Configure(logConf.Level, logConf.Format); err != nil {
+		logger.Error(err)
+	}
+
+	conf := config.GetAppConfig()
+	// Set timezone
+	loc, err := time.LoadLocation(conf.AppTimeZone)
+	if err != nil {
+		logger.Error(err)
+	}
+	time.Local = loc
+
+	if err := run(); err != nil {
+		logger.Error(err)
+		os.Exit(1)
+	}
+}
+
+// run Worker, it processes the jobs of the queue until a signal is received
+func run() error {
+	queueConf := config.GetQueueConfig()
+	if queueConf.Driver == constant.QueueDriverMemory {
+		err := fmt.Errorf("the %s queue is processed by cmd/app, use %s", queueConf.Driver, constant.QueueDriverRedis)
+		return errors.ErrInternalServerError.Wrap(err)
+	}
+
+	service := config.GetAppConfig().AppName + "-worker"
+	shutdownTracing, err := tracing.Setup(context.Background(), config.GetTracingConfig(), service)
+	if err != nil {
+		return errors.Throw(err)
+	}
+	// Flush the spans left on exit
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), constant.ConnectTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Error(err)
+		}
+	}()
+
+	dbConf := config.GetDBConfig()
+	var db *gorm.DB
+	// Give up after the attempts so the orchestrator restarts the worker
+	for attempt := 1; ; attempt++ {
+		db, err = database.NewGormDB(dbConf)
+		if err == nil {
+			break
+		}
+		if attempt == constant.ConnectMaxAttempts {
+			return errors.Throw(err)
+		}
+		logger.Infof("Wait for starting db: %v", err)
+		time.Sleep(constant.ConnectWaitDuration)
+	}
+
+	rdb := redis.New(config.GetRedisConfig())
+	reg, err := registry.NewRegistry(db, rdb)
+	if err != nil {
+		return errors.Throw(err)
+	}
+	defer func() {
+		if err := reg.Close(); err != nil {
+			logger.Error(err)
+		}
+	}()
+
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	done := make(chan struct{})
+	go func() {
+		logger.Infof("Start worker: queue %v, location: %v", queueConf.Name, time.Now().Location().String())
+		reg.JobWorker.Run(ctx)
+		close(done)
+	}()
+
+	// Wait for interrupt signal to drain the jobs in progress with a timeout longer than the one of a job.
+	// Use a buffered channel to avoid missing signals as recommended for signal.Notify
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+
+	logger.Infof("Signal: %d, received", <-quit)
+	stop()
+	select {
+	case <-done:
+	case <-time.After(constant.WorkerShutdownTimeout):
+		return errors.ErrInternalServerError.Wrap(context.DeadlineExceeded)
+	}
+
+	return nil
+}
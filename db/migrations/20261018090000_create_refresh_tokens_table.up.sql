@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS refresh_tokens(
+  id BIGSERIAL PRIMARY KEY,
+  user_id BIGINT NOT NULL,
+  family_id VARCHAR(36) NOT NULL,
+  token_hash VARCHAR(64) UNIQUE NOT NULL,
+  expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  revoked_at TIMESTAMP WITH TIME ZONE,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens (family_id);
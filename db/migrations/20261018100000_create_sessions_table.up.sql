@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS sessions(
+  id VARCHAR(36) PRIMARY KEY,
+  user_id BIGINT NOT NULL,
+  device VARCHAR(150) NOT NULL DEFAULT '',
+  ip VARCHAR(45) NOT NULL DEFAULT '',
+  user_agent TEXT NOT NULL DEFAULT '',
+  last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  revoked_at TIMESTAMP WITH TIME ZONE,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_sessions_user_id ON sessions (user_id);
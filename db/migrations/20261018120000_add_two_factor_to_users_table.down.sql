@@ -0,0 +1,4 @@
+ALTER TABLE users
+  DROP COLUMN IF EXISTS two_factor_secret,
+  DROP COLUMN IF EXISTS two_factor_recovery_codes,
+  DROP COLUMN IF EXISTS two_factor_confirmed_at;
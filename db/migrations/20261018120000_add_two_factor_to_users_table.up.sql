@@ -0,0 +1,4 @@
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS two_factor_secret VARCHAR(64) NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS two_factor_recovery_codes TEXT,
+  ADD COLUMN IF NOT EXISTS two_factor_confirmed_at TIMESTAMP WITH TIME ZONE;
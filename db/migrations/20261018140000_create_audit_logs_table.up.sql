@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS audit_logs(
+  id BIGSERIAL PRIMARY KEY,
+  actor_id BIGINT,
+  action VARCHAR(100) NOT NULL,
+  target_type VARCHAR(50) NOT NULL,
+  target_id VARCHAR(100) NOT NULL DEFAULT '',
+  before JSONB,
+  after JSONB,
+  ip VARCHAR(45) NOT NULL DEFAULT '',
+  user_agent TEXT NOT NULL DEFAULT '',
+  request_id VARCHAR(128) NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_audit_logs_actor_id ON audit_logs (actor_id);
+CREATE INDEX idx_audit_logs_target ON audit_logs (target_type, target_id);
+CREATE INDEX idx_audit_logs_action ON audit_logs (action);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs (created_at);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS outbox_messages(
+  id BIGSERIAL PRIMARY KEY,
+  event_name VARCHAR(100) NOT NULL,
+  payload JSONB NOT NULL,
+  attempts INTEGER NOT NULL DEFAULT 0,
+  available_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  published_at TIMESTAMP WITH TIME ZONE,
+  failed_at TIMESTAMP WITH TIME ZONE,
+  last_error TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_outbox_messages_pending ON outbox_messages (available_at, id)
+  WHERE published_at IS NULL AND failed_at IS NULL;
@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS webhooks(
+  id BIGSERIAL PRIMARY KEY,
+  url VARCHAR(2048) NOT NULL,
+  secret VARCHAR(255) NOT NULL,
+  events JSONB NOT NULL DEFAULT '[]',
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_webhooks_events ON webhooks USING GIN (events);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries(
+  id BIGSERIAL PRIMARY KEY,
+  webhook_id BIGINT NOT NULL,
+  event_id BIGINT NOT NULL,
+  event_name VARCHAR(100) NOT NULL,
+  payload JSONB NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending',
+  attempts INTEGER NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  last_status_code INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT NOT NULL DEFAULT '',
+  delivered_at TIMESTAMP WITH TIME ZONE,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_webhook_id FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE,
+  CONSTRAINT uq_webhook_deliveries_event UNIQUE (webhook_id, event_id)
+);
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at, id) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS webhook_attempts(
+  id BIGSERIAL PRIMARY KEY,
+  delivery_id BIGINT NOT NULL,
+  attempt INTEGER NOT NULL,
+  status_code INTEGER NOT NULL DEFAULT 0,
+  response_body TEXT NOT NULL DEFAULT '',
+  error TEXT NOT NULL DEFAULT '',
+  duration_ms BIGINT NOT NULL DEFAULT 0,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_delivery_id FOREIGN KEY (delivery_id) REFERENCES webhook_deliveries(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_webhook_attempts_delivery_id ON webhook_attempts (delivery_id);
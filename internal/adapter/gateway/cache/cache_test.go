@@ -0,0 +1,222 @@
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-app/internal/adapter/gateway/cache"
+	"go-app/internal/domain/gateway"
+	"go-app/pkg/errors"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+const channel = "cache:test"
+
+// point is stored through encoding.BinaryMarshaler
+type point struct{ x, y int }
+
+func (p point) MarshalBinary() ([]byte, error) {
+	return fmt.Appendf(nil, "%d,%d", p.x, p.y), nil
+}
+
+// newRedis returns a client of a new miniredis server and the server
+func newRedis(t *testing.T) (*redis.Client, *miniredis.Miniredis) {
+	t.Helper()
+	srv := miniredis.RunT(t)
+	rd := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { _ = rd.Close() })
+
+	return rd, srv
+}
+
+// stores returns a store of every driver
+func stores(t *testing.T) map[string]gateway.Cache {
+	t.Helper()
+	rd, _ := newRedis(t)
+	tiered := cache.NewTieredStore(rd, 0, time.Minute, channel)
+	t.Cleanup(func() { _ = tiered.Close() })
+
+	return map[string]gateway.Cache{
+		"memory": cache.NewMemoryStore(0),
+		"redis":  cache.NewRedisStore(rd),
+		"tiered": tiered,
+	}
+}
+
+func TestStoreEncode(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	at := time.Date(2024, 5, 1, 10, 30, 0, 500, time.UTC)
+
+	expectedResults := []struct {
+		value any
+		bytes string
+	}{
+		{"text", "text"},
+		{[]byte("raw"), "raw"},
+		{nil, ""},
+		{42, "42"},
+		{int64(-7), "-7"},
+		{uint8(255), "255"},
+		{1.5, "1.5"},
+		{float32(0.25), "0.25"},
+		{true, "1"},
+		{false, "0"},
+		{at, "2024-05-01T10:30:00.0000005Z"},
+		{time.Second, "1000000000"},
+		{point{3, 4}, "3,4"},
+	}
+
+	// Every store reads back the bytes Redis stores
+	for name, cm := range stores(t) {
+		for testNumber, testExpected := range expectedResults {
+			if err := cm.Set(ctx, "k", testExpected.value, time.Minute); err != nil {
+				t.Fatalf("%s #%d %v", name, testNumber, err)
+			}
+			b, err := cm.Get(ctx, "k")
+			if err != nil || string(b) != testExpected.bytes {
+				t.Errorf("%s #%d got %q %v, %q expected", name, testNumber, b, err, testExpected.bytes)
+			}
+		}
+	}
+
+	// A value Redis can not store is refused by the stores writing it themselves
+	for _, cm := range []gateway.Cache{cache.NewMemoryStore(0), stores(t)["tiered"]} {
+		if err := cm.Set(ctx, "k", struct{}{}, time.Minute); err == nil {
+			t.Error("got no error for a struct")
+		}
+	}
+}
+
+func TestStoreOperations(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	for name, cm := range stores(t) {
+		if _, err := cm.Get(ctx, "missing"); !errors.Is(err, errors.ErrRedisKeyNotFound.Trace()) {
+			t.Errorf("%s got %v for a missing key, ErrRedisKeyNotFound expected", name, err)
+		}
+		if _, err := cm.TTL(ctx, "missing"); !errors.Is(err, errors.ErrRedisKeyNotFound.Trace()) {
+			t.Errorf("%s got %v for the ttl of a missing key, ErrRedisKeyNotFound expected", name, err)
+		}
+
+		if err := cm.Set(ctx, "a", "1", 0); err != nil {
+			t.Fatal(err)
+		}
+		if ttl, err := cm.TTL(ctx, "a"); err != nil || ttl != 0 {
+			t.Errorf("%s got ttl %v %v, zero for a key which never expires expected", name, ttl, err)
+		}
+		if ok, err := cm.SetNX(ctx, "a", "2", time.Minute); err != nil || ok {
+			t.Errorf("%s got SetNX %v %v on an existing key", name, ok, err)
+		}
+		if ok, err := cm.SetNX(ctx, "b", "2", time.Minute); err != nil || !ok {
+			t.Errorf("%s got SetNX %v %v on a new key", name, ok, err)
+		}
+		values, err := cm.GetMany(ctx, "a", "missing", "b")
+		if err != nil || len(values) != 2 || string(values["a"]) != "1" || string(values["b"]) != "2" {
+			t.Errorf("%s got %v %v, the found keys expected", name, values, err)
+		}
+
+		// The expiration is set when the counter is created only
+		for i, exp := range []time.Duration{time.Minute, time.Hour} {
+			if n, err := cm.Incr(ctx, "counter", exp); err != nil || n != int64(i+1) {
+				t.Errorf("%s got %d %v at increment %d", name, n, err, i+1)
+			}
+		}
+		if ttl, err := cm.TTL(ctx, "counter"); err != nil || ttl <= 0 || ttl > time.Minute {
+			t.Errorf("%s got ttl %v %v, the first expiration expected", name, ttl, err)
+		}
+		if b, err := cm.Get(ctx, "counter"); err != nil || string(b) != "2" {
+			t.Errorf("%s got counter %q %v, 2 expected", name, b, err)
+		}
+
+		if err := cm.Del(ctx, "a", "missing"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := cm.Get(ctx, "a"); !errors.Is(err, errors.ErrRedisKeyNotFound.Trace()) {
+			t.Errorf("%s got %v for a deleted key", name, err)
+		}
+		if err := cm.FlushAll(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if values, err := cm.GetMany(ctx, "b", "counter"); err != nil || len(values) != 0 {
+			t.Errorf("%s got %v %v after a flush", name, values, err)
+		}
+	}
+}
+
+// eventually waits up to a second for the value of the key in cm
+func eventually(t *testing.T, cm gateway.Cache, k, expected string) bool {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if b, err := cm.Get(context.Background(), k); err == nil && string(b) == expected {
+			return true
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	return false
+}
+
+func TestTieredStoreInvalidation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	rd, srv := newRedis(t)
+	a := cache.NewTieredStore(rd, 0, time.Minute, channel)
+	b := cache.NewTieredStore(rd, 0, time.Minute, channel)
+	t.Cleanup(func() { _ = b.Close() })
+
+	if err := srv.Set("k", "v1"); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := a.Get(ctx, "k"); err != nil || string(v) != "v1" {
+		t.Fatalf("got %q %v, v1 expected", v, err)
+	}
+	// The value is read from the local tier of a, a write bypassing the store is not seen
+	if err := srv.Set("k", "bypass"); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := a.Get(ctx, "k"); string(v) != "v1" {
+		t.Errorf("got %q, the local value expected", v)
+	}
+
+	// A write of another node drops the key, as a flush does
+	if err := b.Set(ctx, "k", "v2", 0); err != nil {
+		t.Fatal(err)
+	}
+	if !eventually(t, a, "k", "v2") {
+		t.Error("got the key of a not invalidated by b")
+	}
+	if err := srv.Set("k", "v3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.FlushAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Set("k", "v4"); err != nil {
+		t.Fatal(err)
+	}
+	if !eventually(t, a, "k", "v4") {
+		t.Error("got the keys of a not flushed by b")
+	}
+
+	// The invalidations are not applied once a is closed
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Close(); err != nil {
+		t.Errorf("got %v closing twice", err)
+	}
+	if err := b.Set(ctx, "k", "v5", 0); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond * 100)
+	if v, _ := a.Get(ctx, "k"); string(v) != "v4" {
+		t.Errorf("got %q after close, the local value expected", v)
+	}
+}
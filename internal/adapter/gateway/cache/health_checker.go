@@ -0,0 +1,21 @@
+package cache
+
+import (
+	"context"
+
+	"go-app/internal/domain/gateway"
+	"go-app/pkg/errors"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// NewRedisHealthChecker will create a health checker pinging redis
+func NewRedisHealthChecker(rd *redis.Client) gateway.HealthChecker {
+	return gateway.HealthCheckFunc(func(ctx context.Context) error {
+		if err := rd.Ping(ctx).Err(); err != nil {
+			return errors.ErrRedisConnection.Wrap(err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go-app/internal/domain/gateway"
+	"go-app/pkg/errors"
+	"go-app/pkg/lru"
+)
+
+// memoryStore is a store in the process memory, the least recently used keys are evicted over its size
+type memoryStore struct {
+	items *lru.Cache[[]byte]
+}
+
+// NewMemoryStore create cache instance in memory holding at most size keys
+func NewMemoryStore(size int) gateway.Cache {
+	return &memoryStore{
+		items: lru.New[[]byte](size),
+	}
+}
+
+// Get value from key
+func (m *memoryStore) Get(_ context.Context, k string) ([]byte, error) {
+	v, ok := m.items.Get(k)
+	if !ok {
+		return []byte{}, errors.ErrRedisKeyNotFound.Trace()
+	}
+
+	return bytes.Clone(v), nil
+}
+
+// GetMany values of keys, the missing keys are left out
+func (m *memoryStore) GetMany(_ context.Context, ks ...string) (map[string][]byte, error) {
+	values := make(map[string][]byte, len(ks))
+	for _, k := range ks {
+		if v, ok := m.items.Get(k); ok {
+			values[k] = bytes.Clone(v)
+		}
+	}
+
+	return values, nil
+}
+
+// Set value by key and duration time
+func (m *memoryStore) Set(_ context.Context, k string, v any, exp time.Duration) error {
+	b, err := encode(v)
+	if err != nil {
+		return errors.ErrInternalServerError.Wrap(err)
+	}
+	m.items.Set(k, bytes.Clone(b), exp)
+
+	return nil
+}
+
+// SetNX value by key and duration time unless the key exists, it reports whether the value was set
+func (m *memoryStore) SetNX(_ context.Context, k string, v any, exp time.Duration) (bool, error) {
+	b, err := encode(v)
+	if err != nil {
+		return false, errors.ErrInternalServerError.Wrap(err)
+	}
+
+	return m.items.SetNX(k, bytes.Clone(b), exp), nil
+}
+
+// Incr the counter of key, the duration time is set when the counter is created
+func (m *memoryStore) Incr(_ context.Context, k string, exp time.Duration) (int64, error) {
+	var n int64
+	_, err := m.items.Update(k, exp, func(v []byte, ok bool) ([]byte, error) {
+		if ok {
+			current, err := strconv.ParseInt(string(v), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("cache: value of %q is not an integer", k)
+			}
+			n = current
+		}
+		n++
+
+		return strconv.AppendInt(nil, n, 10), nil
+	})
+	if err != nil {
+		return 0, errors.ErrInternalServerError.Wrap(err)
+	}
+
+	return n, nil
+}
+
+// TTL remaining of key, zero when the key never expires
+func (m *memoryStore) TTL(_ context.Context, k string) (time.Duration, error) {
+	ttl, ok := m.items.TTL(k)
+	if !ok {
+		return 0, errors.ErrRedisKeyNotFound.Trace()
+	}
+
+	return ttl, nil
+}
+
+// Del values keys
+func (m *memoryStore) Del(_ context.Context, ks ...string) error {
+	m.items.Delete(ks...)
+
+	return nil
+}
+
+// FlushAll flush all data
+func (m *memoryStore) FlushAll(_ context.Context) error {
+	m.items.Purge()
+
+	return nil
+}
+
+// Close has nothing to stop
+func (*memoryStore) Close() error {
+	return nil
+}
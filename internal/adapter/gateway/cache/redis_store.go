@@ -10,6 +10,17 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// incrScript increments the counter and sets its expiration when the counter is created
+//
+// KEYS: counter. ARGV: expiration in milliseconds, 0 for none
+var incrScript = redis.NewScript(`
+local n = redis.call('INCR', KEYS[1])
+if n == 1 and tonumber(ARGV[1]) > 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return n
+`)
+
 // redisStore is a store for Redis
 type redisStore struct {
 	client *redis.Client
@@ -36,6 +47,25 @@ func (rd redisStore) Get(ctx context.Context, k string) ([]byte, error) {
 	}
 }
 
+// GetMany values of keys, the missing keys are left out
+func (rd redisStore) GetMany(ctx context.Context, ks ...string) (map[string][]byte, error) {
+	values := make(map[string][]byte, len(ks))
+	if len(ks) == 0 {
+		return values, nil
+	}
+	res, err := rd.client.MGet(ctx, ks...).Result()
+	if err != nil {
+		return nil, errors.ErrRedisConnection.Wrap(err)
+	}
+	for i, v := range res {
+		if s, ok := v.(string); ok {
+			values[ks[i]] = []byte(s)
+		}
+	}
+
+	return values, nil
+}
+
 // Set value by key and duration time
 func (rd redisStore) Set(ctx context.Context, k string, v any, exp time.Duration) error {
 	if err := rd.client.Set(ctx, k, v, exp).Err(); err != nil {
@@ -45,6 +75,41 @@ func (rd redisStore) Set(ctx context.Context, k string, v any, exp time.Duration
 	return nil
 }
 
+// SetNX value by key and duration time unless the key exists, it reports whether the value was set
+func (rd redisStore) SetNX(ctx context.Context, k string, v any, exp time.Duration) (bool, error) {
+	ok, err := rd.client.SetNX(ctx, k, v, exp).Result()
+	if err != nil {
+		return false, errors.ErrRedisConnection.Wrap(err)
+	}
+
+	return ok, nil
+}
+
+// Incr the counter of key, the duration time is set when the counter is created
+func (rd redisStore) Incr(ctx context.Context, k string, exp time.Duration) (int64, error) {
+	n, err := incrScript.Run(ctx, rd.client, []string{k}, exp.Milliseconds()).Int64()
+	if err != nil {
+		return 0, errors.ErrRedisConnection.Wrap(err)
+	}
+
+	return n, nil
+}
+
+// TTL remaining of key, zero when the key never expires
+func (rd redisStore) TTL(ctx context.Context, k string) (time.Duration, error) {
+	ttl, err := rd.client.PTTL(ctx, k).Result()
+	switch {
+	case err != nil:
+		return 0, errors.ErrRedisConnection.Wrap(err)
+	case ttl == -2:
+		return 0, errors.ErrRedisKeyNotFound.Trace()
+	case ttl < 0:
+		return 0, nil
+	default:
+		return ttl, nil
+	}
+}
+
 // Del values keys
 func (rd redisStore) Del(ctx context.Context, ks ...string) error {
 	if _, err := rd.client.Del(ctx, ks...).Result(); err != nil {
@@ -62,3 +127,8 @@ func (rd redisStore) FlushAll(ctx context.Context) error {
 
 	return nil
 }
+
+// Close leaves the client open, it is shared
+func (redisStore) Close() error {
+	return nil
+}
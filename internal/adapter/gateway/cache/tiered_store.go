@@ -0,0 +1,289 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"hash/maphash"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-app/internal/domain/gateway"
+	"go-app/pkg/errors"
+	"go-app/pkg/logger"
+	"go-app/pkg/lru"
+	"go-app/pkg/utils"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// invalidation is the message published on every write, the other nodes drop the keys from their local tier
+type invalidation struct {
+	Node  string   `json:"node"`
+	Keys  []string `json:"keys,omitempty"`
+	Flush bool     `json:"flush,omitempty"`
+}
+
+// generationStripes is number of write generations of the local tier, a key uses the one of its hash so the
+// generations take a fixed memory
+const generationStripes = 1024
+
+// tieredStore is a local memory tier in front of Redis, the local entries live at most localTTL and are
+// invalidated through Redis pub/sub when any node writes them. The generation of a key changes on every write of
+// it, a value read from Redis is kept locally only when the key was not written meanwhile
+type tieredStore struct {
+	local       *lru.Cache[[]byte]
+	remote      redisStore
+	channel     string
+	node        string
+	localTTL    time.Duration
+	seed        maphash.Seed
+	generations [generationStripes]atomic.Uint64
+	sub         *redis.PubSub
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+// NewTieredStore create cache instance with a local tier of at most size keys in front of redis, the
+// invalidations are published on channel and applied until Close
+func NewTieredStore(rd *redis.Client, size int, localTTL time.Duration, channel string) gateway.Cache {
+	s := &tieredStore{
+		local:    lru.New[[]byte](size),
+		remote:   redisStore{client: rd},
+		channel:  channel,
+		node:     utils.GenerateUUID(),
+		localTTL: localTTL,
+		seed:     maphash.MakeSeed(),
+		sub:      rd.Subscribe(context.Background(), channel),
+		done:     make(chan struct{}),
+	}
+	go s.subscribe()
+
+	return s
+}
+
+// Get value from key, from the local tier when it holds the key
+func (s *tieredStore) Get(ctx context.Context, k string) ([]byte, error) {
+	if v, ok := s.local.Get(k); ok {
+		return bytes.Clone(v), nil
+	}
+
+	values, err := s.fetch(ctx, k)
+	if err != nil {
+		return []byte{}, err
+	}
+	v, ok := values[k]
+	if !ok {
+		return []byte{}, errors.ErrRedisKeyNotFound.Trace()
+	}
+
+	return v, nil
+}
+
+// GetMany values of keys, the missing keys are left out
+func (s *tieredStore) GetMany(ctx context.Context, ks ...string) (map[string][]byte, error) {
+	values := make(map[string][]byte, len(ks))
+	var missing []string
+	for _, k := range ks {
+		if v, ok := s.local.Get(k); ok {
+			values[k] = bytes.Clone(v)
+		} else {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) == 0 {
+		return values, nil
+	}
+
+	fetched, err := s.fetch(ctx, missing...)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range fetched {
+		values[k] = v
+	}
+
+	return values, nil
+}
+
+// Set value by key and duration time in both tiers
+func (s *tieredStore) Set(ctx context.Context, k string, v any, exp time.Duration) error {
+	b, err := encode(v)
+	if err != nil {
+		return errors.ErrInternalServerError.Wrap(err)
+	}
+	if err := s.remote.Set(ctx, k, b, exp); err != nil {
+		return err
+	}
+	s.generation(k).Add(1)
+	s.local.Set(k, bytes.Clone(b), s.ttl(exp))
+	s.publish(ctx, invalidation{Keys: []string{k}})
+
+	return nil
+}
+
+// SetNX value by key and duration time unless the key exists, it reports whether the value was set
+func (s *tieredStore) SetNX(ctx context.Context, k string, v any, exp time.Duration) (bool, error) {
+	ok, err := s.remote.SetNX(ctx, k, v, exp)
+	if err != nil || !ok {
+		return ok, err
+	}
+	s.invalidate(ctx, k)
+
+	return true, nil
+}
+
+// Incr the counter of key in Redis, the duration time is set when the counter is created
+func (s *tieredStore) Incr(ctx context.Context, k string, exp time.Duration) (int64, error) {
+	n, err := s.remote.Incr(ctx, k, exp)
+	if err != nil {
+		return 0, err
+	}
+	s.invalidate(ctx, k)
+
+	return n, nil
+}
+
+// TTL remaining of key in Redis, zero when the key never expires
+func (s *tieredStore) TTL(ctx context.Context, k string) (time.Duration, error) {
+	return s.remote.TTL(ctx, k)
+}
+
+// Del values keys of both tiers
+func (s *tieredStore) Del(ctx context.Context, ks ...string) error {
+	if err := s.remote.Del(ctx, ks...); err != nil {
+		return err
+	}
+	s.invalidate(ctx, ks...)
+
+	return nil
+}
+
+// FlushAll flush all data of both tiers
+func (s *tieredStore) FlushAll(ctx context.Context) error {
+	if err := s.remote.FlushAll(ctx); err != nil {
+		return err
+	}
+	s.flush()
+	s.publish(ctx, invalidation{Flush: true})
+
+	return nil
+}
+
+// Close stops applying the invalidations of the other nodes
+func (s *tieredStore) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		err = s.sub.Close()
+		<-s.done
+	})
+	if err != nil {
+		return errors.ErrRedisConnection.Wrap(err)
+	}
+
+	return nil
+}
+
+// fetch reads the keys and their time to live from Redis and keeps the found ones locally
+func (s *tieredStore) fetch(ctx context.Context, ks ...string) (map[string][]byte, error) {
+	generations := make([]uint64, len(ks))
+	for i, k := range ks {
+		generations[i] = s.generation(k).Load()
+	}
+	gets := make([]*redis.StringCmd, len(ks))
+	ttls := make([]*redis.DurationCmd, len(ks))
+	_, err := s.remote.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, k := range ks {
+			gets[i] = pipe.Get(ctx, k)
+			ttls[i] = pipe.PTTL(ctx, k)
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, errors.ErrRedisConnection.Wrap(err)
+	}
+
+	values := make(map[string][]byte, len(ks))
+	for i, k := range ks {
+		v, err := gets[i].Bytes()
+		if err != nil {
+			continue
+		}
+		values[k] = v
+		ttl := ttls[i].Val()
+		if ttl == -1 {
+			ttl = 0
+		}
+		if ttl >= 0 && s.generation(k).Load() == generations[i] {
+			s.local.Set(k, bytes.Clone(v), s.ttl(ttl))
+		}
+	}
+
+	return values, nil
+}
+
+// invalidate drops the keys locally and on the other nodes
+func (s *tieredStore) invalidate(ctx context.Context, ks ...string) {
+	s.drop(ks...)
+	s.publish(ctx, invalidation{Keys: ks})
+}
+
+// drop drops the keys locally, the reads in progress do not keep them
+func (s *tieredStore) drop(ks ...string) {
+	for _, k := range ks {
+		s.generation(k).Add(1)
+	}
+	s.local.Delete(ks...)
+}
+
+// flush drops every key locally, the reads in progress do not keep them
+func (s *tieredStore) flush() {
+	for i := range s.generations {
+		s.generations[i].Add(1)
+	}
+	s.local.Purge()
+}
+
+// generation returns the write generation of the key
+func (s *tieredStore) generation(k string) *atomic.Uint64 {
+	return &s.generations[maphash.String(s.seed, k)%generationStripes]
+}
+
+// publish sends the invalidation to the other nodes, they keep the keys at most localTTL when it is lost
+func (s *tieredStore) publish(ctx context.Context, msg invalidation) {
+	msg.Node = s.node
+	b, err := json.Marshal(msg)
+	if err == nil {
+		err = s.remote.client.Publish(ctx, s.channel, b).Err()
+	}
+	if err != nil {
+		logger.WarnContext(ctx, "cache invalidation publish", "error", err)
+	}
+}
+
+// subscribe applies the invalidations of the other nodes until the subscription is closed
+func (s *tieredStore) subscribe() {
+	defer close(s.done)
+	for m := range s.sub.Channel() {
+		var msg invalidation
+		if err := json.Unmarshal([]byte(m.Payload), &msg); err != nil || msg.Node == s.node {
+			continue
+		}
+		if msg.Flush {
+			s.flush()
+			continue
+		}
+		s.drop(msg.Keys...)
+	}
+}
+
+// ttl returns the local time to live of a value expiring after exp, zero exp never expires
+func (s *tieredStore) ttl(exp time.Duration) time.Duration {
+	if exp <= 0 || exp > s.localTTL {
+		return s.localTTL
+	}
+
+	return exp
+}
@@ -0,0 +1,72 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go-app/internal/domain/event"
+	"go-app/internal/domain/gateway"
+	"go-app/pkg/errors"
+	"go-app/pkg/logger"
+)
+
+// bus ...
+type bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]event.Handler
+	next     []gateway.EventPublisher
+}
+
+// NewBus will implement of gateway.EventBus interface, the events are also forwarded to
+// the next publishers such as an external broker
+func NewBus(next ...gateway.EventPublisher) gateway.EventBus {
+	return &bus{
+		handlers: map[string][]event.Handler{},
+		next:     next,
+	}
+}
+
+// Subscribe registers handler for the events named name
+func (b *bus) Subscribe(name string, handler event.Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish calls every handler of the event then the next publishers, all of them are called
+// even when one fails, so the handlers are retried together and must be idempotent
+func (b *bus) Publish(ctx context.Context, env event.Envelope) error {
+	b.mu.RLock()
+	handlers := b.handlers[env.Name]
+	b.mu.RUnlock()
+
+	errs := []error{}
+	for _, h := range handlers {
+		if err := b.call(ctx, h, env); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, p := range b.next {
+		if err := p.Publish(ctx, env); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(handlers) == 0 && len(b.next) == 0 {
+		logger.DebugContext(ctx, "event without subscriber", "event", env.Name, "id", env.ID)
+	}
+
+	return errors.Join(errs...)
+}
+
+// call runs the handler, a panic is returned as an error
+func (*bus) call(ctx context.Context, h event.Handler, env event.Envelope) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("event handler panic: %v", r)
+		}
+	}()
+
+	return h(ctx, env)
+}
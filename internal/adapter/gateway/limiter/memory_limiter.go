@@ -0,0 +1,58 @@
+package limiter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-app/internal/domain/gateway"
+	"go-app/pkg/lru"
+	"go-app/pkg/ratelimit"
+)
+
+// memoryLimiter keeps the theoretical arrival time of the keys in the process memory, the limits are per
+// process, the least recently used keys are evicted over its size
+type memoryLimiter struct {
+	mu   sync.Mutex
+	tats *lru.Cache[time.Time]
+	now  func() time.Time
+}
+
+// NewMemoryLimiter create rate limiter in memory tracking at most size keys
+func NewMemoryLimiter(size int) gateway.RateLimiter {
+	return &memoryLimiter{
+		tats: lru.New[time.Time](size),
+		now:  time.Now,
+	}
+}
+
+// Allow spends a request of key
+func (m *memoryLimiter) Allow(
+	_ context.Context,
+	key string,
+	limit int,
+	period time.Duration,
+) (*gateway.RateLimit, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	tat, _ := m.tats.Get(key)
+	res, tat := ratelimit.Take(now, tat, limit, period)
+	if res.Allowed {
+		m.tats.Set(key, tat, tat.Sub(now))
+	}
+
+	return toRateLimit(res), nil
+}
+
+// toRateLimit converts the result of the algorithm
+func toRateLimit(res ratelimit.Result) *gateway.RateLimit {
+	return &gateway.RateLimit{
+		Allowed:    res.Allowed,
+		Limit:      res.Limit,
+		Remaining:  res.Remaining,
+		RetryAfter: res.RetryAfter,
+		ResetAfter: res.ResetAfter,
+	}
+}
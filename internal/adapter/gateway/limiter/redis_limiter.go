@@ -0,0 +1,81 @@
+package limiter
+
+import (
+	"context"
+	"time"
+
+	"go-app/internal/domain/gateway"
+	"go-app/pkg/errors"
+	"go-app/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeScript spends a token of the bucket of the key with the generic cell rate algorithm of
+// ratelimit.Take, the time is the clock of redis so every instance shares it, times are in microseconds
+//
+// KEYS: theoretical arrival time. ARGV: limit, period.
+// Returns allowed, remaining, retry after and reset after.
+var takeScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local interval = math.floor(period / limit)
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000000 + tonumber(clock[2])
+
+local tat = tonumber(redis.call('GET', KEYS[1]) or now)
+if tat < now then
+	tat = now
+end
+
+local allow_at = tat + interval - period
+if now < allow_at then
+	return {0, 0, allow_at - now, tat - now}
+end
+
+tat = tat + interval
+redis.call('SET', KEYS[1], string.format('%d', tat), 'PX', math.ceil((tat - now) / 1000))
+
+return {1, math.min(limit - 1, math.floor((now - allow_at) / interval)), 0, tat - now}
+`)
+
+// redisLimiter keeps the theoretical arrival time of the keys in redis, the limits are shared by every instance
+type redisLimiter struct {
+	client   *redis.Client
+	fallback gateway.RateLimiter
+}
+
+// NewRedisLimiter create rate limiter with redis, the requests are limited by fallback while redis fails,
+// the errors are returned with a nil fallback
+func NewRedisLimiter(rd *redis.Client, fallback gateway.RateLimiter) gateway.RateLimiter {
+	return &redisLimiter{
+		client:   rd,
+		fallback: fallback,
+	}
+}
+
+// Allow spends a request of key
+func (rl *redisLimiter) Allow(
+	ctx context.Context,
+	key string,
+	limit int,
+	period time.Duration,
+) (*gateway.RateLimit, error) {
+	res, err := takeScript.Run(ctx, rl.client, []string{key}, limit, period.Microseconds()).Int64Slice()
+	if err != nil {
+		if rl.fallback == nil {
+			return nil, errors.ErrRedisConnection.Wrap(err)
+		}
+		logger.WarnContext(ctx, "rate limiter falls back to memory", "error", err.Error())
+
+		return rl.fallback.Allow(ctx, key, limit, period)
+	}
+
+	return &gateway.RateLimit{
+		Allowed:    res[0] == 1,
+		Limit:      limit,
+		Remaining:  int(res[1]),
+		RetryAfter: time.Duration(res[2]) * time.Microsecond,
+		ResetAfter: time.Duration(res[3]) * time.Microsecond,
+	}, nil
+}
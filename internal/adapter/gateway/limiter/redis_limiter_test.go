@@ -0,0 +1,85 @@
+package limiter_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-app/internal/adapter/gateway/limiter"
+	"go-app/pkg/ratelimit"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// TestRedisLimiterMatchesTake runs the script of the redis limiter at the times of the requests and checks it
+// answers as ratelimit.Take
+func TestRedisLimiterMatchesTake(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	srv := miniredis.RunT(t)
+	rd := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { _ = rd.Close() })
+	rl := limiter.NewRedisLimiter(rd, nil)
+
+	expectedResults := []struct {
+		limit    int
+		period   time.Duration
+		requests []time.Duration
+	}{
+		{3, time.Minute, []time.Duration{0, 0, 0, 0, 15 * time.Second, 20 * time.Second, 2 * time.Minute}},
+		{
+			7,
+			time.Second * 10,
+			[]time.Duration{0, 0, 0, 0, 0, 0, 0, 0, time.Second, 1428572 * time.Microsecond, 2 * time.Second},
+		},
+		{1, time.Second, []time.Duration{0, 0, 999 * time.Millisecond, time.Second, time.Second}},
+	}
+
+	start := time.Unix(1700000000, 0)
+	for testNumber, testExpected := range expectedResults {
+		key := "limit:" + string(rune('a'+testNumber))
+		var tat time.Time
+		now := start
+		srv.SetTime(now)
+		for i, elapsed := range testExpected.requests {
+			at := start.Add(elapsed)
+			// The keys expire with the clock of the server
+			srv.FastForward(at.Sub(now))
+			srv.SetTime(at)
+			now = at
+
+			got, err := rl.Allow(ctx, key, testExpected.limit, testExpected.period)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var expected ratelimit.Result
+			expected, next := ratelimit.Take(now, tat, testExpected.limit, testExpected.period)
+			if expected.Allowed {
+				tat = next
+			}
+			if got.Allowed != expected.Allowed || got.Limit != expected.Limit ||
+				got.Remaining != expected.Remaining || got.RetryAfter != expected.RetryAfter ||
+				got.ResetAfter != expected.ResetAfter {
+				t.Errorf("#%d request %d got %+v, %+v expected", testNumber, i, got, expected)
+			}
+		}
+	}
+}
+
+func TestRedisLimiterFallback(t *testing.T) {
+	t.Parallel()
+	rd := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1, DialerRetries: 1})
+	t.Cleanup(func() { _ = rd.Close() })
+
+	if _, err := limiter.NewRedisLimiter(rd, nil).Allow(context.Background(), "k", 1, time.Minute); err == nil {
+		t.Error("got no error without redis and fallback")
+	}
+	rl := limiter.NewRedisLimiter(rd, limiter.NewMemoryLimiter(0))
+	for i, expected := range []bool{true, false} {
+		res, err := rl.Allow(context.Background(), "k", 1, time.Minute)
+		if err != nil || res.Allowed != expected {
+			t.Errorf("#%d got %+v %v, allowed %v by the fallback expected", i, res, err, expected)
+		}
+	}
+}
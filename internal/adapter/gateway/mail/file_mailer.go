@@ -0,0 +1,49 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/config"
+	"go-app/internal/infrastructure/constant"
+	"go-app/pkg/errors"
+	"go-app/pkg/mailer"
+	"go-app/pkg/utils"
+)
+
+// fileTransport writes every message as an .eml file of the directory
+type fileTransport struct {
+	dir string
+}
+
+// NewFileMailer creates a mail service writing the mails to the MAIL_FILE_PATH directory, they open in
+// any mail client
+func NewFileMailer(conf config.Email) (gateway.MailService, error) {
+	dir := conf.FilePath
+	if dir == "" {
+		dir = constant.MailFilePath
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.ErrInternalServerError.Wrap(err)
+	}
+
+	svc, err := newService(conf.From, &fileTransport{dir: dir})
+	if err != nil {
+		return nil, err
+	}
+
+	return svc, nil
+}
+
+// deliver writes the message to <date>_<uuid>.eml, the names sort by sending time
+func (t *fileTransport) deliver(_ context.Context, msg *mailer.Message) error {
+	data, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+	name := msg.Date.Format("20060102T150405.000000000") + "_" + utils.GenerateUUID() + ".eml"
+
+	return os.WriteFile(filepath.Join(t.dir, name), data, 0o644)
+}
@@ -0,0 +1,34 @@
+package mail
+
+import (
+	"context"
+
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/config"
+	"go-app/pkg/logger"
+	"go-app/pkg/mailer"
+)
+
+// logTransport writes the messages to the logger instead of sending them
+type logTransport struct{}
+
+// NewLogMailer creates a mail service logging the mails, for local runs
+func NewLogMailer(conf config.Email) (gateway.MailService, error) {
+	svc, err := newService(conf.From, logTransport{})
+	if err != nil {
+		return nil, err
+	}
+
+	return svc, nil
+}
+
+// deliver logs the recipients and the subject of the message, its content carries tokens and links which must
+// not reach the logs
+func (logTransport) deliver(ctx context.Context, msg *mailer.Message) error {
+	logger.InfoContext(ctx, "mail",
+		"to", msg.To,
+		"subject", msg.Subject,
+	)
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package mail
+
+import (
+	"context"
+	"net/mail"
+	"time"
+
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/pkg/errors"
+	"go-app/pkg/mailer"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// transport delivers an addressed message, it is the part of a driver which differs
+type transport interface {
+	deliver(ctx context.Context, msg *mailer.Message) error
+}
+
+// service renders and addresses the messages, then hands them to the transport of the driver
+type service struct {
+	from      string
+	templates *mailer.Templates
+	transport transport
+}
+
+// newService returns the mail service of the transport sending from from
+func newService(from string, t transport) (*service, error) {
+	templates, err := newTemplates()
+	if err != nil {
+		return nil, errors.ErrInternalServerError.Wrap(err)
+	}
+
+	return &service{
+		from:      from,
+		templates: templates,
+		transport: t,
+	}, nil
+}
+
+// Send a plain text email
+func (s *service) Send(ctx context.Context, subj, body string, to []string) error {
+	return s.send(ctx, &mailer.Message{To: to, Subject: subj, Text: body})
+}
+
+// SendTemplate renders the template name in the variant of locale and sends it as text and html
+func (s *service) SendTemplate(ctx context.Context, name, locale string, data any, to []string) error {
+	msg, err := s.templates.Render(name, locale, data)
+	if err != nil {
+		return errors.ErrInternalServerError.Wrap(err)
+	}
+	msg.To = to
+
+	return s.send(ctx, msg)
+}
+
+// SendMail sends the mail with its copies and attachments
+func (s *service) SendMail(ctx context.Context, m *gateway.Mail) error {
+	msg := &mailer.Message{
+		To:      m.To,
+		Cc:      m.Cc,
+		Bcc:     m.Bcc,
+		ReplyTo: m.ReplyTo,
+		Subject: m.Subject,
+		Text:    m.Text,
+		HTML:    m.HTML,
+	}
+	for _, a := range m.Attachments {
+		msg.Attachments = append(msg.Attachments, mailer.Attachment{
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Data:        a.Data,
+		})
+	}
+
+	return s.send(ctx, msg)
+}
+
+// send checks the addresses of the message and delivers it in a span of the trace of ctx
+func (s *service) send(ctx context.Context, msg *mailer.Message) error {
+	// Check to make sure there is at least one recipient and one "From" address
+	if s.from == "" || len(msg.To) == 0 {
+		return errors.ErrSendEmailFromToInvalid.Trace()
+	}
+	addresses := append([]string{s.from}, msg.Recipients()...)
+	if msg.ReplyTo != "" {
+		addresses = append(addresses, msg.ReplyTo)
+	}
+	for _, address := range addresses {
+		if _, err := mail.ParseAddress(address); err != nil {
+			return errors.ErrBadRequest.Wrap(err)
+		}
+	}
+
+	msg.From = s.from
+	msg.Date = time.Now()
+	ctx, span := tracing.Start(ctx, "mail.deliver",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.Int("mail.recipients", len(msg.Recipients()))),
+	)
+	err := s.transport.deliver(ctx, msg)
+	tracing.End(span, err)
+	if err != nil {
+		return errors.ErrSendEmailFailed.Wrap(err)
+	}
+
+	return nil
+}
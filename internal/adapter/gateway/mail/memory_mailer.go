@@ -0,0 +1,55 @@
+package mail
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"go-app/internal/infrastructure/config"
+	"go-app/pkg/mailer"
+)
+
+// MemoryMailer is a mail service keeping the sent messages in memory, tests inspect them with Messages
+type MemoryMailer struct {
+	*service
+	mu       sync.Mutex
+	messages []mailer.Message
+}
+
+// NewMemoryMailer creates a MemoryMailer
+func NewMemoryMailer(conf config.Email) (*MemoryMailer, error) {
+	m := &MemoryMailer{}
+	svc, err := newService(conf.From, m)
+	if err != nil {
+		return nil, err
+	}
+	m.service = svc
+
+	return m, nil
+}
+
+// deliver keeps the message
+func (m *MemoryMailer) deliver(_ context.Context, msg *mailer.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, *msg)
+
+	return nil
+}
+
+// Messages returns the sent messages, the oldest first
+func (m *MemoryMailer) Messages() []mailer.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.messages)
+}
+
+// Reset forgets the sent messages
+func (m *MemoryMailer) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = nil
+}
@@ -1,77 +1,196 @@
 package mail
 
 import (
-	"bytes"
 	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
 	"net/mail"
 	"net/smtp"
 	"strconv"
-	"strings"
+	"time"
 
 	"go-app/internal/domain/gateway"
 	"go-app/internal/infrastructure/config"
+	"go-app/internal/infrastructure/constant"
 	"go-app/pkg/errors"
+	"go-app/pkg/mailer"
 )
 
-// Email is the type used for email messages github.com/jordan-wright/email
-type Email struct {
-	From    string
-	To      []string
-	Subject string
-	Body    string // Plaintext message or Html message (optional)
-	auth    smtp.Auth
-	addr    string
+// smtpTransport delivers the messages to an SMTP server, in plain text, upgraded with STARTTLS or over
+// implicit TLS
+type smtpTransport struct {
+	host       string
+	addr       string
+	username   string
+	password   string
+	encryption string
+	timeout    time.Duration
 }
 
-// NewEmail creates an Email, and returns the pointer to it.
-func NewSMTPEmail() gateway.MailService {
-	mailConf := config.GetEmailConfig()
+// NewSMTPEmail creates a mail service sending with the SMTP server of conf
+func NewSMTPEmail(conf config.Email) (gateway.MailService, error) {
+	t, err := newSMTPTransport(conf)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := newService(conf.From, t)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc, nil
+}
 
-	return &Email{
-		From: mailConf.From,
-		auth: smtp.PlainAuth("", mailConf.Username, mailConf.Password, mailConf.Host),
-		addr: mailConf.Host + ":" + strconv.Itoa(mailConf.Port),
+// NewSMTPHealthChecker creates a health checker opening a session with the SMTP server of conf, with STARTTLS
+// and the authentication of a delivery
+func NewSMTPHealthChecker(conf config.Email) (gateway.HealthChecker, error) {
+	t, err := newSMTPTransport(conf)
+	if err != nil {
+		return nil, err
 	}
+
+	return gateway.HealthCheckFunc(t.check), nil
 }
 
-// msg converts the Email object to a []byte representation, including all needed MIMEHeaders, boundaries, etc.
-func (e *Email) msg() []byte {
-	buff := &bytes.Buffer{}
-	buff.WriteString("To: " + strings.Join(e.To, ", ") + "\r\n")
-	buff.WriteString("From: " + e.From + "\r\n")
-	buff.WriteString("Subject: " + e.Subject + "\r\n")
-	buff.WriteString("MIME-Version: 1.0\r\n")
-	buff.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
-	buff.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
-	buff.WriteString("\r\n" + e.Body)
-
-	return buff.Bytes()
+// newSMTPTransport returns the transport of conf, zero values fall back to the defaults
+func newSMTPTransport(conf config.Email) (*smtpTransport, error) {
+	timeout := conf.Timeout
+	if timeout <= 0 {
+		timeout = constant.MailTimeout
+	}
+	encryption := conf.Encryption
+	if encryption == "" {
+		encryption = constant.MailEncryptionNone
+	}
+	switch encryption {
+	case constant.MailEncryptionNone, constant.MailEncryptionStartTLS, constant.MailEncryptionTLS:
+	default:
+		return nil, errors.ErrInternalServerError.Wrap(fmt.Errorf("unknown mail encryption %q", encryption))
+	}
+
+	return &smtpTransport{
+		host:       conf.Host,
+		addr:       net.JoinHostPort(conf.Host, strconv.Itoa(conf.Port)),
+		username:   conf.Username,
+		password:   conf.Password,
+		encryption: encryption,
+		timeout:    timeout,
+	}, nil
 }
 
-// Send an email using the given host and SMTP auth (optional), returns any error thrown by smtp.SendMail
-func (e *Email) Send(_ context.Context, subj, body string, to []string) error {
-	e.Subject = subj
-	e.Body = body
-	e.To = to
+// deliver sends the message in one SMTP session bounded by the timeout
+func (t *smtpTransport) deliver(ctx context.Context, msg *mailer.Message) error {
+	data, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	c, err := t.session(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
 
-	for i := range e.To {
-		addr, err := mail.ParseAddress(e.To[i])
+	from, err := mail.ParseAddress(msg.From)
+	if err != nil {
+		return err
+	}
+	if err := c.Mail(from.Address); err != nil {
+		return err
+	}
+	for _, recipient := range msg.Recipients() {
+		addr, err := mail.ParseAddress(recipient)
 		if err != nil {
-			return errors.ErrBadRequest.Wrap(err)
+			return err
+		}
+		if err := c.Rcpt(addr.Address); err != nil {
+			return err
 		}
-		to = append(to, addr.Address)
 	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+// check opens a session with the server and quits, bounded by the timeout
+func (t *smtpTransport) check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	c, err := t.session(ctx)
+	if err != nil {
+		return errors.ErrSendEmailFailed.Wrap(err)
+	}
+	defer c.Close()
+	if err := c.Quit(); err != nil {
+		return errors.ErrSendEmailFailed.Wrap(err)
+	}
+
+	return nil
+}
 
-	from, err := mail.ParseAddress(e.From)
+// session connects to the server until the deadline of ctx, upgrades it with STARTTLS and authenticates
+func (t *smtpTransport) session(ctx context.Context) (*smtp.Client, error) {
+	conn, err := t.dial(ctx)
 	if err != nil {
-		return errors.ErrBadRequest.Wrap(err)
+		return nil, err
 	}
-	msg := e.msg()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	c, err := smtp.NewClient(conn, t.host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if t.encryption == constant.MailEncryptionStartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			c.Close()
+			return nil, fmt.Errorf("smtp server %s does not support STARTTLS", t.addr)
+		}
+		if err := c.StartTLS(&tls.Config{ServerName: t.host, MinVersion: tls.VersionTLS12}); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	if t.username != "" {
+		if ok, _ := c.Extension("AUTH"); ok {
+			if err := c.Auth(smtp.PlainAuth("", t.username, t.password, t.host)); err != nil {
+				c.Close()
+				return nil, err
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// dial connects to the server, over TLS with the implicit TLS encryption
+func (t *smtpTransport) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{}
+	if t.encryption == constant.MailEncryptionTLS {
+		tlsDialer := &tls.Dialer{
+			NetDialer: dialer,
+			Config:    &tls.Config{ServerName: t.host, MinVersion: tls.VersionTLS12},
+		}
 
-	// Check to make sure there is at least one recipient and one "From" address
-	if e.From == "" || len(to) == 0 {
-		return errors.ErrSendEmailFromToInvalid.Trace()
+		return tlsDialer.DialContext(ctx, "tcp", t.addr)
 	}
 
-	return smtp.SendMail(e.addr, e.auth, from.Address, to, msg)
+	return dialer.DialContext(ctx, "tcp", t.addr)
 }
@@ -0,0 +1,28 @@
+package mail
+
+import (
+	"embed"
+	"io/fs"
+
+	"go-app/internal/infrastructure/config"
+	"go-app/internal/infrastructure/constant"
+	"go-app/pkg/mailer"
+)
+
+// templateFS holds the layouts and the <locale>/<name>.txt|html templates of the mails
+//
+//go:embed templates
+var templateFS embed.FS
+
+// newTemplates parses the embedded templates
+func newTemplates() (*mailer.Templates, error) {
+	fsys, err := fs.Sub(templateFS, "templates")
+	if err != nil {
+		return nil, err
+	}
+	funcs := map[string]any{
+		"appName": func() string { return config.GetAppConfig().AppName },
+	}
+
+	return mailer.NewTemplates(fsys, constant.MailDefaultLocale, funcs)
+}
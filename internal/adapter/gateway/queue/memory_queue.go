@@ -0,0 +1,177 @@
+package queue
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/constant"
+	"go-app/pkg/errors"
+	"go-app/pkg/utils"
+)
+
+const (
+	// memoryPollInterval is the wait between two reservations when the queue is empty
+	memoryPollInterval = time.Millisecond * 10
+	// reclaimError is the last error of a job whose visibility timeout is over, as after a crash of its worker
+	reclaimError = "visibility timeout expired"
+)
+
+// memoryQueue is a job queue living in the process, it is lost on exit and meant for tests
+// and a single process setup
+type memoryQueue struct {
+	mu          sync.Mutex
+	visibility  time.Duration
+	maxAttempts int
+	jobs        map[string]gateway.Job
+	ready       []string
+	delayed     map[string]time.Time
+	processing  map[string]time.Time
+	dead        []gateway.Job
+}
+
+// NewMemoryQueue create job queue in memory, visibility is the time a reserved job is hidden, maxAttempts
+// applies to the reclaimed jobs without their own
+func NewMemoryQueue(visibility time.Duration, maxAttempts int) gateway.JobQueue {
+	if maxAttempts <= 0 {
+		maxAttempts = constant.JobMaxAttempts
+	}
+
+	return &memoryQueue{
+		visibility:  visibility,
+		maxAttempts: maxAttempts,
+		jobs:        map[string]gateway.Job{},
+		delayed:     map[string]time.Time{},
+		processing:  map[string]time.Time{},
+	}
+}
+
+// Enqueue stores the job, it is ready at once unless RunAt is in the future
+func (q *memoryQueue) Enqueue(_ context.Context, job *gateway.Job) error {
+	if job.ID == "" {
+		job.ID = utils.GenerateUUID()
+	}
+	if job.CreatedAt.IsZero() {
+		job.CreatedAt = time.Now()
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.jobs[job.ID] = *job
+	if job.RunAt.After(time.Now()) {
+		q.delayed[job.ID] = job.RunAt
+	} else {
+		q.ready = append(q.ready, job.ID)
+	}
+
+	return nil
+}
+
+// Reserve pops the next job, it waits up to wait and returns nil when no job is ready
+func (q *memoryQueue) Reserve(ctx context.Context, wait time.Duration) (*gateway.Job, error) {
+	deadline := time.Now().Add(wait)
+	for {
+		if job := q.pop(); job != nil {
+			return job, nil
+		}
+
+		if !time.Now().Before(deadline) {
+			return nil, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.Throw(ctx.Err())
+		case <-time.After(min(memoryPollInterval, time.Until(deadline))):
+		}
+	}
+}
+
+// Ack removes the job which is done
+func (q *memoryQueue) Ack(_ context.Context, job *gateway.Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.processing, job.ID)
+	delete(q.jobs, job.ID)
+
+	return nil
+}
+
+// Retry stores the job with its attempts and schedules it at runAt
+func (q *memoryQueue) Retry(_ context.Context, job *gateway.Job, runAt time.Time) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job.RunAt = runAt
+	delete(q.processing, job.ID)
+	q.jobs[job.ID] = *job
+	q.delayed[job.ID] = runAt
+
+	return nil
+}
+
+// Fail moves the job to the dead jobs
+func (q *memoryQueue) Fail(_ context.Context, job *gateway.Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.processing, job.ID)
+	delete(q.jobs, job.ID)
+	q.dead = append(q.dead, *job)
+
+	return nil
+}
+
+// pop moves the due jobs to ready, reclaims the expired ones as the redis queue does and reserves the first one
+func (q *memoryQueue) pop() *gateway.Job {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	q.ready = append(q.ready, due(q.delayed, now)...)
+	for _, id := range due(q.processing, now) {
+		job := q.jobs[id]
+		job.Attempts++
+		job.LastError = reclaimError
+		maxAttempts := q.maxAttempts
+		if job.MaxAttempts > 0 {
+			maxAttempts = job.MaxAttempts
+		}
+		if job.Attempts >= maxAttempts {
+			delete(q.jobs, id)
+			q.dead = append(q.dead, job)
+			continue
+		}
+		q.jobs[id] = job
+		q.ready = append(q.ready, id)
+	}
+	if len(q.ready) == 0 {
+		return nil
+	}
+
+	id := q.ready[0]
+	q.ready = q.ready[1:]
+	q.processing[id] = now.Add(q.visibility)
+	job := q.jobs[id]
+
+	return &job
+}
+
+// due removes the ids whose time is over from set and returns them in time order
+func due(set map[string]time.Time, now time.Time) []string {
+	ids := []string{}
+	for id, t := range set {
+		if !t.After(now) {
+			ids = append(ids, id)
+		}
+	}
+	slices.SortFunc(ids, func(a, b string) int { return set[a].Compare(set[b]) })
+	for _, id := range ids {
+		delete(set, id)
+	}
+
+	return ids
+}
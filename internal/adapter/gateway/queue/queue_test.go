@@ -0,0 +1,189 @@
+package queue_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go-app/internal/adapter/gateway/queue"
+	"go-app/internal/domain/gateway"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	visibility = time.Millisecond * 100
+	// maxAttempts is the default max attempts of the queues
+	maxAttempts = 2
+)
+
+// drivers returns a queue of every driver, the redis one with its client
+func drivers(t *testing.T) (map[string]gateway.JobQueue, *redis.Client) {
+	t.Helper()
+	rd := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	t.Cleanup(func() { _ = rd.Close() })
+
+	return map[string]gateway.JobQueue{
+		"memory": queue.NewMemoryQueue(visibility, maxAttempts),
+		"redis":  queue.NewRedisQueue(rd, "test", visibility, maxAttempts),
+	}, rd
+}
+
+// reserve reserves a job waiting up to wait, nil for none
+func reserve(t *testing.T, q gateway.JobQueue, wait time.Duration) *gateway.Job {
+	t.Helper()
+	job, err := q.Reserve(context.Background(), wait)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return job
+}
+
+func TestQueueDelayedAndRetried(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	queues, _ := drivers(t)
+
+	for name, q := range queues {
+		ready := &gateway.Job{Type: "ready"}
+		delayed := &gateway.Job{Type: "delayed", RunAt: time.Now().Add(time.Millisecond * 50)}
+		for _, job := range []*gateway.Job{delayed, ready} {
+			if err := q.Enqueue(ctx, job); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if job := reserve(t, q, 0); job == nil || job.ID != ready.ID {
+			t.Fatalf("%s got %+v, the ready job expected", name, job)
+		}
+		if job := reserve(t, q, 0); job != nil {
+			t.Errorf("%s got %+v before the delayed job is due", name, job)
+		}
+		if err := q.Ack(ctx, ready); err != nil {
+			t.Fatal(err)
+		}
+
+		job := reserve(t, q, time.Second)
+		if job == nil || job.ID != delayed.ID {
+			t.Fatalf("%s got %+v, the delayed job expected", name, job)
+		}
+		job.Attempts = 1
+		job.LastError = "failed"
+		if err := q.Retry(ctx, job, time.Now().Add(time.Millisecond*50)); err != nil {
+			t.Fatal(err)
+		}
+		if job := reserve(t, q, 0); job != nil {
+			t.Errorf("%s got %+v before the retry is due", name, job)
+		}
+		job = reserve(t, q, time.Second)
+		if job == nil || job.ID != delayed.ID || job.Attempts != 1 || job.LastError != "failed" {
+			t.Fatalf("%s got %+v, the retried job expected", name, job)
+		}
+		if err := q.Ack(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestQueueVisibilityTimeout(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	queues, _ := drivers(t)
+
+	for name, q := range queues {
+		crash := &gateway.Job{Type: "crash", Payload: []byte(`{"to":"a/b"}`), Trace: map[string]string{"traceparent": "00-1"}}
+		if err := q.Enqueue(ctx, crash); err != nil {
+			t.Fatal(err)
+		}
+		first := reserve(t, q, 0)
+		if first == nil {
+			t.Fatalf("%s got no job", name)
+		}
+		if job := reserve(t, q, 0); job != nil {
+			t.Errorf("%s got %+v while the job is reserved", name, job)
+		}
+		// The job is not acknowledged, as by a crashed worker, the lost attempt is counted
+		job := reserve(t, q, time.Second)
+		if job == nil || job.ID != first.ID || job.Attempts != 1 || job.LastError != "visibility timeout expired" {
+			t.Fatalf("%s got %+v, the job given back after the visibility timeout expected", name, job)
+		}
+		if string(job.Payload) != string(crash.Payload) || job.Trace["traceparent"] != "00-1" ||
+			!job.CreatedAt.Equal(crash.CreatedAt) {
+			t.Errorf("%s got %+v, the reclaimed job unchanged expected", name, job)
+		}
+	}
+}
+
+func TestQueueVisibilityTimeoutDead(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	queues, rd := drivers(t)
+
+	for name, q := range queues {
+		// The default max attempts applies to a job without its own
+		for _, job := range []*gateway.Job{{Type: "crash"}, {Type: "crash-once", MaxAttempts: 1}} {
+			if err := q.Enqueue(ctx, job); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for range 2 {
+			if job := reserve(t, q, 0); job == nil {
+				t.Fatalf("%s got no job", name)
+			}
+		}
+		if job := reserve(t, q, time.Second); job == nil || job.Type != "crash" || job.Attempts != 1 {
+			t.Fatalf("%s got %+v, the job with an attempt left expected", name, job)
+		}
+		if job := reserve(t, q, visibility*3); job != nil {
+			t.Errorf("%s got %+v, the jobs dead once their attempts are spent expected", name, job)
+		}
+	}
+
+	dead, err := rd.LRange(ctx, "queue:test:dead", 0, -1).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	attempts := map[string]int{}
+	for _, data := range dead {
+		job := gateway.Job{}
+		if err := json.Unmarshal([]byte(data), &job); err != nil {
+			t.Fatal(err)
+		}
+		attempts[job.Type] = job.Attempts
+	}
+	if len(dead) != 2 || attempts["crash"] != 2 || attempts["crash-once"] != 1 {
+		t.Errorf("got dead jobs %v", dead)
+	}
+}
+
+func TestQueueFail(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	queues, rd := drivers(t)
+
+	for name, q := range queues {
+		if err := q.Enqueue(ctx, &gateway.Job{Type: "dead"}); err != nil {
+			t.Fatal(err)
+		}
+		job := reserve(t, q, 0)
+		job.Attempts = 5
+		if err := q.Fail(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+		if job := reserve(t, q, visibility*2); job != nil {
+			t.Errorf("%s got %+v, the dead job is never reserved again", name, job)
+		}
+	}
+
+	dead, err := rd.LRange(ctx, "queue:test:dead", 0, -1).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var job gateway.Job
+	if len(dead) != 1 || json.Unmarshal([]byte(dead[0]), &job) != nil || job.Type != "dead" || job.Attempts != 5 {
+		t.Errorf("got dead jobs %v", dead)
+	}
+}
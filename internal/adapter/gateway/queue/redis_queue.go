@@ -0,0 +1,217 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/constant"
+	"go-app/pkg/errors"
+	"go-app/pkg/utils"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// redisPollInterval is the wait between two reservations when the queue is empty
+	redisPollInterval = time.Millisecond * 200
+	// redisDeadLength is the number of dead jobs kept
+	redisDeadLength = 1000
+)
+
+// reserveScript moves the due delayed jobs to the ready list and reclaims the jobs whose visibility timeout is
+// over, a reclaimed job counts the lost attempt and is moved to the dead list once its max attempts are spent,
+// then pops a ready job into the processing set until the deadline
+//
+// KEYS: ready, delayed, processing, jobs, dead. ARGV: now and deadline in milliseconds, default max attempts,
+// dead length and error of a reclaimed job
+var reserveScript = redis.NewScript(`
+local due = redis.call('ZRANGEBYSCORE', KEYS[2], '-inf', ARGV[1], 'LIMIT', 0, 100)
+for _, id in ipairs(due) do
+	redis.call('ZREM', KEYS[2], id)
+	redis.call('RPUSH', KEYS[1], id)
+end
+local expired = redis.call('ZRANGEBYSCORE', KEYS[3], '-inf', ARGV[1], 'LIMIT', 0, 100)
+for _, id in ipairs(expired) do
+	redis.call('ZREM', KEYS[3], id)
+	local data = redis.call('HGET', KEYS[4], id)
+	if data then
+		local reclaimed = cjson.decode(data)
+		reclaimed['attempts'] = (tonumber(reclaimed['attempts']) or 0) + 1
+		reclaimed['last_error'] = ARGV[5]
+		local max = tonumber(reclaimed['max_attempts']) or 0
+		if max <= 0 then
+			max = tonumber(ARGV[3])
+		end
+		data = cjson.encode(reclaimed)
+		if reclaimed['attempts'] >= max then
+			redis.call('HDEL', KEYS[4], id)
+			redis.call('LPUSH', KEYS[5], data)
+			redis.call('LTRIM', KEYS[5], 0, tonumber(ARGV[4]) - 1)
+		else
+			redis.call('HSET', KEYS[4], id, data)
+			redis.call('RPUSH', KEYS[1], id)
+		end
+	end
+end
+local id = redis.call('LPOP', KEYS[1])
+if not id then
+	return false
+end
+local job = redis.call('HGET', KEYS[4], id)
+if not job then
+	return false
+end
+redis.call('ZADD', KEYS[3], ARGV[2], id)
+return job
+`)
+
+// redisQueue is a job queue in Redis, the jobs are stored in a hash and their ids move between
+// the ready list, the delayed and the processing sorted sets
+type redisQueue struct {
+	client      *redis.Client
+	prefix      string
+	visibility  time.Duration
+	maxAttempts int
+}
+
+// NewRedisQueue create job queue named name in redis, visibility is the time a reserved job is
+// hidden from other workers, maxAttempts applies to the reclaimed jobs without their own
+func NewRedisQueue(rd *redis.Client, name string, visibility time.Duration, maxAttempts int) gateway.JobQueue {
+	if maxAttempts <= 0 {
+		maxAttempts = constant.JobMaxAttempts
+	}
+
+	return &redisQueue{
+		client:      rd,
+		prefix:      "queue:" + name + ":",
+		visibility:  visibility,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// Enqueue stores the job, it is ready at once unless RunAt is in the future
+func (q *redisQueue) Enqueue(ctx context.Context, job *gateway.Job) error {
+	if job.ID == "" {
+		job.ID = utils.GenerateUUID()
+	}
+	if job.CreatedAt.IsZero() {
+		job.CreatedAt = time.Now()
+	}
+	data, err := json.Marshal(job)
+	if err != nil {
+		return errors.ErrInternalServerError.Wrap(err)
+	}
+
+	_, err = q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, q.key("jobs"), job.ID, data)
+		if job.RunAt.After(time.Now()) {
+			pipe.ZAdd(ctx, q.key("delayed"), redis.Z{Score: float64(job.RunAt.UnixMilli()), Member: job.ID})
+		} else {
+			pipe.RPush(ctx, q.key("ready"), job.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.ErrRedisConnection.Wrap(err)
+	}
+
+	return nil
+}
+
+// Reserve pops the next job, it waits up to wait and returns nil when no job is ready
+func (q *redisQueue) Reserve(ctx context.Context, wait time.Duration) (*gateway.Job, error) {
+	deadline := time.Now().Add(wait)
+	for {
+		now := time.Now()
+		keys := []string{q.key("ready"), q.key("delayed"), q.key("processing"), q.key("jobs"), q.key("dead")}
+		data, err := reserveScript.Run(ctx, q.client, keys,
+			now.UnixMilli(), now.Add(q.visibility).UnixMilli(), q.maxAttempts, redisDeadLength, reclaimError,
+		).Text()
+		switch {
+		case err == nil:
+			job := &gateway.Job{}
+			if err := json.Unmarshal([]byte(data), job); err != nil {
+				return nil, errors.ErrInternalServerError.Wrap(err)
+			}
+			return job, nil
+		case !errors.Is(err, redis.Nil):
+			return nil, errors.ErrRedisConnection.Wrap(err)
+		}
+
+		if !now.Before(deadline) {
+			return nil, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.Throw(ctx.Err())
+		case <-time.After(min(redisPollInterval, time.Until(deadline))):
+		}
+	}
+}
+
+// Ack removes the job which is done
+func (q *redisQueue) Ack(ctx context.Context, job *gateway.Job) error {
+	_, err := q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, q.key("processing"), job.ID)
+		pipe.HDel(ctx, q.key("jobs"), job.ID)
+
+		return nil
+	})
+	if err != nil {
+		return errors.ErrRedisConnection.Wrap(err)
+	}
+
+	return nil
+}
+
+// Retry stores the job with its attempts and schedules it at runAt
+func (q *redisQueue) Retry(ctx context.Context, job *gateway.Job, runAt time.Time) error {
+	job.RunAt = runAt
+	data, err := json.Marshal(job)
+	if err != nil {
+		return errors.ErrInternalServerError.Wrap(err)
+	}
+
+	_, err = q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, q.key("processing"), job.ID)
+		pipe.HSet(ctx, q.key("jobs"), job.ID, data)
+		pipe.ZAdd(ctx, q.key("delayed"), redis.Z{Score: float64(runAt.UnixMilli()), Member: job.ID})
+
+		return nil
+	})
+	if err != nil {
+		return errors.ErrRedisConnection.Wrap(err)
+	}
+
+	return nil
+}
+
+// Fail moves the job to the dead list, the latest dead jobs are kept for inspection
+func (q *redisQueue) Fail(ctx context.Context, job *gateway.Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return errors.ErrInternalServerError.Wrap(err)
+	}
+
+	_, err = q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, q.key("processing"), job.ID)
+		pipe.HDel(ctx, q.key("jobs"), job.ID)
+		pipe.LPush(ctx, q.key("dead"), data)
+		pipe.LTrim(ctx, q.key("dead"), 0, redisDeadLength-1)
+
+		return nil
+	})
+	if err != nil {
+		return errors.ErrRedisConnection.Wrap(err)
+	}
+
+	return nil
+}
+
+// key returns the redis key of the part of the queue
+func (q *redisQueue) key(part string) string {
+	return q.prefix + part
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/metrics"
+)
+
+// authEvents is a struct that represent the authentication events counted by the metrics
+type authEvents struct{}
+
+// NewAuthEvents will create new an authEvents object representation of gateway.AuthEvents interface, every event
+// is exposed from zero
+func NewAuthEvents() gateway.AuthEvents {
+	for _, event := range []string{
+		gateway.AuthLoginSucceeded,
+		gateway.AuthLoginFailed,
+		gateway.AuthLoginBlocked,
+		gateway.AuthLockedOut,
+		gateway.AuthPasswordResetRequested,
+		gateway.AuthPasswordReset,
+		gateway.AuthSessionRevoked,
+		gateway.AuthRefreshTokenReused,
+	} {
+		metrics.AuthEvents.WithLabelValues(event)
+	}
+
+	return authEvents{}
+}
+
+// Record is a function to count the event
+func (authEvents) Record(event string) {
+	metrics.AuthEvents.WithLabelValues(event).Inc()
+}
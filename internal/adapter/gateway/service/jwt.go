@@ -6,55 +6,71 @@ import (
 
 	"go-app/internal/domain/entity"
 	"go-app/internal/domain/gateway"
-	"go-app/internal/infrastructure/config"
 	"go-app/internal/infrastructure/constant"
 	"go-app/pkg/errors"
+	"go-app/pkg/utils"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// revokedSessionPrefix is prefix of cache key for revoked session
+const revokedSessionPrefix = "session_revoked_"
+
 type CustomClaims struct {
-	ID     uint   `json:"id"`
-	Name   string `json:"name"`
-	Email  string `json:"email"`
-	RoleID uint   `json:"role_id"`
+	ID              uint     `json:"id"`
+	Name            string   `json:"name"`
+	Email           string   `json:"email"`
+	RoleID          uint     `json:"role_id"`
+	SessionID       string   `json:"sid"`
+	Permissions     []string `json:"permissions"`
+	EmailVerifiedAt int64    `json:"email_verified_at"`
 	jwt.RegisteredClaims
 }
 
 // jWTService is a struct that represent the jwt's service
 type jWTService struct {
-	cm gateway.Cache
+	keys *JWTKeySet
+	cm   gateway.Cache
 }
 
 // NewJWTService will create new an jwtService object representation of gateway.JWTService interface
-func NewJWTService(cm gateway.Cache) gateway.JWTService {
+func NewJWTService(keys *JWTKeySet, cm gateway.Cache) gateway.JWTService {
 	return &jWTService{
-		cm: cm,
+		keys: keys,
+		cm:   cm,
 	}
 }
 
 // GenerateToken is a function to generate the jwt token
-func (*jWTService) GenerateToken(_ context.Context, user *entity.User) (string, int64, error) {
+func (svc *jWTService) GenerateToken(_ context.Context, user *entity.User, sessionID string) (string, int64, error) {
 	// Create token and store to claims
 	now := time.Now()
 	expirationTime := now.Add(constant.TokenLifetime)
 
-	// JwtKey is secret key fow singed
-	jwtKey := []byte(config.GetAppConfig().AppJWTKey)
 	exp := jwt.NewNumericDate(expirationTime)
 
+	var verifiedAt int64
+	if user.EmailVerifiedAt != nil {
+		verifiedAt = user.EmailVerifiedAt.Unix()
+	}
+
 	// Generate token
 	cls := &CustomClaims{
-		ID:     user.ID,
-		Name:   user.Name,
-		Email:  user.Email,
-		RoleID: user.RoleID,
+		ID:              user.ID,
+		Name:            user.Name,
+		Email:           user.Email,
+		RoleID:          user.RoleID,
+		SessionID:       sessionID,
+		Permissions:     user.Permissions,
+		EmailVerifiedAt: verifiedAt,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        utils.GenerateUUID(),
+			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: exp,
 		},
 	}
 
-	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, cls).SignedString(jwtKey)
+	token, err := svc.keys.Sign(cls)
 	if err != nil {
 		return "", int64(0), errors.ErrBadRequest.Wrap(err)
 	}
@@ -62,6 +78,26 @@ func (*jWTService) GenerateToken(_ context.Context, user *entity.User) (string,
 	return token, exp.Unix(), nil
 }
 
+// GenerateRefreshToken is a function to generate an opaque refresh token
+func (*jWTService) GenerateRefreshToken(_ context.Context) (string, int64, error) {
+	token, err := utils.RandString(constant.RefreshTokenLength)
+	if err != nil {
+		return "", int64(0), errors.ErrBadRequest.Wrap(err)
+	}
+
+	return token, time.Now().Add(constant.RefreshTokenLifetime).Unix(), nil
+}
+
+// SessionID is a function to get the session the jwt token belongs to
+func (*jWTService) SessionID(token any) (string, error) {
+	claims, err := convertToClaims(token)
+	if err != nil {
+		return "", errors.Throw(err)
+	}
+
+	return claims.SessionID, nil
+}
+
 // Invalidate is a function to invalidate the jwt token
 func (svc *jWTService) Invalidate(ctx context.Context, token any) error {
 	claims, err := convertToClaims(token)
@@ -76,6 +112,15 @@ func (svc *jWTService) Invalidate(ctx context.Context, token any) error {
 	return nil
 }
 
+// InvalidateSession is a function to invalidate every jwt token of the session
+func (svc *jWTService) InvalidateSession(ctx context.Context, sessionID string) error {
+	if err := svc.cm.Set(ctx, revokedSessionPrefix+sessionID, true, constant.TokenLifetime); err != nil {
+		return errors.Throw(err)
+	}
+
+	return nil
+}
+
 // Decode is a function to convert the jwt token to user
 func (svc *jWTService) Decode(ctx context.Context, token any) (*entity.User, error) {
 	claims, err := convertToClaims(token)
@@ -83,16 +128,31 @@ func (svc *jWTService) Decode(ctx context.Context, token any) (*entity.User, err
 		return nil, errors.Throw(err)
 	}
 
+	// Tokens must belong to a session
+	if claims.SessionID == "" {
+		return nil, errors.ErrJWTInvalidClaims.Trace()
+	}
+
 	// Check JWT token invalid
 	if _, err := svc.cm.Get(ctx, claims.RegisteredClaims.ID); err == nil {
 		return nil, errors.ErrJWTRevoke.Trace()
 	}
 
+	// Check session of JWT token invalid
+	if _, err := svc.cm.Get(ctx, revokedSessionPrefix+claims.SessionID); err == nil {
+		return nil, errors.ErrJWTRevoke.Trace()
+	}
+
 	user := &entity.User{
-		ID:     claims.ID,
-		Name:   claims.Name,
-		Email:  claims.Email,
-		RoleID: claims.RoleID,
+		ID:          claims.ID,
+		Name:        claims.Name,
+		Email:       claims.Email,
+		RoleID:      claims.RoleID,
+		Permissions: claims.Permissions,
+	}
+	if claims.EmailVerifiedAt > 0 {
+		verifiedAt := time.Unix(claims.EmailVerifiedAt, 0)
+		user.EmailVerifiedAt = &verifiedAt
 	}
 
 	return user, nil
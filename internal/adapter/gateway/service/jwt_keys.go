@@ -0,0 +1,110 @@
+package service
+
+import (
+	"strings"
+
+	"go-app/internal/infrastructure/config"
+	"go-app/pkg/errors"
+	"go-app/pkg/jwk"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTKeySet is the keys used to sign and verify jwt tokens, retired keys are kept to verify
+// tokens issued before a rotation
+type JWTKeySet struct {
+	method  jwt.SigningMethod
+	secret  []byte
+	signing *jwk.Key
+	keys    map[string]*jwk.Key
+	ids     []string
+}
+
+// NewJWTKeySet will load the keys of conf, HS256 signs with secret and publishes no key
+func NewJWTKeySet(conf config.JWT, secret string) (*JWTKeySet, error) {
+	ks := &JWTKeySet{
+		secret: []byte(secret),
+		keys:   map[string]*jwk.Key{},
+	}
+
+	for _, item := range strings.Split(conf.Keys, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		id, path, ok := strings.Cut(item, "=")
+		if !ok || id == "" || path == "" {
+			return nil, errors.ErrJWTInvalidKeySet.Trace()
+		}
+		key, err := jwk.LoadPEM(id, path)
+		if err != nil {
+			return nil, errors.ErrJWTInvalidKeySet.Wrap(err)
+		}
+		ks.keys[id] = key
+		ks.ids = append(ks.ids, id)
+	}
+
+	switch conf.Algorithm {
+	case "", jwt.SigningMethodHS256.Alg():
+		ks.method = jwt.SigningMethodHS256
+		return ks, nil
+	case jwk.RS256:
+		ks.method = jwt.SigningMethodRS256
+	case jwk.EdDSA:
+		ks.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, errors.ErrJWTInvalidKeySet.Trace()
+	}
+
+	// Asymmetric tokens are signed by a private key of the same algorithm
+	key, ok := ks.keys[conf.SigningKeyID]
+	if !ok || key.Private == nil || key.Algorithm != conf.Algorithm {
+		return nil, errors.ErrJWTInvalidKeySet.Trace()
+	}
+	ks.signing = key
+
+	return ks, nil
+}
+
+// Sign will sign the claims with the current key
+func (ks *JWTKeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.method, claims)
+	if ks.signing == nil {
+		return token.SignedString(ks.secret)
+	}
+
+	token.Header["kid"] = ks.signing.ID
+	return token.SignedString(ks.signing.Private)
+}
+
+// Keyfunc will return the key verifying the token from its kid header
+func (ks *JWTKeySet) Keyfunc(token *jwt.Token) (any, error) {
+	if ks.signing == nil {
+		if token.Method.Alg() != jwt.SigningMethodHS256.Alg() {
+			return nil, errors.ErrJWTUnknownKey.Trace()
+		}
+
+		return ks.secret, nil
+	}
+
+	id, _ := token.Header["kid"].(string)
+	key, ok := ks.keys[id]
+	if !ok || token.Method.Alg() != key.Algorithm {
+		return nil, errors.ErrJWTUnknownKey.Trace()
+	}
+
+	return key.Public, nil
+}
+
+// JWKS will return the public keys, empty when tokens are signed with the shared secret
+func (ks *JWTKeySet) JWKS() jwk.Set {
+	set := jwk.Set{Keys: []jwk.JWK{}}
+	if ks.signing == nil {
+		return set
+	}
+	for _, id := range ks.ids {
+		set.Keys = append(set.Keys, ks.keys[id].JWK())
+	}
+
+	return set
+}
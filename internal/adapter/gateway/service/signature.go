@@ -0,0 +1,28 @@
+package service
+
+import (
+	"go-app/internal/domain/gateway"
+	"go-app/pkg/utils"
+)
+
+// signatureService is a struct that represent the signature's service
+type signatureService struct {
+	key string
+}
+
+// NewSignatureService will create new an signatureService object representation of gateway.SignatureService interface
+func NewSignatureService(key string) gateway.SignatureService {
+	return &signatureService{
+		key: key,
+	}
+}
+
+// Sign is a function to sign the payload with HMAC-SHA256
+func (svc *signatureService) Sign(payload string) string {
+	return utils.HMACSHA256(svc.key, payload)
+}
+
+// Verify is a function to check the signature of the payload
+func (svc *signatureService) Verify(payload, signature string) bool {
+	return utils.HMACEqual(svc.Sign(payload), signature)
+}
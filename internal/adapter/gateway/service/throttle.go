@@ -2,78 +2,253 @@ package service
 
 import (
 	"context"
-	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"go-app/internal/adapter/gateway/cache"
+	"go-app/internal/domain/entity"
 	"go-app/internal/domain/gateway"
 	"go-app/internal/infrastructure/constant"
 	"go-app/pkg/errors"
 	"go-app/pkg/utils"
+
+	"github.com/redis/go-redis/v9"
 )
 
-// throttleService is a struct that represent the throttle's service
+// throttlePrefix is prefix of the cache keys of the failed logins
+const throttlePrefix = "throttle:"
+
+// failScript counts a failed login of a subject, the failure reaching the limit increments the level of the
+// subject, locks it for the lockout of the level and restarts its failures in the same step
+//
+// KEYS: failures, level, lock. ARGV: limit, failure window, lockout history, first and longest lockout in
+// milliseconds. Returns the level and the lockout in milliseconds, zeros when the failure does not lock
+var failScript = redis.NewScript(`
+local n = redis.call('INCR', KEYS[1])
+if n == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+if n ~= tonumber(ARGV[1]) then
+	return {0, 0}
+end
+local level = redis.call('INCR', KEYS[2])
+if level == 1 then
+	redis.call('PEXPIRE', KEYS[2], ARGV[3])
+end
+local d = tonumber(ARGV[4])
+local longest = tonumber(ARGV[5])
+for i = 2, level do
+	if d >= longest then
+		break
+	end
+	d = d * 2
+end
+d = math.min(d, longest)
+redis.call('SET', KEYS[3], level, 'PX', d)
+redis.call('DEL', KEYS[1])
+return {level, d}
+`)
+
+// throttleService counts the failed logins of the subjects, a subject reaching its limit in the window is locked,
+// the lockout doubles with every lockout in the history. With redis a failure is counted and locks in failScript,
+// with another cache the failures of the process are counted in turn
 type throttleService struct {
 	cm gateway.Cache
+	rd *redis.Client
+	mu sync.Mutex
 }
 
-type throttleData struct {
-	Count int `json:"count"`
+// throttleSubject is an account or an ip and its limit
+type throttleSubject struct {
+	scope   string
+	subject string
+	limit   int
 }
 
-// NewThrottleService will create new an throttleService object representation of domain.ThrottleService interface
+// NewThrottleService will create new an throttleService object representation of domain.ThrottleService interface,
+// the failures are only atomic in the process so the cache is the memory one
 func NewThrottleService(cm gateway.Cache) gateway.ThrottleService {
 	return &throttleService{
 		cm: cm,
 	}
 }
 
-// Blocked is a function to check if the request login is blocked or not
-func (svc *throttleService) Blocked(ctx context.Context, key, ip string) (bool, error) {
-	hash := utils.MD5Hash(key + ip)
-	if b, err := svc.cm.Get(ctx, hash); err == nil {
-		data := throttleData{}
-		if err := json.Unmarshal(b, &data); err != nil {
-			return false, errors.ErrBadRequest.Wrap(err)
-		}
-		if data.Count >= constant.MaxLoginAttempt {
-			return true, nil
+// NewRedisThrottleService will create new an throttleService object representation of domain.ThrottleService
+// interface which keeps its keys in redis, shared by every instance
+func NewRedisThrottleService(rd *redis.Client) gateway.ThrottleService {
+	return &throttleService{
+		cm: cache.NewRedisStore(rd),
+		rd: rd,
+	}
+}
+
+// Blocked returns the longest remaining lockout of the account and the ip, zero when neither is locked
+func (svc *throttleService) Blocked(ctx context.Context, account, ip string) (time.Duration, error) {
+	var blocked time.Duration
+	for _, s := range subjects(account, ip) {
+		ttl, err := svc.cm.TTL(ctx, s.key("lock"))
+		if err != nil {
+			if errors.Is(err, errors.ErrRedisKeyNotFound.Trace()) {
+				continue
+			}
+			return 0, errors.Throw(err)
 		}
+		blocked = max(blocked, ttl)
 	}
 
-	return false, nil
+	return blocked, nil
 }
 
-// Incr is a function to increment the request login
-func (svc *throttleService) Incr(ctx context.Context, key, ip string) error {
-	hash := utils.MD5Hash(key + ip)
-	data := throttleData{
-		Count: 1,
+// Fail counts a failed login of the account and the ip, the lockout of the account is returned when the
+// failure locks it
+func (svc *throttleService) Fail(ctx context.Context, account, ip string) (*entity.Lockout, error) {
+	var locked *entity.Lockout
+	for _, s := range subjects(account, ip) {
+		level, duration, err := svc.fail(ctx, s)
+		if err != nil {
+			return nil, errors.Throw(err)
+		}
+		if level > 0 && s.scope == constant.LoginLockoutScopeAccount {
+			locked = &entity.Lockout{
+				Scope:      s.scope,
+				Subject:    s.subject,
+				Failures:   s.limit,
+				Level:      level,
+				RetryAfter: duration,
+			}
+		}
 	}
-	// Check if the key exist
-	if b, err := svc.cm.Get(ctx, hash); err == nil {
-		if err := json.Unmarshal(b, &data); err != nil {
-			return errors.ErrBadRequest.Wrap(err)
+
+	return locked, nil
+}
+
+// Clear resets the failures and the lockouts of the account
+func (svc *throttleService) Clear(ctx context.Context, account string) error {
+	return svc.Unlock(ctx, account, "")
+}
+
+// Lockouts returns the state of the account and the ip
+func (svc *throttleService) Lockouts(ctx context.Context, account, ip string) ([]entity.Lockout, error) {
+	lockouts := make([]entity.Lockout, 0, 2)
+	for _, s := range subjects(account, ip) {
+		ks := []string{s.key("failures"), s.key("level")}
+		values, err := svc.cm.GetMany(ctx, ks...)
+		if err != nil {
+			return nil, errors.Throw(err)
+		}
+		lockout := entity.Lockout{
+			Scope:    s.scope,
+			Subject:  s.subject,
+			Failures: atoi(values[ks[0]]),
+			Level:    atoi(values[ks[1]]),
+		}
+		ttl, err := svc.cm.TTL(ctx, s.key("lock"))
+		if err != nil && !errors.Is(err, errors.ErrRedisKeyNotFound.Trace()) {
+			return nil, errors.Throw(err)
 		}
-		data.Count++
+		lockout.RetryAfter = ttl
+		lockouts = append(lockouts, lockout)
 	}
-	// marshal the data
-	bytes, err := json.Marshal(&data)
-	if err != nil {
-		return errors.ErrBadRequest.Wrap(err)
+
+	return lockouts, nil
+}
+
+// Unlock removes the failures and the lockouts of the account and the ip
+func (svc *throttleService) Unlock(ctx context.Context, account, ip string) error {
+	ks := []string{}
+	for _, s := range subjects(account, ip) {
+		ks = append(ks, s.key("failures"), s.key("level"), s.key("lock"))
+	}
+	if len(ks) == 0 {
+		return nil
 	}
-	// increment the value
-	if err := svc.cm.Set(ctx, hash, bytes, constant.ThrottleBlockExpireDuration); err != nil {
+	if err := svc.cm.Del(ctx, ks...); err != nil {
 		return errors.Throw(err)
 	}
 
 	return nil
 }
 
-// Clear is a function to clear the request login
-func (svc *throttleService) Clear(ctx context.Context, key, ip string) error {
-	hash := utils.MD5Hash(key + ip)
-	if err := svc.cm.Del(ctx, hash); err != nil {
-		return errors.Throw(err)
+// fail counts a failed login of the subject, the level and the lockout are returned when the failure locks it,
+// zeros otherwise. Only the failure reaching the limit locks, the next ones are rejected by Blocked
+func (svc *throttleService) fail(ctx context.Context, s throttleSubject) (int, time.Duration, error) {
+	if svc.rd != nil {
+		return svc.failScript(ctx, s)
 	}
 
-	return nil
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	n, err := svc.cm.Incr(ctx, s.key("failures"), constant.LoginFailureWindow)
+	if err != nil {
+		return 0, 0, errors.Throw(err)
+	}
+	if int(n) != s.limit {
+		return 0, 0, nil
+	}
+	level, err := svc.cm.Incr(ctx, s.key("level"), constant.LoginLockoutHistory)
+	if err != nil {
+		return 0, 0, errors.Throw(err)
+	}
+	duration := lockoutDuration(int(level))
+	if err := svc.cm.Set(ctx, s.key("lock"), level, duration); err != nil {
+		return 0, 0, errors.Throw(err)
+	}
+	if err := svc.cm.Del(ctx, s.key("failures")); err != nil {
+		return 0, 0, errors.Throw(err)
+	}
+
+	return int(level), duration, nil
+}
+
+// failScript counts a failed login of the subject in redis with failScript
+func (svc *throttleService) failScript(ctx context.Context, s throttleSubject) (int, time.Duration, error) {
+	ks := []string{s.key("failures"), s.key("level"), s.key("lock")}
+	res, err := failScript.Run(ctx, svc.rd, ks, s.limit, constant.LoginFailureWindow.Milliseconds(),
+		constant.LoginLockoutHistory.Milliseconds(), constant.LoginLockoutDuration.Milliseconds(),
+		constant.LoginLockoutMaxDuration.Milliseconds()).Int64Slice()
+	if err != nil {
+		return 0, 0, errors.ErrRedisConnection.Wrap(err)
+	}
+	if len(res) != 2 {
+		return 0, 0, nil
+	}
+
+	return int(res[0]), time.Duration(res[1]) * time.Millisecond, nil
+}
+
+// key returns the cache key of the kind of data of the subject
+func (s throttleSubject) key(kind string) string {
+	return throttlePrefix + kind + ":" + s.scope + ":" + utils.SHA256Hash(s.subject)
+}
+
+// subjects returns the account and the ip which are not empty, accounts are case insensitive
+func subjects(account, ip string) []throttleSubject {
+	list := make([]throttleSubject, 0, 2)
+	if account = strings.ToLower(strings.TrimSpace(account)); account != "" {
+		list = append(list, throttleSubject{constant.LoginLockoutScopeAccount, account, constant.LoginAccountMaxFailures})
+	}
+	if ip != "" {
+		list = append(list, throttleSubject{constant.LoginLockoutScopeIP, ip, constant.LoginIPMaxFailures})
+	}
+
+	return list
+}
+
+// lockoutDuration doubles the first lockout duration for every level above the first, up to the longest one
+func lockoutDuration(level int) time.Duration {
+	d := constant.LoginLockoutDuration
+	for i := 1; i < level && d < constant.LoginLockoutMaxDuration; i++ {
+		d *= 2
+	}
+
+	return min(d, constant.LoginLockoutMaxDuration)
+}
+
+// atoi parses a counter of the cache, zero when it is missing
+func atoi(b []byte) int {
+	n, _ := strconv.Atoi(string(b))
+
+	return n
 }
@@ -0,0 +1,155 @@
+package service_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"go-app/internal/adapter/gateway/cache"
+	"go-app/internal/adapter/gateway/service"
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/constant"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// throttles returns a throttle of the memory cache and one of redis
+func throttles(t *testing.T) map[string]gateway.ThrottleService {
+	t.Helper()
+	rd := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	t.Cleanup(func() { _ = rd.Close() })
+
+	return map[string]gateway.ThrottleService{
+		"memory": service.NewThrottleService(cache.NewMemoryStore(0)),
+		"redis":  service.NewRedisThrottleService(rd),
+	}
+}
+
+// failUntilLocked counts failed logins of the account until it is locked, it returns the lockout and the number
+// of failures
+func failUntilLocked(t *testing.T, svc gateway.ThrottleService, account string) (*entity.Lockout, int) {
+	t.Helper()
+	for n := 1; n <= constant.LoginAccountMaxFailures*2; n++ {
+		lockout, err := svc.Fail(context.Background(), account, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lockout != nil {
+			return lockout, n
+		}
+	}
+
+	return nil, 0
+}
+
+func TestThrottleLocksAtLimit(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	for name, svc := range throttles(t) {
+		for n := 1; n < constant.LoginAccountMaxFailures; n++ {
+			if blocked, err := svc.Blocked(ctx, "user@example.com", ""); err != nil || blocked != 0 {
+				t.Fatalf("%s got blocked %v %v after %d failures", name, blocked, err, n-1)
+			}
+			if lockout, err := svc.Fail(ctx, "User@Example.com ", ""); err != nil || lockout != nil {
+				t.Fatalf("%s got %+v %v at failure %d", name, lockout, err, n)
+			}
+		}
+
+		lockout, err := svc.Fail(ctx, "user@example.com", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lockout == nil || lockout.Level != 1 || lockout.RetryAfter != constant.LoginLockoutDuration ||
+			lockout.Failures != constant.LoginAccountMaxFailures {
+			t.Fatalf("%s got %+v, the first lockout at the limit expected", name, lockout)
+		}
+		blocked, err := svc.Blocked(ctx, "user@example.com", "")
+		if err != nil || blocked <= 0 || blocked > constant.LoginLockoutDuration {
+			t.Errorf("%s got blocked %v %v, the first lockout expected", name, blocked, err)
+		}
+		// The failures restart, the next failure does not lock again
+		if lockout, err := svc.Fail(ctx, "user@example.com", ""); err != nil || lockout != nil {
+			t.Errorf("%s got %+v %v after the lockout", name, lockout, err)
+		}
+	}
+}
+
+func TestThrottleEscalates(t *testing.T) {
+	t.Parallel()
+
+	expectedResults := []time.Duration{
+		constant.LoginLockoutDuration,
+		constant.LoginLockoutDuration * 2,
+		constant.LoginLockoutDuration * 4,
+	}
+
+	for name, svc := range throttles(t) {
+		for testNumber, testExpected := range expectedResults {
+			lockout, n := failUntilLocked(t, svc, "user@example.com")
+			if lockout == nil || n != constant.LoginAccountMaxFailures || lockout.Level != testNumber+1 ||
+				lockout.RetryAfter != testExpected {
+				t.Errorf("%s #%d got %+v after %d failures, %v expected", name, testNumber, lockout, n, testExpected)
+			}
+		}
+	}
+}
+
+func TestThrottleLocksIP(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	for name, svc := range throttles(t) {
+		// Every account stays under its limit, the ip reaches its one
+		for n := range constant.LoginIPMaxFailures {
+			if lockout, err := svc.Fail(ctx, "user"+strconv.Itoa(n)+"@example.com", "203.0.113.7"); err != nil ||
+				lockout != nil {
+				t.Fatalf("%s got %+v %v at failure %d, only the ip locked expected", name, lockout, err, n)
+			}
+		}
+		if blocked, err := svc.Blocked(ctx, "other@example.com", "203.0.113.7"); err != nil || blocked <= 0 {
+			t.Errorf("%s got blocked %v %v, the ip locked expected", name, blocked, err)
+		}
+		if blocked, err := svc.Blocked(ctx, "other@example.com", "203.0.113.8"); err != nil || blocked != 0 {
+			t.Errorf("%s got blocked %v %v, another ip expected free", name, blocked, err)
+		}
+	}
+}
+
+func TestThrottleClear(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	for name, svc := range throttles(t) {
+		for range 2 {
+			if lockout, _ := failUntilLocked(t, svc, "user@example.com"); lockout == nil {
+				t.Fatalf("%s got no lockout", name)
+			}
+		}
+		if _, err := svc.Fail(ctx, "user@example.com", ""); err != nil {
+			t.Fatal(err)
+		}
+		lockouts, err := svc.Lockouts(ctx, "user@example.com", "")
+		if err != nil || len(lockouts) != 1 || lockouts[0].Level != 2 || lockouts[0].Failures != 1 ||
+			lockouts[0].RetryAfter <= 0 {
+			t.Fatalf("%s got %+v %v, the second lockout and a failure expected", name, lockouts, err)
+		}
+
+		if err := svc.Clear(ctx, "user@example.com"); err != nil {
+			t.Fatal(err)
+		}
+		lockouts, err = svc.Lockouts(ctx, "user@example.com", "")
+		if err != nil || len(lockouts) != 1 || lockouts[0].Level != 0 || lockouts[0].Failures != 0 ||
+			lockouts[0].RetryAfter != 0 {
+			t.Errorf("%s got %+v %v, the account reset expected", name, lockouts, err)
+		}
+		// The level restarts with the first lockout
+		if lockout, _ := failUntilLocked(t, svc, "user@example.com"); lockout == nil || lockout.Level != 1 ||
+			lockout.RetryAfter != constant.LoginLockoutDuration {
+			t.Errorf("%s got %+v, the first lockout expected", name, lockout)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go-app/internal/domain/gateway"
+	"go-app/pkg/webhook"
+)
+
+// webhookService is a struct that represent the webhook's service
+type webhookService struct {
+	client *webhook.Client
+}
+
+// NewWebhookService will create new an webhookService object representation of gateway.WebhookService interface
+func NewWebhookService(timeout time.Duration, userAgent string) gateway.WebhookService {
+	return &webhookService{
+		client: webhook.NewClient(timeout, userAgent),
+	}
+}
+
+// Send is a function to post the request signed with its secret
+func (svc *webhookService) Send(ctx context.Context, req gateway.WebhookRequest) (gateway.WebhookResponse, error) {
+	res, err := svc.client.Send(ctx, webhook.Message{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		Event:      req.Event,
+		DeliveryID: req.DeliveryID,
+		Body:       req.Body,
+	})
+
+	return gateway.WebhookResponse{StatusCode: res.StatusCode, Body: res.Body, Duration: res.Duration}, err
+}
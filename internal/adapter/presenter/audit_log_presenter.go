@@ -0,0 +1,65 @@
+package presenter
+
+import (
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/repository"
+)
+
+// ConvertAuditLogEntityToResponse DTO http purpose
+func ConvertAuditLogEntityToResponse(log *entity.AuditLog) dto.AuditLogResponse {
+	return dto.AuditLogResponse{
+		ID:         log.ID,
+		ActorID:    log.ActorID,
+		Action:     log.Action,
+		TargetType: log.TargetType,
+		TargetID:   log.TargetID,
+		Before:     log.Before,
+		After:      log.After,
+		IP:         log.IP,
+		UserAgent:  log.UserAgent,
+		RequestID:  log.RequestID,
+		CreatedAt:  log.CreatedAt,
+	}
+}
+
+// ConvertAuditLogListRequestToQuery DTO http purpose
+func ConvertAuditLogListRequestToQuery(req *dto.AuditLogListRequest, useCursor bool) repository.Query {
+	q := ConvertListRequestToQuery(&req.ListRequest, useCursor)
+	if req.ActorID != 0 {
+		q.Filters = append(q.Filters, repository.Filter{Field: "actor_id", Operator: repository.FilterEq, Value: req.ActorID})
+	}
+	if req.Action != "" {
+		q.Filters = append(q.Filters, repository.Filter{Field: "action", Operator: repository.FilterEq, Value: req.Action})
+	}
+	if req.TargetType != "" {
+		q.Filters = append(q.Filters, repository.Filter{
+			Field:    "target_type",
+			Operator: repository.FilterEq,
+			Value:    req.TargetType,
+		})
+	}
+	if req.TargetID != "" {
+		q.Filters = append(q.Filters, repository.Filter{
+			Field:    "target_id",
+			Operator: repository.FilterEq,
+			Value:    req.TargetID,
+		})
+	}
+	if !req.CreatedFrom.IsZero() {
+		q.Filters = append(q.Filters, repository.Filter{
+			Field:    "created_at",
+			Operator: repository.FilterGte,
+			Value:    req.CreatedFrom,
+		})
+	}
+	if !req.CreatedTo.IsZero() {
+		q.Filters = append(q.Filters, repository.Filter{
+			Field:    "created_at",
+			Operator: repository.FilterLte,
+			Value:    req.CreatedTo,
+		})
+	}
+
+	return q
+}
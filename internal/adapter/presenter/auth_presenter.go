@@ -6,15 +6,17 @@ import (
 )
 
 // ConvertUserToLoginResponse DTO http purpose
-func ConvertUserToLoginResponse(user entity.User, tokenStr string, exp int64) dto.UserLoginResponse {
+func ConvertUserToLoginResponse(user entity.User, token *entity.AuthToken) dto.UserLoginResponse {
 	return dto.UserLoginResponse{
 		ID:     user.ID,
 		Name:   user.Name,
 		Email:  user.Email,
 		RoleID: user.RoleID,
 		Auth: dto.AuthResponse{
-			AccessToken: tokenStr,
-			ExpiresAt:   exp,
+			AccessToken:      token.AccessToken,
+			ExpiresAt:        token.ExpiresAt,
+			RefreshToken:     token.RefreshToken,
+			RefreshExpiresAt: token.RefreshExpiresAt,
 		},
 	}
 }
@@ -32,7 +34,15 @@ func ConvertRegisterRequestToEntity(userReq *dto.UserRegisterRequest) *entity.Us
 	return &entity.User{
 		Name:     userReq.Name,
 		Email:    userReq.Email,
-		RoleID:   userReq.RoleID,
 		Password: userReq.Password,
 	}
 }
+
+// ConvertChallengeToResponse DTO http purpose
+func ConvertChallengeToResponse(challenge *entity.TwoFactorChallenge) dto.TwoFactorChallengeResponse {
+	return dto.TwoFactorChallengeResponse{
+		MFARequired: true,
+		MFAToken:    challenge.Token,
+		ExpiresAt:   challenge.ExpiresAt,
+	}
+}
@@ -0,0 +1,19 @@
+package presenter
+
+import (
+	"go-app/internal/delivery/http/dto"
+	"go-app/pkg/validate"
+)
+
+// ConvertFieldErrorsToResponse DTO http purpose
+func ConvertFieldErrorsToResponse(fields []validate.FieldError) []dto.FieldErrorResponse {
+	res := make([]dto.FieldErrorResponse, 0, len(fields))
+	for _, field := range fields {
+		res = append(res, dto.FieldErrorResponse{
+			Field:    field.Field,
+			Messages: field.Messages,
+		})
+	}
+
+	return res
+}
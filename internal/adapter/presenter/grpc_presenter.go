@@ -0,0 +1,132 @@
+package presenter
+
+import (
+	"time"
+
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/repository"
+	authv1 "go-app/proto/auth/v1"
+	commonv1 "go-app/proto/common/v1"
+	rolev1 "go-app/proto/role/v1"
+	userv1 "go-app/proto/user/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ConvertUserEntityToProto DTO grpc purpose
+func ConvertUserEntityToProto(user *entity.User) *userv1.User {
+	return &userv1.User{
+		Id:        uint64(user.ID),
+		Name:      user.Name,
+		Email:     user.Email,
+		RoleId:    uint64(user.RoleID),
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}
+}
+
+// ConvertRoleEntityToProto DTO grpc purpose
+func ConvertRoleEntityToProto(role *entity.Role) *rolev1.Role {
+	return &rolev1.Role{
+		Id:        uint64(role.ID),
+		Name:      role.Name,
+		Slug:      role.Slug,
+		CreatedAt: timestamppb.New(role.CreatedAt),
+		UpdatedAt: timestamppb.New(role.UpdatedAt),
+	}
+}
+
+// ConvertSessionEntityToProto DTO grpc purpose
+func ConvertSessionEntityToProto(session *entity.Session, currentID string) *authv1.Session {
+	return &authv1.Session{
+		Id:         session.ID,
+		Device:     session.Device,
+		Ip:         session.IP,
+		UserAgent:  session.UserAgent,
+		Current:    session.ID == currentID,
+		LastSeenAt: timestamppb.New(session.LastSeenAt),
+		CreatedAt:  timestamppb.New(session.CreatedAt),
+	}
+}
+
+// ConvertUserToLoginProto DTO grpc purpose
+func ConvertUserToLoginProto(user *entity.User, token *entity.AuthToken) *authv1.LoginResponse {
+	return &authv1.LoginResponse{
+		User: ConvertUserEntityToProto(user),
+		Token: &authv1.Token{
+			AccessToken:      token.AccessToken,
+			ExpiresAt:        timestamppb.New(time.Unix(token.ExpiresAt, 0)),
+			RefreshToken:     token.RefreshToken,
+			RefreshExpiresAt: timestamppb.New(time.Unix(token.RefreshExpiresAt, 0)),
+		},
+	}
+}
+
+// ConvertChallengeToProto DTO grpc purpose
+func ConvertChallengeToProto(challenge *entity.TwoFactorChallenge) *authv1.LoginResponse {
+	return &authv1.LoginResponse{
+		Challenge: &authv1.TwoFactorChallenge{
+			MfaToken:  challenge.Token,
+			ExpiresAt: timestamppb.New(time.Unix(challenge.ExpiresAt, 0)),
+		},
+	}
+}
+
+// ConvertPaginationToProto DTO grpc purpose
+func ConvertPaginationToProto(pg repository.Pagination) *commonv1.Pagination {
+	res := &commonv1.Pagination{
+		Page:       int32(pg.Page),
+		Limit:      int32(pg.Limit),
+		Total:      pg.Total,
+		NextCursor: pg.NextCursor,
+	}
+	if !pg.UseCursor && pg.Limit > 0 {
+		res.LastPage = int32(max(1, (pg.Total+int64(pg.Limit)-1)/int64(pg.Limit)))
+	}
+
+	return res
+}
+
+// ConvertListProtoToRequest DTO grpc purpose, the request is validated as the http one
+func ConvertListProtoToRequest(list *commonv1.ListRequest) dto.ListRequest {
+	return dto.ListRequest{
+		Page:   int(list.GetPage()),
+		Limit:  int(list.GetLimit()),
+		Cursor: list.GetCursor(),
+		Sort:   list.GetSort(),
+	}
+}
+
+// ConvertListUsersProtoToRequest DTO grpc purpose
+func ConvertListUsersProtoToRequest(req *userv1.ListUsersRequest) *dto.UserListRequest {
+	return &dto.UserListRequest{
+		ListRequest:   ConvertListProtoToRequest(req.GetList()),
+		RoleID:        uint(req.GetRoleId()),
+		Email:         req.GetEmail(),
+		EmailContains: req.GetEmailContains(),
+		Name:          req.GetName(),
+		CreatedFrom:   convertProtoToTime(req.GetCreatedFrom()),
+		CreatedTo:     convertProtoToTime(req.GetCreatedTo()),
+	}
+}
+
+// ConvertListRolesProtoToRequest DTO grpc purpose
+func ConvertListRolesProtoToRequest(req *rolev1.ListRolesRequest) *dto.RoleListRequest {
+	return &dto.RoleListRequest{
+		ListRequest: ConvertListProtoToRequest(req.GetList()),
+		Name:        req.GetName(),
+		Slug:        req.GetSlug(),
+		CreatedFrom: convertProtoToTime(req.GetCreatedFrom()),
+		CreatedTo:   convertProtoToTime(req.GetCreatedTo()),
+	}
+}
+
+// convertProtoToTime returns the zero time when ts is not set
+func convertProtoToTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+
+	return ts.AsTime()
+}
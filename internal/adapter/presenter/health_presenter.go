@@ -0,0 +1,26 @@
+package presenter
+
+import (
+	"time"
+
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/domain/entity"
+)
+
+// ConvertHealthReportToResponse DTO http purpose
+func ConvertHealthReportToResponse(report *entity.HealthReport) dto.HealthResponse {
+	res := dto.HealthResponse{
+		Status: report.Status,
+		Checks: make([]dto.HealthCheckResponse, 0, len(report.Checks)),
+	}
+	for _, check := range report.Checks {
+		res.Checks = append(res.Checks, dto.HealthCheckResponse{
+			Name:      check.Name,
+			Status:    check.Status,
+			Required:  check.Required,
+			LatencyMS: float64(check.Latency) / float64(time.Millisecond),
+		})
+	}
+
+	return res
+}
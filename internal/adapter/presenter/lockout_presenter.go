@@ -0,0 +1,27 @@
+package presenter
+
+import (
+	"math"
+	"time"
+
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/domain/entity"
+)
+
+// ConvertLockoutEntityToResponse DTO http purpose
+func ConvertLockoutEntityToResponse(lockout *entity.Lockout, now time.Time) dto.LockoutResponse {
+	res := dto.LockoutResponse{
+		Scope:    lockout.Scope,
+		Subject:  lockout.Subject,
+		Failures: lockout.Failures,
+		Level:    lockout.Level,
+	}
+	if lockout.RetryAfter > 0 {
+		until := now.Add(lockout.RetryAfter).Truncate(time.Second)
+		res.Locked = true
+		res.RetryAfter = int(math.Ceil(lockout.RetryAfter.Seconds()))
+		res.LockedUntil = &until
+	}
+
+	return res
+}
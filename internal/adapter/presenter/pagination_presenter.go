@@ -0,0 +1,142 @@
+package presenter
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/domain/repository"
+)
+
+// ConvertListRequestToQuery DTO http purpose, useCursor selects keyset pagination
+func ConvertListRequestToQuery(req *dto.ListRequest, useCursor bool) repository.Query {
+	q := repository.Query{
+		Page:      req.Page,
+		Limit:     req.Limit,
+		UseCursor: useCursor,
+		Cursor:    req.Cursor,
+	}
+	for _, field := range strings.Split(req.Sort, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		s := repository.Sort{Field: strings.TrimPrefix(field, "-"), Desc: strings.HasPrefix(field, "-")}
+		q.Sorts = append(q.Sorts, s)
+	}
+
+	return q
+}
+
+// ConvertUserListRequestToQuery DTO http purpose
+func ConvertUserListRequestToQuery(req *dto.UserListRequest, useCursor bool) repository.Query {
+	q := ConvertListRequestToQuery(&req.ListRequest, useCursor)
+	if req.RoleID != 0 {
+		q.Filters = append(q.Filters, repository.Filter{Field: "role_id", Operator: repository.FilterEq, Value: req.RoleID})
+	}
+	if req.Email != "" {
+		q.Filters = append(q.Filters, repository.Filter{Field: "email", Operator: repository.FilterEq, Value: req.Email})
+	}
+	if req.EmailContains != "" {
+		q.Filters = append(q.Filters, repository.Filter{
+			Field:    "email",
+			Operator: repository.FilterContains,
+			Value:    req.EmailContains,
+		})
+	}
+	if req.Name != "" {
+		q.Filters = append(q.Filters, repository.Filter{Field: "name", Operator: repository.FilterContains, Value: req.Name})
+	}
+	if !req.CreatedFrom.IsZero() {
+		q.Filters = append(q.Filters, repository.Filter{
+			Field:    "created_at",
+			Operator: repository.FilterGte,
+			Value:    req.CreatedFrom,
+		})
+	}
+	if !req.CreatedTo.IsZero() {
+		q.Filters = append(q.Filters, repository.Filter{
+			Field:    "created_at",
+			Operator: repository.FilterLte,
+			Value:    req.CreatedTo,
+		})
+	}
+
+	return q
+}
+
+// ConvertRoleListRequestToQuery DTO http purpose
+func ConvertRoleListRequestToQuery(req *dto.RoleListRequest, useCursor bool) repository.Query {
+	q := ConvertListRequestToQuery(&req.ListRequest, useCursor)
+	if req.Name != "" {
+		q.Filters = append(q.Filters, repository.Filter{Field: "name", Operator: repository.FilterContains, Value: req.Name})
+	}
+	if req.Slug != "" {
+		q.Filters = append(q.Filters, repository.Filter{Field: "slug", Operator: repository.FilterEq, Value: req.Slug})
+	}
+	if !req.CreatedFrom.IsZero() {
+		q.Filters = append(q.Filters, repository.Filter{
+			Field:    "created_at",
+			Operator: repository.FilterGte,
+			Value:    req.CreatedFrom,
+		})
+	}
+	if !req.CreatedTo.IsZero() {
+		q.Filters = append(q.Filters, repository.Filter{
+			Field:    "created_at",
+			Operator: repository.FilterLte,
+			Value:    req.CreatedTo,
+		})
+	}
+
+	return q
+}
+
+// ConvertPaginationToResponse DTO http purpose, links are built from the request url u
+func ConvertPaginationToResponse[T any](data []T, pg repository.Pagination, u *url.URL) dto.PaginatedResponse[T] {
+	res := dto.PaginatedResponse[T]{
+		Data: data,
+		Meta: dto.PaginationMeta{Limit: pg.Limit},
+	}
+	res.Links.Self = u.String()
+
+	if pg.UseCursor {
+		res.Meta.NextCursor = pg.NextCursor
+		res.Links.First = pageURL(u, "cursor", "")
+		if pg.NextCursor != "" {
+			res.Links.Next = pageURL(u, "cursor", pg.NextCursor)
+		}
+
+		return res
+	}
+
+	lastPage := 1
+	if pg.Total > 0 {
+		lastPage = int((pg.Total + int64(pg.Limit) - 1) / int64(pg.Limit))
+	}
+	total := pg.Total
+	res.Meta.Page = pg.Page
+	res.Meta.Total = &total
+	res.Meta.LastPage = lastPage
+	res.Links.First = pageURL(u, "page", "1")
+	res.Links.Last = pageURL(u, "page", strconv.Itoa(lastPage))
+	if pg.Page > 1 {
+		res.Links.Prev = pageURL(u, "page", strconv.Itoa(min(pg.Page-1, lastPage)))
+	}
+	if pg.Page < lastPage {
+		res.Links.Next = pageURL(u, "page", strconv.Itoa(pg.Page+1))
+	}
+
+	return res
+}
+
+// pageURL returns u with the query param key set to value
+func pageURL(u *url.URL, key, value string) string {
+	q := u.Query()
+	q.Set(key, value)
+	next := *u
+	next.RawQuery = q.Encode()
+
+	return next.String()
+}
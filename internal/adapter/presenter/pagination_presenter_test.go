@@ -0,0 +1,98 @@
+package presenter_test
+
+import (
+	"net/url"
+	"testing"
+
+	"go-app/internal/adapter/presenter"
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/domain/repository"
+)
+
+func TestConvertPaginationToResponse(t *testing.T) {
+	t.Parallel()
+	u, err := url.Parse("/api/users?limit=10&name=ann&page=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cursorURL, err := url.Parse("/api/users?cursor=abc&limit=10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedResults := []struct {
+		pg    repository.Pagination
+		u     *url.URL
+		meta  dto.PaginationMeta
+		links dto.PaginationLinks
+	}{
+		{
+			repository.Pagination{Page: 2, Limit: 10, Total: 35},
+			u,
+			dto.PaginationMeta{Page: 2, Limit: 10, LastPage: 4},
+			dto.PaginationLinks{
+				Self:  "/api/users?limit=10&name=ann&page=2",
+				First: "/api/users?limit=10&name=ann&page=1",
+				Prev:  "/api/users?limit=10&name=ann&page=1",
+				Next:  "/api/users?limit=10&name=ann&page=3",
+				Last:  "/api/users?limit=10&name=ann&page=4",
+			},
+		},
+		// An empty listing has one page, a page past the last one points back to it
+		{
+			repository.Pagination{Page: 1, Limit: 10},
+			u,
+			dto.PaginationMeta{Page: 1, Limit: 10, LastPage: 1},
+			dto.PaginationLinks{
+				Self:  "/api/users?limit=10&name=ann&page=2",
+				First: "/api/users?limit=10&name=ann&page=1",
+				Last:  "/api/users?limit=10&name=ann&page=1",
+			},
+		},
+		{
+			repository.Pagination{Page: 9, Limit: 10, Total: 20},
+			u,
+			dto.PaginationMeta{Page: 9, Limit: 10, LastPage: 2},
+			dto.PaginationLinks{
+				Self:  "/api/users?limit=10&name=ann&page=2",
+				First: "/api/users?limit=10&name=ann&page=1",
+				Prev:  "/api/users?limit=10&name=ann&page=2",
+				Last:  "/api/users?limit=10&name=ann&page=2",
+			},
+		},
+		// A cursor has no total, the next link carries the next cursor
+		{
+			repository.Pagination{Limit: 10, UseCursor: true, NextCursor: "def"},
+			cursorURL,
+			dto.PaginationMeta{Limit: 10, NextCursor: "def"},
+			dto.PaginationLinks{
+				Self:  "/api/users?cursor=abc&limit=10",
+				First: "/api/users?cursor=&limit=10",
+				Next:  "/api/users?cursor=def&limit=10",
+			},
+		},
+		{
+			repository.Pagination{Limit: 10, UseCursor: true},
+			cursorURL,
+			dto.PaginationMeta{Limit: 10},
+			dto.PaginationLinks{
+				Self:  "/api/users?cursor=abc&limit=10",
+				First: "/api/users?cursor=&limit=10",
+			},
+		},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		res := presenter.ConvertPaginationToResponse([]int{1}, testExpected.pg, testExpected.u)
+		meta := res.Meta
+		if testExpected.pg.UseCursor == (meta.Total != nil) ||
+			(meta.Total != nil && *meta.Total != testExpected.pg.Total) {
+			t.Errorf("#%d got the total %v of %+v", testNumber, meta.Total, testExpected.pg)
+		}
+		meta.Total = nil
+		if meta != testExpected.meta || res.Links != testExpected.links {
+			t.Errorf("#%d got %+v %+v, %+v %+v expected", testNumber, meta, res.Links,
+				testExpected.meta, testExpected.links)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package presenter
+
+import (
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/domain/entity"
+)
+
+// ConvertPermissionEntityToResponse DTO http purpose
+func ConvertPermissionEntityToResponse(permission *entity.Permission) dto.PermissionResponse {
+	return dto.PermissionResponse{
+		ID:          permission.ID,
+		Name:        permission.Name,
+		Description: permission.Description,
+		CreatedAt:   permission.CreatedAt,
+		UpdatedAt:   permission.UpdatedAt,
+	}
+}
+
+// ConvertPermissionRequestToEntity DTO http purpose
+func ConvertPermissionRequestToEntity(permission *dto.PermissionRequest) *entity.Permission {
+	return &entity.Permission{
+		Name:        permission.Name,
+		Description: permission.Description,
+	}
+}
@@ -0,0 +1,28 @@
+package presenter
+
+import (
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/domain/entity"
+)
+
+// ConvertSessionEntityToResponse DTO http purpose
+func ConvertSessionEntityToResponse(session *entity.Session, currentID string) dto.SessionResponse {
+	return dto.SessionResponse{
+		ID:         session.ID,
+		Device:     session.Device,
+		IP:         session.IP,
+		UserAgent:  session.UserAgent,
+		Current:    session.ID == currentID,
+		LastSeenAt: session.LastSeenAt,
+		CreatedAt:  session.CreatedAt,
+	}
+}
+
+// ConvertLoginRequestToSession DTO http purpose
+func ConvertLoginRequestToSession(userReq *dto.UserLoginRequest, ip, userAgent string) *entity.Session {
+	return &entity.Session{
+		Device:    userReq.Device,
+		IP:        ip,
+		UserAgent: userAgent,
+	}
+}
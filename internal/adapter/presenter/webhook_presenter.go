@@ -0,0 +1,113 @@
+package presenter
+
+import (
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/repository"
+)
+
+// ConvertWebhookEntityToResponse DTO http purpose, the secret is not exposed
+func ConvertWebhookEntityToResponse(webhook *entity.Webhook) dto.WebhookResponse {
+	return dto.WebhookResponse{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    webhook.Events,
+		Active:    webhook.Active,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
+
+// ConvertWebhookRequestToEntity DTO http purpose, webhooks are active unless told otherwise
+func ConvertWebhookRequestToEntity(webhook *dto.WebhookRequest) *entity.Webhook {
+	active := true
+	if webhook.Active != nil {
+		active = *webhook.Active
+	}
+
+	return &entity.Webhook{
+		URL:    webhook.URL,
+		Events: webhook.Events,
+		Active: active,
+		Secret: webhook.Secret,
+	}
+}
+
+// ConvertWebhookDeliveryEntityToResponse DTO http purpose
+func ConvertWebhookDeliveryEntityToResponse(
+	delivery *entity.WebhookDelivery,
+	attempts []entity.WebhookAttempt,
+) dto.WebhookDeliveryResponse {
+	res := dto.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		EventID:        delivery.EventID,
+		EventName:      delivery.EventName,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+	for i := range attempts {
+		res.AttemptLogs = append(res.AttemptLogs, dto.WebhookAttemptResponse{
+			ID:           attempts[i].ID,
+			Attempt:      attempts[i].Attempt,
+			StatusCode:   attempts[i].StatusCode,
+			ResponseBody: attempts[i].ResponseBody,
+			Error:        attempts[i].Error,
+			DurationMS:   attempts[i].Duration.Milliseconds(),
+			CreatedAt:    attempts[i].CreatedAt,
+		})
+	}
+
+	return res
+}
+
+// ConvertWebhookListRequestToQuery DTO http purpose
+func ConvertWebhookListRequestToQuery(req *dto.WebhookListRequest, useCursor bool) repository.Query {
+	q := ConvertListRequestToQuery(&req.ListRequest, useCursor)
+	if req.URL != "" {
+		q.Filters = append(q.Filters, repository.Filter{Field: "url", Operator: repository.FilterContains, Value: req.URL})
+	}
+	if req.Active != nil {
+		q.Filters = append(q.Filters, repository.Filter{Field: "active", Operator: repository.FilterEq, Value: *req.Active})
+	}
+
+	return q
+}
+
+// ConvertWebhookDeliveryListRequestToQuery DTO http purpose
+func ConvertWebhookDeliveryListRequestToQuery(req *dto.WebhookDeliveryListRequest, useCursor bool) repository.Query {
+	q := ConvertListRequestToQuery(&req.ListRequest, useCursor)
+	if req.Status != "" {
+		q.Filters = append(q.Filters, repository.Filter{Field: "status", Operator: repository.FilterEq, Value: req.Status})
+	}
+	if req.EventName != "" {
+		q.Filters = append(q.Filters, repository.Filter{
+			Field:    "event_name",
+			Operator: repository.FilterEq,
+			Value:    req.EventName,
+		})
+	}
+	if !req.CreatedFrom.IsZero() {
+		q.Filters = append(q.Filters, repository.Filter{
+			Field:    "created_at",
+			Operator: repository.FilterGte,
+			Value:    req.CreatedFrom,
+		})
+	}
+	if !req.CreatedTo.IsZero() {
+		q.Filters = append(q.Filters, repository.Filter{
+			Field:    "created_at",
+			Operator: repository.FilterLte,
+			Value:    req.CreatedTo,
+		})
+	}
+
+	return q
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"time"
+
+	"go-app/internal/domain/entity"
+)
+
+// AuditLog DAO model
+type AuditLog struct {
+	ID         uint           `gorm:"primaryKey"`
+	ActorID    *uint          `json:"actor_id"`
+	Action     string         `json:"action"`
+	TargetType string         `json:"target_type"`
+	TargetID   string         `json:"target_id"`
+	Before     map[string]any `gorm:"serializer:json"`
+	After      map[string]any `gorm:"serializer:json"`
+	IP         string         `json:"ip"`
+	UserAgent  string         `json:"user_agent"`
+	RequestID  string         `json:"request_id"`
+	CreatedAt  time.Time
+}
+
+// convertAuditLogToEntity .-
+func convertAuditLogToEntity(dao *AuditLog) *entity.AuditLog {
+	e := &entity.AuditLog{
+		ID:         dao.ID,
+		ActorID:    dao.ActorID,
+		Action:     dao.Action,
+		TargetType: dao.TargetType,
+		TargetID:   dao.TargetID,
+		Before:     dao.Before,
+		After:      dao.After,
+		IP:         dao.IP,
+		UserAgent:  dao.UserAgent,
+		RequestID:  dao.RequestID,
+		CreatedAt:  dao.CreatedAt,
+	}
+
+	return e
+}
+
+// convertAuditLogToDao .-
+func convertAuditLogToDao(entity *entity.AuditLog) *AuditLog {
+	d := &AuditLog{
+		ID:         entity.ID,
+		ActorID:    entity.ActorID,
+		Action:     entity.Action,
+		TargetType: entity.TargetType,
+		TargetID:   entity.TargetID,
+		Before:     entity.Before,
+		After:      entity.After,
+		IP:         entity.IP,
+		UserAgent:  entity.UserAgent,
+		RequestID:  entity.RequestID,
+		CreatedAt:  entity.CreatedAt,
+	}
+
+	return d
+}
+
+// sortValue returns the value of the whitelisted column
+func (dao *AuditLog) sortValue(field string) any {
+	if field == "created_at" {
+		return dao.CreatedAt
+	}
+
+	return dao.ID
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/repository"
+	"go-app/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// auditRepository ...
+type auditRepository struct {
+	*gorm.DB
+}
+
+// NewAuditRepository will implement of domain.AuditRepository interface
+func NewAuditRepository(db *gorm.DB) repository.AuditRepository {
+	return &auditRepository{
+		DB: db,
+	}
+}
+
+// Fetch will fetch a page of content from db, the query must be normalized
+func (rp *auditRepository) Fetch(
+	ctx context.Context,
+	q repository.Query,
+) ([]entity.AuditLog, repository.Pagination, error) {
+	dao, pg, err := paginate[AuditLog](rp.DB.WithContext(ctx).Model(&AuditLog{}), "audit_logs", q)
+	if err != nil {
+		return nil, pg, errors.Throw(err)
+	}
+
+	logs := []entity.AuditLog{}
+	for i := range dao {
+		l := convertAuditLogToEntity(&dao[i])
+		logs = append(logs, *l)
+	}
+
+	return logs, pg, nil
+}
+
+// Store will create the audit log, logs are never updated
+func (rp *auditRepository) Store(ctx context.Context, log *entity.AuditLog) error {
+	dao := convertAuditLogToDao(log)
+	if err := rp.DB.WithContext(ctx).Create(dao).Error; err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+	*log = *convertAuditLogToEntity(dao)
+
+	return nil
+}
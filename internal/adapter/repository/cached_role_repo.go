@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/domain/repository"
+)
+
+// cachedRoleRepository caches Find of the next repository and invalidates it on every write
+type cachedRoleRepository struct {
+	repository.RoleRepository
+	cm    gateway.Cache
+	cache *readThrough[entity.Role]
+}
+
+// NewCachedRoleRepository will decorate next with a read-through cache of the roles kept for ttl
+func NewCachedRoleRepository(
+	next repository.RoleRepository,
+	cm gateway.Cache,
+	ttl time.Duration,
+) repository.RoleRepository {
+	return &cachedRoleRepository{
+		RoleRepository: next,
+		cm:             cm,
+		cache:          newReadThrough[entity.Role](cm, cacheKindRole, ttl),
+	}
+}
+
+// Find will find the role from the cache, then from the next repository
+func (rp *cachedRoleRepository) Find(ctx context.Context, id uint) (*entity.Role, error) {
+	return rp.cache.find(ctx, id, func(ctx context.Context) (*entity.Role, error) {
+		return rp.RoleRepository.Find(ctx, id)
+	})
+}
+
+// Store will create the role and drop the cached not found of its id
+func (rp *cachedRoleRepository) Store(ctx context.Context, r *entity.Role) error {
+	if err := rp.RoleRepository.Store(ctx, r); err != nil {
+		return err
+	}
+	invalidateCache(ctx, rp.cm, cacheKindRole, r.ID)
+
+	return nil
+}
+
+// Update will update the role and invalidate it
+func (rp *cachedRoleRepository) Update(ctx context.Context, r *entity.Role) error {
+	if err := rp.RoleRepository.Update(ctx, r); err != nil {
+		return err
+	}
+	invalidateCache(ctx, rp.cm, cacheKindRole, r.ID)
+
+	return nil
+}
+
+// Delete will delete the role and invalidate it
+func (rp *cachedRoleRepository) Delete(ctx context.Context, id uint) error {
+	if err := rp.RoleRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	invalidateCache(ctx, rp.cm, cacheKindRole, id)
+
+	return nil
+}
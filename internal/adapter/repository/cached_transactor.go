@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/domain/repository"
+)
+
+// cachedTransactor records the users and roles written in a transaction and invalidates them once it is
+// committed, a read loading a row before the commit caches it under a version bumped by the invalidation
+type cachedTransactor struct {
+	repository.Transactor
+	cm gateway.Cache
+}
+
+// NewCachedTransactor will decorate next to invalidate the cache of the cached repositories
+func NewCachedTransactor(next repository.Transactor, cm gateway.Cache) repository.Transactor {
+	return &cachedTransactor{
+		Transactor: next,
+		cm:         cm,
+	}
+}
+
+// Transaction will run fn with repositories recording their writes, the cache is invalidated after commit
+func (rp *cachedTransactor) Transaction(
+	ctx context.Context,
+	fn func(ctx context.Context, repos repository.Repositories) error,
+) error {
+	written := &writtenIDs{}
+	if err := rp.Transactor.Transaction(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		return fn(ctx, &cachedRepositories{Repositories: repos, written: written})
+	}); err != nil {
+		return err
+	}
+	invalidateCache(ctx, rp.cm, cacheKindUser, written.users...)
+	invalidateCache(ctx, rp.cm, cacheKindRole, written.roles...)
+
+	return nil
+}
+
+// writtenIDs are the ids of the entities written in a transaction
+type writtenIDs struct {
+	users []uint
+	roles []uint
+}
+
+// cachedRepositories is the repositories of a transaction recording the written users and roles
+type cachedRepositories struct {
+	repository.Repositories
+	written *writtenIDs
+}
+
+// User returns the user repository of the transaction
+func (r *cachedRepositories) User() repository.UserRepository {
+	return &txUserRepository{UserRepository: r.Repositories.User(), written: r.written}
+}
+
+// Role returns the role repository of the transaction
+func (r *cachedRepositories) Role() repository.RoleRepository {
+	return &txRoleRepository{RoleRepository: r.Repositories.Role(), written: r.written}
+}
+
+// txUserRepository records the users written in a transaction, its reads are never cached
+type txUserRepository struct {
+	repository.UserRepository
+	written *writtenIDs
+}
+
+// Store will create the user
+func (rp *txUserRepository) Store(ctx context.Context, u *entity.User) error {
+	if err := rp.UserRepository.Store(ctx, u); err != nil {
+		return err
+	}
+	rp.written.users = append(rp.written.users, u.ID)
+
+	return nil
+}
+
+// Update will update the user
+func (rp *txUserRepository) Update(ctx context.Context, u *entity.User) error {
+	rp.written.users = append(rp.written.users, u.ID)
+
+	return rp.UserRepository.Update(ctx, u)
+}
+
+// UpdateTwoFactor will update the two factor columns of the user
+func (rp *txUserRepository) UpdateTwoFactor(ctx context.Context, u *entity.User) error {
+	rp.written.users = append(rp.written.users, u.ID)
+
+	return rp.UserRepository.UpdateTwoFactor(ctx, u)
+}
+
+// VerifyEmail will mark the email of the user as verified
+func (rp *txUserRepository) VerifyEmail(ctx context.Context, id uint) error {
+	rp.written.users = append(rp.written.users, id)
+
+	return rp.UserRepository.VerifyEmail(ctx, id)
+}
+
+// Delete will delete the user
+func (rp *txUserRepository) Delete(ctx context.Context, id uint) error {
+	rp.written.users = append(rp.written.users, id)
+
+	return rp.UserRepository.Delete(ctx, id)
+}
+
+// txRoleRepository records the roles written in a transaction, its reads are never cached
+type txRoleRepository struct {
+	repository.RoleRepository
+	written *writtenIDs
+}
+
+// Store will create the role
+func (rp *txRoleRepository) Store(ctx context.Context, r *entity.Role) error {
+	if err := rp.RoleRepository.Store(ctx, r); err != nil {
+		return err
+	}
+	rp.written.roles = append(rp.written.roles, r.ID)
+
+	return nil
+}
+
+// Update will update the role
+func (rp *txRoleRepository) Update(ctx context.Context, r *entity.Role) error {
+	rp.written.roles = append(rp.written.roles, r.ID)
+
+	return rp.RoleRepository.Update(ctx, r)
+}
+
+// Delete will delete the role
+func (rp *txRoleRepository) Delete(ctx context.Context, id uint) error {
+	rp.written.roles = append(rp.written.roles, id)
+
+	return rp.RoleRepository.Delete(ctx, id)
+}
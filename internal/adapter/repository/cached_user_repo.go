@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/domain/repository"
+)
+
+// cachedUserRepository caches Find of the next repository and invalidates it on every write
+type cachedUserRepository struct {
+	repository.UserRepository
+	cm    gateway.Cache
+	cache *readThrough[cachedUser]
+}
+
+// cachedUser is the cached projection of a user, the password and the two factor secrets are left out so the
+// cache never holds credentials
+type cachedUser struct {
+	ID                   uint       `json:"id"`
+	Name                 string     `json:"name"`
+	Email                string     `json:"email"`
+	RoleID               uint       `json:"role_id"`
+	Permissions          []string   `json:"permissions"`
+	EmailVerifiedAt      *time.Time `json:"email_verified_at"`
+	TwoFactorConfirmedAt *time.Time `json:"two_factor_confirmed_at"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+	DeletedAt            *time.Time `json:"deleted_at"`
+}
+
+// NewCachedUserRepository will decorate next with a read-through cache of the users kept for ttl
+func NewCachedUserRepository(
+	next repository.UserRepository,
+	cm gateway.Cache,
+	ttl time.Duration,
+) repository.UserRepository {
+	return &cachedUserRepository{
+		UserRepository: next,
+		cm:             cm,
+		cache:          newReadThrough[cachedUser](cm, cacheKindUser, ttl),
+	}
+}
+
+// Find will find the user without its credentials from the cache, then from the next repository
+func (rp *cachedUserRepository) Find(ctx context.Context, id uint) (*entity.User, error) {
+	u, err := rp.cache.find(ctx, id, func(ctx context.Context) (*cachedUser, error) {
+		u, err := rp.UserRepository.Find(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		return convertUserToCached(u), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return convertCachedToUser(u), nil
+}
+
+// FindCredentials will find the user with its credentials from the next repository, they are never cached
+func (rp *cachedUserRepository) FindCredentials(ctx context.Context, id uint) (*entity.User, error) {
+	return rp.UserRepository.FindCredentials(ctx, id)
+}
+
+// Store will create the user and drop the cached not found of its id
+func (rp *cachedUserRepository) Store(ctx context.Context, u *entity.User) error {
+	if err := rp.UserRepository.Store(ctx, u); err != nil {
+		return err
+	}
+	invalidateCache(ctx, rp.cm, cacheKindUser, u.ID)
+
+	return nil
+}
+
+// Update will update the user and invalidate it
+func (rp *cachedUserRepository) Update(ctx context.Context, u *entity.User) error {
+	if err := rp.UserRepository.Update(ctx, u); err != nil {
+		return err
+	}
+	invalidateCache(ctx, rp.cm, cacheKindUser, u.ID)
+
+	return nil
+}
+
+// UpdateTwoFactor will update the two factor columns and invalidate the user
+func (rp *cachedUserRepository) UpdateTwoFactor(ctx context.Context, u *entity.User) error {
+	if err := rp.UserRepository.UpdateTwoFactor(ctx, u); err != nil {
+		return err
+	}
+	invalidateCache(ctx, rp.cm, cacheKindUser, u.ID)
+
+	return nil
+}
+
+// ConsumeRecoveryCode will consume the recovery code and invalidate the user
+func (rp *cachedUserRepository) ConsumeRecoveryCode(ctx context.Context, id uint, hash string) (bool, error) {
+	consumed, err := rp.UserRepository.ConsumeRecoveryCode(ctx, id, hash)
+	if err != nil {
+		return false, err
+	}
+	invalidateCache(ctx, rp.cm, cacheKindUser, id)
+
+	return consumed, nil
+}
+
+// VerifyEmail will mark the email as verified and invalidate the user
+func (rp *cachedUserRepository) VerifyEmail(ctx context.Context, id uint) error {
+	if err := rp.UserRepository.VerifyEmail(ctx, id); err != nil {
+		return err
+	}
+	invalidateCache(ctx, rp.cm, cacheKindUser, id)
+
+	return nil
+}
+
+// UnverifyEmail will mark the email as not verified and invalidate the user
+func (rp *cachedUserRepository) UnverifyEmail(ctx context.Context, id uint) error {
+	if err := rp.UserRepository.UnverifyEmail(ctx, id); err != nil {
+		return err
+	}
+	invalidateCache(ctx, rp.cm, cacheKindUser, id)
+
+	return nil
+}
+
+// Delete will delete the user and invalidate it
+func (rp *cachedUserRepository) Delete(ctx context.Context, id uint) error {
+	if err := rp.UserRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	invalidateCache(ctx, rp.cm, cacheKindUser, id)
+
+	return nil
+}
+
+// convertUserToCached returns the cached projection of the user
+func convertUserToCached(u *entity.User) *cachedUser {
+	return &cachedUser{
+		ID:                   u.ID,
+		Name:                 u.Name,
+		Email:                u.Email,
+		RoleID:               u.RoleID,
+		Permissions:          u.Permissions,
+		EmailVerifiedAt:      u.EmailVerifiedAt,
+		TwoFactorConfirmedAt: u.TwoFactorConfirmedAt,
+		CreatedAt:            u.CreatedAt,
+		UpdatedAt:            u.UpdatedAt,
+		DeletedAt:            u.DeletedAt,
+	}
+}
+
+// convertCachedToUser returns the user of the cached projection, without its credentials
+func convertCachedToUser(u *cachedUser) *entity.User {
+	return &entity.User{
+		ID:                   u.ID,
+		Name:                 u.Name,
+		Email:                u.Email,
+		RoleID:               u.RoleID,
+		Permissions:          u.Permissions,
+		EmailVerifiedAt:      u.EmailVerifiedAt,
+		TwoFactorConfirmedAt: u.TwoFactorConfirmedAt,
+		CreatedAt:            u.CreatedAt,
+		UpdatedAt:            u.UpdatedAt,
+		DeletedAt:            u.DeletedAt,
+	}
+}
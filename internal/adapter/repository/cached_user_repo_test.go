@@ -0,0 +1,208 @@
+package repository_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-app/internal/adapter/gateway/cache"
+	"go-app/internal/adapter/repository"
+	"go-app/internal/domain/entity"
+	domain "go-app/internal/domain/repository"
+	"go-app/pkg/errors"
+)
+
+// fakeUserRepository keeps the users in a map and counts the loads, a load waits for release when it is set
+type fakeUserRepository struct {
+	domain.UserRepository
+
+	mu      sync.Mutex
+	users   map[uint]entity.User
+	loads   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func newFakeUserRepository(users ...entity.User) *fakeUserRepository {
+	rp := &fakeUserRepository{users: map[uint]entity.User{}}
+	for _, u := range users {
+		rp.users[u.ID] = u
+	}
+
+	return rp
+}
+
+func (rp *fakeUserRepository) Find(_ context.Context, id uint) (*entity.User, error) {
+	rp.loads.Add(1)
+	rp.mu.Lock()
+	u, ok := rp.users[id]
+	rp.mu.Unlock()
+	if rp.started != nil {
+		rp.started <- struct{}{}
+		<-rp.release
+	}
+	if !ok {
+		return nil, errors.ErrNotFound.Trace()
+	}
+
+	return &u, nil
+}
+
+func (rp *fakeUserRepository) FindCredentials(ctx context.Context, id uint) (*entity.User, error) {
+	return rp.Find(ctx, id)
+}
+
+func (rp *fakeUserRepository) Store(_ context.Context, u *entity.User) error {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	rp.users[u.ID] = *u
+
+	return nil
+}
+
+func (rp *fakeUserRepository) Update(ctx context.Context, u *entity.User) error {
+	return rp.Store(ctx, u)
+}
+
+func TestCachedUserRepositoryCredentials(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	next := newFakeUserRepository(entity.User{
+		ID:                     1,
+		Name:                   "Jane",
+		Password:               "hash",
+		TwoFactorSecret:        "secret",
+		TwoFactorRecoveryCodes: []string{"code"},
+	})
+	rp := repository.NewCachedUserRepository(next, cache.NewMemoryStore(0), time.Minute)
+
+	for range 2 {
+		u, err := rp.Find(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.Name != "Jane" || u.Password != "" || u.TwoFactorSecret != "" || u.TwoFactorRecoveryCodes != nil {
+			t.Errorf("got %+v, a user without credentials expected", u)
+		}
+	}
+	for range 2 {
+		u, err := rp.FindCredentials(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.Password != "hash" || u.TwoFactorSecret != "secret" {
+			t.Errorf("got %+v, a user with credentials expected", u)
+		}
+	}
+	if loads := next.loads.Load(); loads != 3 {
+		t.Errorf("got %d loads, 3 expected", loads)
+	}
+}
+
+func TestCachedUserRepositoryNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	next := newFakeUserRepository()
+	rp := repository.NewCachedUserRepository(next, cache.NewMemoryStore(0), time.Minute)
+
+	for range 2 {
+		if _, err := rp.Find(ctx, 1); !errors.Is(err, errors.ErrNotFound.Trace()) {
+			t.Fatalf("got %v, ErrNotFound expected", err)
+		}
+	}
+	if loads := next.loads.Load(); loads != 1 {
+		t.Errorf("got %d loads, the not found cached expected", loads)
+	}
+
+	if err := rp.Store(ctx, &entity.User{ID: 1, Name: "Jane"}); err != nil {
+		t.Fatal(err)
+	}
+	if u, err := rp.Find(ctx, 1); err != nil || u.Name != "Jane" {
+		t.Errorf("got %+v %v, the stored user expected", u, err)
+	}
+}
+
+func TestCachedUserRepositorySingleflight(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	next := newFakeUserRepository(entity.User{ID: 1, Name: "Jane"})
+	next.started = make(chan struct{}, 1)
+	next.release = make(chan struct{})
+	rp := repository.NewCachedUserRepository(next, cache.NewMemoryStore(0), time.Minute)
+
+	const callers = 10
+	var wg sync.WaitGroup
+	names := make(chan string, callers)
+	for range callers {
+		wg.Go(func() {
+			u, err := rp.Find(ctx, 1)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			names <- u.Name
+		})
+	}
+	<-next.started
+	// Leaves the other callers the time to join the load
+	time.Sleep(50 * time.Millisecond)
+	close(next.release)
+	wg.Wait()
+	close(names)
+
+	for name := range names {
+		if name != "Jane" {
+			t.Errorf("got %q, Jane expected", name)
+		}
+	}
+	if loads := next.loads.Load(); loads != 1 {
+		t.Errorf("got %d loads, 1 expected", loads)
+	}
+}
+
+func TestCachedUserRepositoryInvalidation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	next := newFakeUserRepository(entity.User{ID: 1, Name: "Jane"})
+	next.started = make(chan struct{}, 1)
+	next.release = make(chan struct{})
+	rp := repository.NewCachedUserRepository(next, cache.NewMemoryStore(0), time.Minute)
+
+	// A load reads the row before the update and ends after it
+	stale := make(chan *entity.User)
+	go func() {
+		u, _ := rp.Find(ctx, 1)
+		stale <- u
+	}()
+	<-next.started
+	if err := rp.Update(ctx, &entity.User{ID: 1, Name: "John"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A caller arriving after the update does not join the load started before it
+	fresh := make(chan *entity.User)
+	go func() {
+		u, _ := rp.Find(ctx, 1)
+		fresh <- u
+	}()
+	<-next.started
+	close(next.release)
+	if u := <-stale; u == nil || u.Name != "Jane" {
+		t.Errorf("got %+v, the row read before the update expected", u)
+	}
+	if u := <-fresh; u == nil || u.Name != "John" {
+		t.Errorf("got %+v, the updated row expected", u)
+	}
+
+	next.started = nil
+	for range 2 {
+		if u, err := rp.Find(ctx, 1); err != nil || u.Name != "John" {
+			t.Errorf("got %+v %v, the updated row expected", u, err)
+		}
+	}
+	if loads := next.loads.Load(); loads != 2 {
+		t.Errorf("got %d loads, 2 expected", loads)
+	}
+}
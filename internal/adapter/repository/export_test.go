@@ -0,0 +1,7 @@
+package repository
+
+// EncodeCursor exports encodeCursor to the tests
+var EncodeCursor = encodeCursor
+
+// DecodeCursor exports decodeCursor to the tests
+var DecodeCursor = decodeCursor
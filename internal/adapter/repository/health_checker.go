@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"go-app/internal/domain/gateway"
+	"go-app/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// NewHealthChecker will create a health checker pinging the database of the repositories
+func NewHealthChecker(db *gorm.DB) gateway.HealthChecker {
+	return gateway.HealthCheckFunc(func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return errors.ErrUnexpectedDBError.Wrap(err)
+		}
+		if err := sqlDB.PingContext(ctx); err != nil {
+			return errors.ErrUnexpectedDBError.Wrap(err)
+		}
+
+		return nil
+	})
+}
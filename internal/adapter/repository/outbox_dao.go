@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"time"
+
+	"go-app/internal/domain/entity"
+)
+
+// OutboxMessage DAO model
+type OutboxMessage struct {
+	ID          uint   `gorm:"primaryKey"`
+	EventName   string `json:"event_name"`
+	Payload     []byte `gorm:"type:jsonb"`
+	Attempts    int    `json:"attempts"`
+	AvailableAt time.Time
+	PublishedAt *time.Time
+	FailedAt    *time.Time
+	LastError   string `json:"last_error"`
+	CreatedAt   time.Time
+}
+
+// convertOutboxMessageToEntity .-
+func convertOutboxMessageToEntity(dao *OutboxMessage) *entity.OutboxMessage {
+	e := &entity.OutboxMessage{
+		ID:          dao.ID,
+		EventName:   dao.EventName,
+		Payload:     dao.Payload,
+		Attempts:    dao.Attempts,
+		AvailableAt: dao.AvailableAt,
+		PublishedAt: dao.PublishedAt,
+		FailedAt:    dao.FailedAt,
+		LastError:   dao.LastError,
+		CreatedAt:   dao.CreatedAt,
+	}
+
+	return e
+}
+
+// convertOutboxMessageToDao .-
+func convertOutboxMessageToDao(entity *entity.OutboxMessage) *OutboxMessage {
+	d := &OutboxMessage{
+		ID:          entity.ID,
+		EventName:   entity.EventName,
+		Payload:     entity.Payload,
+		Attempts:    entity.Attempts,
+		AvailableAt: entity.AvailableAt,
+		PublishedAt: entity.PublishedAt,
+		FailedAt:    entity.FailedAt,
+		LastError:   entity.LastError,
+		CreatedAt:   entity.CreatedAt,
+	}
+
+	return d
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/repository"
+	"go-app/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// outboxRepository ...
+type outboxRepository struct {
+	*gorm.DB
+}
+
+// NewOutboxRepository will implement of domain.OutboxRepository interface
+func NewOutboxRepository(db *gorm.DB) repository.OutboxRepository {
+	return &outboxRepository{
+		DB: db,
+	}
+}
+
+// Store will create the message, it is available at once when AvailableAt is zero
+func (rp *outboxRepository) Store(ctx context.Context, msg *entity.OutboxMessage) error {
+	dao := convertOutboxMessageToDao(msg)
+	if dao.AvailableAt.IsZero() {
+		dao.AvailableAt = time.Now()
+	}
+	if err := rp.DB.WithContext(ctx).Create(dao).Error; err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+	*msg = *convertOutboxMessageToEntity(dao)
+
+	return nil
+}
+
+// Claim will fetch the messages due for publishing in order and postpone them by lease, so other dispatchers
+// skip them while they are published, a message whose dispatcher died is claimed again once the lease is over
+func (rp *outboxRepository) Claim(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+) ([]entity.OutboxMessage, error) {
+	dao := []OutboxMessage{}
+	err := rp.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+			Where("published_at IS NULL AND failed_at IS NULL AND available_at <= ?", time.Now()).
+			Order("id").
+			Limit(limit).
+			Find(&dao).Error; err != nil {
+			return err
+		}
+		if len(dao) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, 0, len(dao))
+		for i := range dao {
+			ids = append(ids, dao[i].ID)
+		}
+
+		return tx.Model(&OutboxMessage{}).Where("id IN ?", ids).Update("available_at", time.Now().Add(lease)).Error
+	})
+	if err != nil {
+		return nil, errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	msgs := []entity.OutboxMessage{}
+	for i := range dao {
+		msgs = append(msgs, *convertOutboxMessageToEntity(&dao[i]))
+	}
+
+	return msgs, nil
+}
+
+// MarkPublished will record the message as delivered
+func (rp *outboxRepository) MarkPublished(ctx context.Context, id uint) error {
+	err := rp.DB.WithContext(ctx).Model(&OutboxMessage{}).Where("id = ?", id).
+		Update("published_at", time.Now()).Error
+	if err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return nil
+}
+
+// MarkRetry will record the failed attempt and schedule the next one
+func (rp *outboxRepository) MarkRetry(
+	ctx context.Context,
+	id uint,
+	attempts int,
+	availableAt time.Time,
+	lastErr string,
+) error {
+	err := rp.DB.WithContext(ctx).Model(&OutboxMessage{}).Where("id = ?", id).Updates(map[string]any{
+		"attempts":     attempts,
+		"available_at": availableAt,
+		"last_error":   lastErr,
+	}).Error
+	if err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return nil
+}
+
+// MarkFailed will record the message as dead, it is not retried anymore
+func (rp *outboxRepository) MarkFailed(ctx context.Context, id uint, attempts int, lastErr string) error {
+	err := rp.DB.WithContext(ctx).Model(&OutboxMessage{}).Where("id = ?", id).Updates(map[string]any{
+		"attempts":   attempts,
+		"failed_at":  time.Now(),
+		"last_error": lastErr,
+	}).Error
+	if err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return nil
+}
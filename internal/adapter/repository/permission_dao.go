@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"time"
+
+	"go-app/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+// Permission DAO model
+type Permission struct {
+	gorm.Model
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// RolePermission DAO model
+type RolePermission struct {
+	RoleID       uint `gorm:"primaryKey"`
+	PermissionID uint `gorm:"primaryKey"`
+	CreatedAt    time.Time
+}
+
+// convertPermissionToEntity .-
+func convertPermissionToEntity(dao *Permission) *entity.Permission {
+	e := &entity.Permission{
+		ID:          dao.ID,
+		Name:        dao.Name,
+		Description: dao.Description,
+		CreatedAt:   dao.CreatedAt,
+		UpdatedAt:   dao.UpdatedAt,
+	}
+
+	return e
+}
+
+// convertPermissionToDao .-
+func convertPermissionToDao(entity *entity.Permission) *Permission {
+	d := &Permission{
+		Model: gorm.Model{
+			ID:        entity.ID,
+			CreatedAt: entity.CreatedAt,
+			UpdatedAt: entity.UpdatedAt,
+		},
+		Name:        entity.Name,
+		Description: entity.Description,
+	}
+
+	return d
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/repository"
+	"go-app/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// permissionRepository ...
+type permissionRepository struct {
+	*gorm.DB
+}
+
+// NewPermissionRepository will implement of repository.PermissionRepository interface
+func NewPermissionRepository(db *gorm.DB) repository.PermissionRepository {
+	return &permissionRepository{
+		DB: db,
+	}
+}
+
+// Fetch will fetch content from db
+func (rp *permissionRepository) Fetch(ctx context.Context) ([]entity.Permission, error) {
+	dao := []Permission{}
+	if err := rp.DB.WithContext(ctx).Order("name").Find(&dao).Error; err != nil {
+		return nil, errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return convertPermissionsToEntities(dao), nil
+}
+
+// Find will find content from db
+func (rp *permissionRepository) Find(ctx context.Context, id uint) (*entity.Permission, error) {
+	dao := Permission{}
+	if err := rp.DB.WithContext(ctx).First(&dao, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound.Wrap(err)
+		}
+		return nil, errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return convertPermissionToEntity(&dao), nil
+}
+
+// CheckExists will check if data is exist or not
+func (rp *permissionRepository) CheckExists(ctx context.Context, q entity.Permission, id *uint) (bool, error) {
+	dao := convertPermissionToDao(&q)
+	var exists bool
+	subQuery := rp.DB.WithContext(ctx).
+		Model(&Permission{}).
+		Select("count(*) > 0").
+		Where(&dao)
+
+	if id != nil {
+		subQuery = subQuery.Where("id <> ?", id)
+	}
+	if err := subQuery.Find(&exists).Error; err != nil {
+		return false, errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return exists, nil
+}
+
+// Store will create data to db
+func (rp *permissionRepository) Store(ctx context.Context, p *entity.Permission) error {
+	dao := convertPermissionToDao(p)
+	if err := rp.DB.WithContext(ctx).Create(&dao).Error; err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	*p = *convertPermissionToEntity(dao)
+
+	return nil
+}
+
+// Update will update data to db
+func (rp *permissionRepository) Update(ctx context.Context, p *entity.Permission) error {
+	dao := convertPermissionToDao(p)
+	if err := rp.DB.WithContext(ctx).Save(&dao).Error; err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	*p = *convertPermissionToEntity(dao)
+
+	return nil
+}
+
+// Delete will delete data from db
+func (rp *permissionRepository) Delete(ctx context.Context, id uint) error {
+	err := rp.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("permission_id = ?", id).Delete(&RolePermission{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&Permission{}, id).Error
+	})
+	if err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return nil
+}
+
+// FetchByRole will fetch permissions granted to the role
+func (rp *permissionRepository) FetchByRole(ctx context.Context, roleID uint) ([]entity.Permission, error) {
+	dao := []Permission{}
+	if err := rp.DB.WithContext(ctx).
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Where("role_permissions.role_id = ?", roleID).
+		Order("permissions.name").
+		Find(&dao).Error; err != nil {
+		return nil, errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return convertPermissionsToEntities(dao), nil
+}
+
+// SyncRole will replace permissions granted to the role
+func (rp *permissionRepository) SyncRole(ctx context.Context, roleID uint, ids []uint) error {
+	err := rp.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", roleID).Delete(&RolePermission{}).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		dao := make([]RolePermission, 0, len(ids))
+		for _, id := range ids {
+			dao = append(dao, RolePermission{RoleID: roleID, PermissionID: id})
+		}
+
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&dao).Error
+	})
+	if err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return nil
+}
+
+// AttachRole will grant the permission to the role
+func (rp *permissionRepository) AttachRole(ctx context.Context, roleID, id uint) error {
+	dao := &RolePermission{RoleID: roleID, PermissionID: id}
+	if err := rp.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&dao).Error; err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return nil
+}
+
+// DetachRole will revoke the permission from the role
+func (rp *permissionRepository) DetachRole(ctx context.Context, roleID, id uint) error {
+	if err := rp.DB.WithContext(ctx).
+		Where("role_id = ? AND permission_id = ?", roleID, id).
+		Delete(&RolePermission{}).Error; err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return nil
+}
+
+// convertPermissionsToEntities .-
+func convertPermissionsToEntities(dao []Permission) []entity.Permission {
+	permissions := []entity.Permission{}
+	for i := range dao {
+		p := convertPermissionToEntity(&dao[i])
+		permissions = append(permissions, *p)
+	}
+
+	return permissions
+}
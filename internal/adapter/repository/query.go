@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go-app/internal/domain/repository"
+	"go-app/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// sortable is implemented by DAO which can be paginated with a cursor
+type sortable interface {
+	// sortValue returns the value of the whitelisted column
+	sortValue(field string) any
+}
+
+// cursor is the position after the last item of a page
+type cursor struct {
+	Field string          `json:"f"`
+	Value json.RawMessage `json:"v"`
+	ID    uint            `json:"id"`
+}
+
+// paginate applies filters, sorts and pagination of query on table, query must be normalized
+func paginate[T any, PT interface {
+	*T
+	sortable
+}](db *gorm.DB, table string, q repository.Query) ([]T, repository.Pagination, error) {
+	pg := repository.Pagination{Limit: q.Limit, UseCursor: q.UseCursor}
+	db = applyFilters(db, table, q.Filters)
+
+	// Offset pagination
+	if !q.UseCursor {
+		if err := db.Session(&gorm.Session{}).Model(new(T)).Count(&pg.Total).Error; err != nil {
+			return nil, pg, errors.ErrUnexpectedDBError.Wrap(err)
+		}
+		pg.Page = q.Page
+
+		items := []T{}
+		if err := applySorts(db, table, q.Sorts).
+			Offset((q.Page - 1) * q.Limit).
+			Limit(q.Limit).
+			Find(&items).Error; err != nil {
+			return nil, pg, errors.ErrUnexpectedDBError.Wrap(err)
+		}
+
+		return items, pg, nil
+	}
+
+	// Keyset pagination
+	sort := q.Sorts[0]
+	if q.Cursor != "" {
+		value, id, err := decodeCursor(q.Cursor, sort.Field, PT(new(T)).sortValue(sort.Field))
+		if err != nil {
+			return nil, pg, errors.Throw(err)
+		}
+		db = applyCursor(db, table, sort, value, id)
+	}
+
+	items := []T{}
+	if err := applySorts(db, table, q.Sorts).Limit(q.Limit + 1).Find(&items).Error; err != nil {
+		return nil, pg, errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	if len(items) > q.Limit {
+		items = items[:q.Limit]
+		last := PT(&items[len(items)-1])
+		id, _ := last.sortValue("id").(uint)
+		next, err := encodeCursor(sort.Field, last.sortValue(sort.Field), id)
+		if err != nil {
+			return nil, pg, errors.Throw(err)
+		}
+		pg.NextCursor = next
+	}
+
+	return items, pg, nil
+}
+
+// applyFilters adds conditions of filters, fields must be whitelisted
+func applyFilters(db *gorm.DB, table string, filters []repository.Filter) *gorm.DB {
+	for _, f := range filters {
+		col := clause.Column{Table: table, Name: f.Field}
+		switch f.Operator {
+		case repository.FilterEq:
+			db = db.Where(clause.Eq{Column: col, Value: f.Value})
+		case repository.FilterContains:
+			db = db.Where("? ILIKE ?", col, "%"+escapeLike(fmt.Sprint(f.Value))+"%")
+		case repository.FilterGte:
+			db = db.Where(clause.Gte{Column: col, Value: f.Value})
+		case repository.FilterLte:
+			db = db.Where(clause.Lte{Column: col, Value: f.Value})
+		}
+	}
+
+	return db
+}
+
+// applySorts adds orders of sorts with id as tie breaker for stable pages
+func applySorts(db *gorm.DB, table string, sorts []repository.Sort) *gorm.DB {
+	hasID := false
+	for _, s := range sorts {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Table: table, Name: s.Field}, Desc: s.Desc})
+		hasID = hasID || s.Field == "id"
+	}
+	if !hasID {
+		desc := len(sorts) > 0 && sorts[0].Desc
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Table: table, Name: "id"}, Desc: desc})
+	}
+
+	return db
+}
+
+// applyCursor adds the keyset condition to start after the cursor
+func applyCursor(db *gorm.DB, table string, sort repository.Sort, value any, id uint) *gorm.DB {
+	op := ">"
+	if sort.Desc {
+		op = "<"
+	}
+	idCol := clause.Column{Table: table, Name: "id"}
+	if sort.Field == "id" {
+		return db.Where(fmt.Sprintf("? %s ?", op), idCol, id)
+	}
+
+	col := clause.Column{Table: table, Name: sort.Field}
+	return db.Where(
+		fmt.Sprintf("(? %s ? OR (? = ? AND ? %s ?))", op, op),
+		col, value, col, value, idCol, id,
+	)
+}
+
+// encodeCursor returns an opaque cursor
+func encodeCursor(field string, value any, id uint) (string, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", errors.ErrInternalServerError.Wrap(err)
+	}
+	b, err := json.Marshal(cursor{Field: field, Value: raw, ID: id})
+	if err != nil {
+		return "", errors.ErrInternalServerError.Wrap(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decodeCursor parses the cursor, the value is decoded into the type of zero
+func decodeCursor(s, field string, zero any) (any, uint, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, 0, errors.ErrInvalidQuery.Wrap(err)
+	}
+	c := cursor{}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, 0, errors.ErrInvalidQuery.Wrap(err)
+	}
+	if c.Field != field {
+		return nil, 0, errors.ErrInvalidQuery.Trace()
+	}
+
+	value := reflect.New(reflect.TypeOf(zero))
+	if err := json.Unmarshal(c.Value, value.Interface()); err != nil {
+		return nil, 0, errors.ErrInvalidQuery.Wrap(err)
+	}
+
+	return value.Elem().Interface(), c.ID, nil
+}
+
+// escapeLike escapes wildcards of LIKE patterns
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,69 @@
+package repository_test
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+	"time"
+
+	"go-app/internal/adapter/repository"
+	"go-app/pkg/errors"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	t.Parallel()
+	at := time.Date(2024, 5, 1, 10, 30, 0, 500, time.UTC)
+
+	expectedResults := []struct {
+		field string
+		value any
+		id    uint
+	}{
+		{"id", uint(42), 42},
+		{"name", "O'Brien, ü", 7},
+		{"created_at", at, 3},
+		{"role_id", uint(0), 1},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		c, err := repository.EncodeCursor(testExpected.field, testExpected.value, testExpected.id)
+		if err != nil {
+			t.Fatalf("#%d %v", testNumber, err)
+		}
+		value, id, err := repository.DecodeCursor(c, testExpected.field, testExpected.value)
+		if err != nil || id != testExpected.id || !reflect.DeepEqual(value, testExpected.value) {
+			t.Errorf("#%d got %v %d %v, %v %d expected", testNumber, value, id, err,
+				testExpected.value, testExpected.id)
+		}
+	}
+}
+
+func TestCursorTampered(t *testing.T) {
+	t.Parallel()
+	valid, err := repository.EncodeCursor("name", "alice", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	expectedResults := []struct {
+		cursor string
+		field  string
+		zero   any
+	}{
+		// A cursor is kept on the sort it was issued for
+		{valid, "email", ""},
+		{"not base64!", "name", ""},
+		{encode(`{"f":"name","v":"alice"`), "name", ""},
+		// The value must decode into the type of the column
+		{encode(`{"f":"created_at","v":"alice","id":7}`), "created_at", time.Time{}},
+		{encode(`{"f":"id","v":-1,"id":7}`), "id", uint(0)},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		value, id, err := repository.DecodeCursor(testExpected.cursor, testExpected.field, testExpected.zero)
+		if !errors.Is(err, errors.ErrInvalidQuery.Trace()) {
+			t.Errorf("#%d got %v %d %v, ErrInvalidQuery expected", testNumber, value, id, err)
+		}
+	}
+}
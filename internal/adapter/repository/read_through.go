@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/constant"
+	"go-app/pkg/errors"
+	"go-app/pkg/logger"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// cacheKindUser is the kind of the cached users
+	cacheKindUser = "user"
+	// cacheKindRole is the kind of the cached roles
+	cacheKindRole = "role"
+)
+
+// cacheNotFound is the cached value of a row which does not exist
+var cacheNotFound = []byte("null")
+
+// readThrough is the cache-aside of one kind of entity keyed by id. Every id has a version counter bumped by
+// each invalidation and part of the key of its entry, so a load started before a write caches the row under
+// a version nobody reads anymore. The keys also carry the version of the cached shape so a deploy changing
+// an entity never decodes the entries of the previous one
+type readThrough[T any] struct {
+	cm     gateway.Cache
+	kind   string
+	ttl    time.Duration
+	loader *singleflight.Group
+}
+
+// newReadThrough returns the cache-aside of the entities of kind kept for ttl
+func newReadThrough[T any](cm gateway.Cache, kind string, ttl time.Duration) *readThrough[T] {
+	return &readThrough[T]{
+		cm:     cm,
+		kind:   kind,
+		ttl:    ttl,
+		loader: &singleflight.Group{},
+	}
+}
+
+// find returns the entity of id from the cache, on a miss it is loaded once for all the concurrent callers
+// of the same version and cached, a not found row is cached for a shorter time
+func (r *readThrough[T]) find(ctx context.Context, id uint, load func(ctx context.Context) (*T, error)) (*T, error) {
+	version, err := cacheVersion(ctx, r.cm, r.kind, id)
+	if err != nil {
+		// Without the version a cached entry can not be trusted
+		logger.WarnContext(ctx, "repository cache version", "kind", r.kind, "id", id, "error", err)
+		return load(ctx)
+	}
+
+	key := cacheKey(r.kind, id, version)
+	if b, err := r.cm.Get(ctx, key); err == nil {
+		if bytes.Equal(b, cacheNotFound) {
+			return nil, errors.ErrNotFound.Trace()
+		}
+		var item T
+		if err := json.Unmarshal(b, &item); err == nil {
+			return &item, nil
+		}
+	}
+
+	v, err, _ := r.loader.Do(key, func() (any, error) {
+		// the load is shared, it must not be cancelled with the caller which started it
+		ctx := context.WithoutCancel(ctx)
+		item, err := load(ctx)
+		if err != nil {
+			if errors.Is(err, errors.ErrNotFound.Trace()) {
+				r.set(ctx, id, version, cacheNotFound, constant.RepositoryCacheNegativeTTL)
+			}
+			return nil, err
+		}
+		if b, err := json.Marshal(item); err == nil {
+			r.set(ctx, id, version, b, r.ttl)
+		}
+
+		return item, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	item := *v.(*T)
+
+	return &item, nil
+}
+
+// set caches the value loaded at version unless the entity was invalidated meanwhile, the cache is an
+// optimisation so a failure is only logged
+func (r *readThrough[T]) set(ctx context.Context, id uint, version int64, b []byte, ttl time.Duration) {
+	if current, err := cacheVersion(ctx, r.cm, r.kind, id); err != nil || current != version {
+		return
+	}
+	key := cacheKey(r.kind, id, version)
+	if err := r.cm.Set(ctx, key, b, ttl); err != nil {
+		logger.WarnContext(ctx, "repository cache set", "key", key, "error", err)
+	}
+}
+
+// invalidateCache bumps the versions of the entities of kind so their entries are never read again, a
+// failure is only logged as the entries expire anyway
+func invalidateCache(ctx context.Context, cm gateway.Cache, kind string, ids ...uint) {
+	for _, id := range ids {
+		key := cacheVersionKey(kind, id)
+		if _, err := cm.Incr(ctx, key, constant.RepositoryCacheVersionTTL); err != nil {
+			logger.WarnContext(ctx, "repository cache invalidate", "key", key, "error", err)
+		}
+	}
+}
+
+// cacheVersion returns the version of the entity of kind and id, zero before its first invalidation
+func cacheVersion(ctx context.Context, cm gateway.Cache, kind string, id uint) (int64, error) {
+	b, err := cm.Get(ctx, cacheVersionKey(kind, id))
+	if errors.Is(err, errors.ErrRedisKeyNotFound.Trace()) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	version, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		return 0, errors.ErrInternalServerError.Wrap(err)
+	}
+
+	return version, nil
+}
+
+// cacheVersionKey returns the key of the version counter of the entity of kind and id
+func cacheVersionKey(kind string, id uint) string {
+	return "repo:" + constant.RepositoryCacheVersion + ":" + kind + ":" + strconv.FormatUint(uint64(id), 10) +
+		":version"
+}
+
+// cacheKey returns the key of the entity of kind and id at version
+func cacheKey(kind string, id uint, version int64) string {
+	return "repo:" + constant.RepositoryCacheVersion + ":" + kind + ":" + strconv.FormatUint(uint64(id), 10) +
+		":" + strconv.FormatInt(version, 10)
+}
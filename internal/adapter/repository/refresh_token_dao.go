@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/pkg/utils"
+)
+
+// RefreshToken DAO model
+type RefreshToken struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `json:"user_id"`
+	FamilyID  string `json:"family_id"`
+	TokenHash string `json:"token_hash"`
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// convertRefreshTokenToEntity .-
+func convertRefreshTokenToEntity(dao *RefreshToken) *entity.RefreshToken {
+	e := &entity.RefreshToken{
+		ID:        dao.ID,
+		UserID:    dao.UserID,
+		FamilyID:  dao.FamilyID,
+		ExpiresAt: dao.ExpiresAt,
+		RevokedAt: dao.RevokedAt,
+		CreatedAt: dao.CreatedAt,
+		UpdatedAt: dao.UpdatedAt,
+	}
+
+	return e
+}
+
+// convertRefreshTokenToDao .-
+func convertRefreshTokenToDao(entity *entity.RefreshToken) *RefreshToken {
+	d := &RefreshToken{
+		ID:        entity.ID,
+		UserID:    entity.UserID,
+		FamilyID:  entity.FamilyID,
+		TokenHash: utils.SHA256Hash(entity.Token),
+		ExpiresAt: entity.ExpiresAt,
+		RevokedAt: entity.RevokedAt,
+		CreatedAt: entity.CreatedAt,
+		UpdatedAt: entity.UpdatedAt,
+	}
+
+	return d
+}
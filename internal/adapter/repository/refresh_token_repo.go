@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/repository"
+	"go-app/pkg/errors"
+	"go-app/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+// refreshTokenRepository ...
+type refreshTokenRepository struct {
+	*gorm.DB
+}
+
+// NewRefreshTokenRepository will implement of repository.RefreshTokenRepository interface
+func NewRefreshTokenRepository(db *gorm.DB) repository.RefreshTokenRepository {
+	return &refreshTokenRepository{
+		DB: db,
+	}
+}
+
+// Store will create data to db, only the hash of the token is persisted
+func (rp *refreshTokenRepository) Store(ctx context.Context, rt *entity.RefreshToken) error {
+	dao := convertRefreshTokenToDao(rt)
+	if err := rp.DB.WithContext(ctx).Create(&dao).Error; err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	token := rt.Token
+	*rt = *convertRefreshTokenToEntity(dao)
+	rt.Token = token
+
+	return nil
+}
+
+// FindByToken will find refresh token by its plain value
+func (rp *refreshTokenRepository) FindByToken(ctx context.Context, token string) (*entity.RefreshToken, error) {
+	dao := RefreshToken{}
+	if err := rp.DB.WithContext(ctx).
+		Where("token_hash = ?", utils.SHA256Hash(token)).
+		First(&dao).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrAuthRefreshTokenInvalid.Wrap(err)
+		}
+		return nil, errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return convertRefreshTokenToEntity(&dao), nil
+}
+
+// Revoke will mark the token as revoked, it returns false when the token was already revoked
+func (rp *refreshTokenRepository) Revoke(ctx context.Context, id uint) (bool, error) {
+	result := rp.DB.WithContext(ctx).
+		Model(&RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return false, errors.ErrUnexpectedDBError.Wrap(result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// RevokeFamily will revoke every token issued from the same login
+func (rp *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	if err := rp.DB.WithContext(ctx).
+		Model(&RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error; err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return nil
+}
@@ -48,3 +48,17 @@ func convertRoleToDao(entity *entity.Role) *Role {
 
 	return d
 }
+
+// sortValue returns the value of the whitelisted column
+func (dao *Role) sortValue(field string) any {
+	switch field {
+	case "name":
+		return dao.Name
+	case "slug":
+		return dao.Slug
+	case "created_at":
+		return dao.CreatedAt
+	default:
+		return dao.ID
+	}
+}
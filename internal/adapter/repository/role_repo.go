@@ -21,21 +21,23 @@ func NewRoleRepository(db *gorm.DB) repository.RoleRepository {
 	}
 }
 
-// Fetch will fetch content from db
-func (rp *roleRepository) Fetch(ctx context.Context) ([]entity.Role, error) {
-	dao := []Role{}
-	if err := rp.DB.WithContext(ctx).Find(&dao).Error; err != nil {
-		return nil, errors.ErrUnexpectedDBError.Wrap(err)
+// Fetch will fetch a page of content from db, the query must be normalized
+func (rp *roleRepository) Fetch(
+	ctx context.Context,
+	q repository.Query,
+) ([]entity.Role, repository.Pagination, error) {
+	dao, pg, err := paginate[Role](rp.DB.WithContext(ctx).Model(&Role{}), "roles", q)
+	if err != nil {
+		return nil, pg, errors.Throw(err)
 	}
 
 	roles := []entity.Role{}
-
 	for i := range dao {
 		r := convertRoleToEntity(&dao[i])
 		roles = append(roles, *r)
 	}
 
-	return roles, nil
+	return roles, pg, nil
 }
 
 // Find will find content from db
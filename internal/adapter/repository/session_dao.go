@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"time"
+
+	"go-app/internal/domain/entity"
+)
+
+// Session DAO model
+type Session struct {
+	ID         string `gorm:"primaryKey"`
+	UserID     uint   `json:"user_id"`
+	Device     string `json:"device"`
+	IP         string `json:"ip"`
+	UserAgent  string `json:"user_agent"`
+	LastSeenAt time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// convertSessionToEntity .-
+func convertSessionToEntity(dao *Session) *entity.Session {
+	e := &entity.Session{
+		ID:         dao.ID,
+		UserID:     dao.UserID,
+		Device:     dao.Device,
+		IP:         dao.IP,
+		UserAgent:  dao.UserAgent,
+		LastSeenAt: dao.LastSeenAt,
+		RevokedAt:  dao.RevokedAt,
+		CreatedAt:  dao.CreatedAt,
+		UpdatedAt:  dao.UpdatedAt,
+	}
+
+	return e
+}
+
+// convertSessionToDao .-
+func convertSessionToDao(entity *entity.Session) *Session {
+	d := &Session{
+		ID:         entity.ID,
+		UserID:     entity.UserID,
+		Device:     entity.Device,
+		IP:         entity.IP,
+		UserAgent:  entity.UserAgent,
+		LastSeenAt: entity.LastSeenAt,
+		RevokedAt:  entity.RevokedAt,
+		CreatedAt:  entity.CreatedAt,
+		UpdatedAt:  entity.UpdatedAt,
+	}
+
+	return d
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/repository"
+	"go-app/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// sessionRepository ...
+type sessionRepository struct {
+	*gorm.DB
+}
+
+// NewSessionRepository will implement of repository.SessionRepository interface
+func NewSessionRepository(db *gorm.DB) repository.SessionRepository {
+	return &sessionRepository{
+		DB: db,
+	}
+}
+
+// FetchActiveByUser will fetch sessions of user which are not revoked and seen since the given time
+func (rp *sessionRepository) FetchActiveByUser(
+	ctx context.Context,
+	userID uint,
+	since time.Time,
+) ([]entity.Session, error) {
+	dao := []Session{}
+	if err := rp.DB.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND last_seen_at >= ?", userID, since).
+		Order("last_seen_at DESC").
+		Find(&dao).Error; err != nil {
+		return nil, errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	sessions := []entity.Session{}
+	for i := range dao {
+		s := convertSessionToEntity(&dao[i])
+		sessions = append(sessions, *s)
+	}
+
+	return sessions, nil
+}
+
+// Find will find content from db
+func (rp *sessionRepository) Find(ctx context.Context, id string) (*entity.Session, error) {
+	dao := Session{}
+	if err := rp.DB.WithContext(ctx).Where("id = ?", id).First(&dao).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound.Wrap(err)
+		}
+		return nil, errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return convertSessionToEntity(&dao), nil
+}
+
+// Store will create data to db
+func (rp *sessionRepository) Store(ctx context.Context, s *entity.Session) error {
+	dao := convertSessionToDao(s)
+	if err := rp.DB.WithContext(ctx).Create(&dao).Error; err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+	*s = *convertSessionToEntity(dao)
+
+	return nil
+}
+
+// Touch will update last seen of session, it returns false when the session is revoked or missing
+func (rp *sessionRepository) Touch(ctx context.Context, id, ip string) (bool, error) {
+	result := rp.DB.WithContext(ctx).
+		Model(&Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]any{"ip": ip, "last_seen_at": time.Now()})
+	if result.Error != nil {
+		return false, errors.ErrUnexpectedDBError.Wrap(result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// Revoke will mark the session as revoked
+func (rp *sessionRepository) Revoke(ctx context.Context, id string) error {
+	if err := rp.DB.WithContext(ctx).
+		Model(&Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error; err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return nil
+}
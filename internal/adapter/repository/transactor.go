@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+
+	"go-app/internal/domain/repository"
+	"go-app/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// transactor ...
+type transactor struct {
+	*gorm.DB
+}
+
+// NewTransactor will implement of domain.Transactor interface
+func NewTransactor(db *gorm.DB) repository.Transactor {
+	return &transactor{
+		DB: db,
+	}
+}
+
+// Transaction will run fn with repositories bound to a db transaction
+func (rp *transactor) Transaction(
+	ctx context.Context,
+	fn func(ctx context.Context, repos repository.Repositories) error,
+) error {
+	if err := rp.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(ctx, &repositories{DB: tx})
+	}); err != nil {
+		var be *errors.BaseError
+		if errors.As(err, &be) {
+			return be
+		}
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return nil
+}
+
+// repositories is the repositories of a transaction
+type repositories struct {
+	*gorm.DB
+}
+
+// User returns the user repository of the transaction
+func (r *repositories) User() repository.UserRepository {
+	return NewUserRepository(r.DB)
+}
+
+// Role returns the role repository of the transaction
+func (r *repositories) Role() repository.RoleRepository {
+	return NewRoleRepository(r.DB)
+}
+
+// PasswordReset returns the password reset repository of the transaction
+func (r *repositories) PasswordReset() repository.PasswordResetRepository {
+	return NewPasswordResetRepository(r.DB)
+}
+
+// Audit returns the audit repository of the transaction
+func (r *repositories) Audit() repository.AuditRepository {
+	return NewAuditRepository(r.DB)
+}
+
+// Outbox returns the outbox repository of the transaction
+func (r *repositories) Outbox() repository.OutboxRepository {
+	return NewOutboxRepository(r.DB)
+}
@@ -0,0 +1,77 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"go-app/internal/adapter/repository"
+	"go-app/internal/domain/entity"
+	domain "go-app/internal/domain/repository"
+	"go-app/pkg/errors"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newMockDB returns a gorm db of the postgres dialect on a mocked connection
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db, mock
+}
+
+func TestTransactor(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	expectedResults := []struct {
+		fnErr  error
+		commit bool
+	}{
+		{nil, true},
+		// An error of the callback rolls back both writes and is returned as is
+		{errors.ErrUserExistsByEmail.Trace(), false},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		db, mock := newMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "audit_logs"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery(`INSERT INTO "outbox_messages"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		if testExpected.commit {
+			mock.ExpectCommit()
+		} else {
+			mock.ExpectRollback()
+		}
+
+		err := repository.NewTransactor(db).Transaction(ctx, func(ctx context.Context, repos domain.Repositories) error {
+			if err := repos.Audit().Store(ctx, &entity.AuditLog{Action: "test"}); err != nil {
+				return err
+			}
+			if err := repos.Outbox().Store(ctx, &entity.OutboxMessage{EventName: "test"}); err != nil {
+				return err
+			}
+
+			return testExpected.fnErr
+		})
+		if !errors.Is(err, testExpected.fnErr) || (err == nil) != (testExpected.fnErr == nil) {
+			t.Errorf("#%d got %v, %v expected", testNumber, err, testExpected.fnErr)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("#%d %v", testNumber, err)
+		}
+	}
+}
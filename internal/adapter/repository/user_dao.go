@@ -1,7 +1,10 @@
 package repository
 
 import (
+	"time"
+
 	"go-app/internal/domain/entity"
+	"go-app/internal/infrastructure/tracing"
 	"go-app/pkg/errors"
 	"go-app/pkg/utils"
 
@@ -15,11 +18,25 @@ type User struct {
 	Email    string `json:"email"`
 	RoleID   uint   `json:"role_id"`
 	Password string `json:"Password"`
+
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+
+	TwoFactorSecret        string     `json:"two_factor_secret"`
+	TwoFactorRecoveryCodes []string   `json:"two_factor_recovery_codes" gorm:"serializer:json"`
+	TwoFactorConfirmedAt   *time.Time `json:"two_factor_confirmed_at"`
 }
 
-// BeforeSave hooks
-func (dao *User) BeforeSave(_ *gorm.DB) error {
+// twoFactorColumns are only written by UpdateTwoFactor
+var twoFactorColumns = []string{"two_factor_secret", "two_factor_recovery_codes", "two_factor_confirmed_at"}
+
+// guardedColumns are never written by Update
+var guardedColumns = append([]string{"email_verified_at"}, twoFactorColumns...)
+
+// BeforeSave hooks, the password is hashed in a span, bcrypt is slow by design
+func (dao *User) BeforeSave(tx *gorm.DB) error {
+	_, span := tracing.Start(tx.Statement.Context, "bcrypt.GeneratePassword")
 	hashPW, err := utils.GeneratePassword(dao.Password)
+	tracing.End(span, err)
 	if err != nil {
 		return errors.ErrBadGateway.Wrap(err)
 	}
@@ -38,6 +55,11 @@ func convertUserToEntity(dao *User) *entity.User {
 		Password:  dao.Password,
 		CreatedAt: dao.CreatedAt,
 		UpdatedAt: dao.UpdatedAt,
+
+		EmailVerifiedAt:        dao.EmailVerifiedAt,
+		TwoFactorSecret:        dao.TwoFactorSecret,
+		TwoFactorRecoveryCodes: dao.TwoFactorRecoveryCodes,
+		TwoFactorConfirmedAt:   dao.TwoFactorConfirmedAt,
 	}
 
 	return e
@@ -55,7 +77,28 @@ func convertUserToDao(entity *entity.User) *User {
 		Email:    entity.Email,
 		RoleID:   entity.RoleID,
 		Password: entity.Password,
+
+		EmailVerifiedAt:        entity.EmailVerifiedAt,
+		TwoFactorSecret:        entity.TwoFactorSecret,
+		TwoFactorRecoveryCodes: entity.TwoFactorRecoveryCodes,
+		TwoFactorConfirmedAt:   entity.TwoFactorConfirmedAt,
 	}
 
 	return d
 }
+
+// sortValue returns the value of the whitelisted column
+func (dao *User) sortValue(field string) any {
+	switch field {
+	case "name":
+		return dao.Name
+	case "email":
+		return dao.Email
+	case "role_id":
+		return dao.RoleID
+	case "created_at":
+		return dao.CreatedAt
+	default:
+		return dao.ID
+	}
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"time"
+
 	"go-app/internal/domain/entity"
 	"go-app/internal/domain/repository"
 	"go-app/pkg/errors"
@@ -21,21 +23,23 @@ func NewUserRepository(db *gorm.DB) repository.UserRepository {
 	}
 }
 
-// Fetch will fetch content from db
-func (rp *userRepository) Fetch(ctx context.Context) ([]entity.User, error) {
-	dao := []User{}
-
-	if err := rp.DB.WithContext(ctx).Find(&dao).Error; err != nil {
-		return nil, errors.ErrUnexpectedDBError.Wrap(err)
+// Fetch will fetch a page of content from db, the query must be normalized
+func (rp *userRepository) Fetch(
+	ctx context.Context,
+	q repository.Query,
+) ([]entity.User, repository.Pagination, error) {
+	dao, pg, err := paginate[User](rp.DB.WithContext(ctx).Model(&User{}), "users", q)
+	if err != nil {
+		return nil, pg, errors.Throw(err)
 	}
-	users := []entity.User{}
 
+	users := []entity.User{}
 	for i := range dao {
 		u := convertUserToEntity(&dao[i])
 		users = append(users, *u)
 	}
 
-	return users, nil
+	return users, pg, nil
 }
 
 // Find will find content from db
@@ -51,6 +55,11 @@ func (rp *userRepository) Find(ctx context.Context, id uint) (*entity.User, erro
 	return convertUserToEntity(&dao), nil
 }
 
+// FindCredentials will find content with the password and the two factor secrets from db
+func (rp *userRepository) FindCredentials(ctx context.Context, id uint) (*entity.User, error) {
+	return rp.Find(ctx, id)
+}
+
 // Store will create data to db
 func (rp *userRepository) Store(ctx context.Context, user *entity.User) error {
 	dao := convertUserToDao(user)
@@ -96,7 +105,7 @@ func (rp *userRepository) CheckExists(ctx context.Context, q entity.User, id *ui
 // Update will update data to db
 func (rp *userRepository) Update(ctx context.Context, user *entity.User) error {
 	dao := convertUserToDao(user)
-	if err := rp.DB.WithContext(ctx).Save(&dao).Error; err != nil {
+	if err := rp.DB.WithContext(ctx).Omit(guardedColumns...).Save(&dao).Error; err != nil {
 		return errors.ErrUnexpectedDBError.Wrap(err)
 	}
 	*user = *convertUserToEntity(dao)
@@ -104,6 +113,62 @@ func (rp *userRepository) Update(ctx context.Context, user *entity.User) error {
 	return nil
 }
 
+// UpdateTwoFactor will update two factor columns only, recovery codes are stored hashed
+func (rp *userRepository) UpdateTwoFactor(ctx context.Context, user *entity.User) error {
+	dao := convertUserToDao(user)
+	dao.UpdatedAt = time.Now()
+	if err := rp.DB.WithContext(ctx).
+		Session(&gorm.Session{SkipHooks: true}).
+		Model(&dao).
+		Select(append(twoFactorColumns, "updated_at")).
+		Updates(&dao).Error; err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return nil
+}
+
+// ConsumeRecoveryCode will remove the hash from the recovery codes of user, it returns false when the hash was
+// already removed so a code is consumed once by concurrent logins
+func (rp *userRepository) ConsumeRecoveryCode(ctx context.Context, id uint, hash string) (bool, error) {
+	result := rp.DB.WithContext(ctx).
+		Model(&User{}).
+		Where("id = ? AND jsonb_exists(two_factor_recovery_codes::jsonb, ?)", id, hash).
+		UpdateColumns(map[string]any{
+			"two_factor_recovery_codes": gorm.Expr("(two_factor_recovery_codes::jsonb - ?::text)::text", hash),
+			"updated_at":                time.Now(),
+		})
+	if result.Error != nil {
+		return false, errors.ErrUnexpectedDBError.Wrap(result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// VerifyEmail will mark the email of user as verified
+func (rp *userRepository) VerifyEmail(ctx context.Context, id uint) error {
+	if err := rp.DB.WithContext(ctx).
+		Model(&User{}).
+		Where("id = ? AND email_verified_at IS NULL", id).
+		UpdateColumns(map[string]any{"email_verified_at": time.Now(), "updated_at": time.Now()}).Error; err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return nil
+}
+
+// UnverifyEmail will mark the email of user as not verified
+func (rp *userRepository) UnverifyEmail(ctx context.Context, id uint) error {
+	if err := rp.DB.WithContext(ctx).
+		Model(&User{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]any{"email_verified_at": nil, "updated_at": time.Now()}).Error; err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return nil
+}
+
 // Delete will delete data from db
 func (rp *userRepository) Delete(ctx context.Context, id uint) error {
 	dao := User{}
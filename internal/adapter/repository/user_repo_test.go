@@ -0,0 +1,39 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"go-app/internal/adapter/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestConsumeRecoveryCode(t *testing.T) {
+	t.Parallel()
+
+	expectedResults := []struct {
+		rows     int64
+		consumed bool
+	}{
+		{1, true},
+		// The code was consumed by a concurrent login
+		{0, false},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		db, mock := newMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "users" SET .* WHERE \(id = \$\d AND jsonb_exists\(two_factor_recovery_codes::jsonb, \$\d\)`).
+			WillReturnResult(sqlmock.NewResult(0, testExpected.rows))
+		mock.ExpectCommit()
+
+		consumed, err := repository.NewUserRepository(db).ConsumeRecoveryCode(context.Background(), 7, "hash")
+		if err != nil || consumed != testExpected.consumed {
+			t.Errorf("#%d got %v %v, %v expected", testNumber, consumed, err, testExpected.consumed)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("#%d %v", testNumber, err)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"time"
+
+	"go-app/internal/domain/entity"
+)
+
+// Webhook DAO model
+type Webhook struct {
+	ID        uint     `gorm:"primaryKey"`
+	URL       string   `json:"url"`
+	Secret    string   `json:"secret"`
+	Events    []string `gorm:"serializer:json"`
+	Active    bool     `json:"active"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// WebhookDelivery DAO model
+type WebhookDelivery struct {
+	ID             uint   `gorm:"primaryKey"`
+	WebhookID      uint   `json:"webhook_id"`
+	EventID        uint   `json:"event_id"`
+	EventName      string `json:"event_name"`
+	Payload        []byte `gorm:"type:jsonb"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	NextAttemptAt  time.Time
+	LastStatusCode int    `json:"last_status_code"`
+	LastError      string `json:"last_error"`
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// WebhookAttempt DAO model
+type WebhookAttempt struct {
+	ID           uint   `gorm:"primaryKey"`
+	DeliveryID   uint   `json:"delivery_id"`
+	Attempt      int    `json:"attempt"`
+	StatusCode   int    `json:"status_code"`
+	ResponseBody string `json:"response_body"`
+	Error        string `json:"error"`
+	DurationMS   int64  `json:"duration_ms"`
+	CreatedAt    time.Time
+}
+
+// convertWebhookToEntity .-
+func convertWebhookToEntity(dao *Webhook) *entity.Webhook {
+	e := &entity.Webhook{
+		ID:        dao.ID,
+		URL:       dao.URL,
+		Secret:    dao.Secret,
+		Events:    dao.Events,
+		Active:    dao.Active,
+		CreatedAt: dao.CreatedAt,
+		UpdatedAt: dao.UpdatedAt,
+	}
+
+	return e
+}
+
+// convertWebhookToDao .-
+func convertWebhookToDao(entity *entity.Webhook) *Webhook {
+	d := &Webhook{
+		ID:        entity.ID,
+		URL:       entity.URL,
+		Secret:    entity.Secret,
+		Events:    entity.Events,
+		Active:    entity.Active,
+		CreatedAt: entity.CreatedAt,
+		UpdatedAt: entity.UpdatedAt,
+	}
+
+	return d
+}
+
+// sortValue returns the value of the whitelisted column
+func (dao *Webhook) sortValue(field string) any {
+	if field == "created_at" {
+		return dao.CreatedAt
+	}
+
+	return dao.ID
+}
+
+// convertWebhookDeliveryToEntity .-
+func convertWebhookDeliveryToEntity(dao *WebhookDelivery) *entity.WebhookDelivery {
+	e := &entity.WebhookDelivery{
+		ID:             dao.ID,
+		WebhookID:      dao.WebhookID,
+		EventID:        dao.EventID,
+		EventName:      dao.EventName,
+		Payload:        dao.Payload,
+		Status:         dao.Status,
+		Attempts:       dao.Attempts,
+		NextAttemptAt:  dao.NextAttemptAt,
+		LastStatusCode: dao.LastStatusCode,
+		LastError:      dao.LastError,
+		DeliveredAt:    dao.DeliveredAt,
+		CreatedAt:      dao.CreatedAt,
+		UpdatedAt:      dao.UpdatedAt,
+	}
+
+	return e
+}
+
+// convertWebhookDeliveryToDao .-
+func convertWebhookDeliveryToDao(entity *entity.WebhookDelivery) *WebhookDelivery {
+	d := &WebhookDelivery{
+		ID:             entity.ID,
+		WebhookID:      entity.WebhookID,
+		EventID:        entity.EventID,
+		EventName:      entity.EventName,
+		Payload:        entity.Payload,
+		Status:         entity.Status,
+		Attempts:       entity.Attempts,
+		NextAttemptAt:  entity.NextAttemptAt,
+		LastStatusCode: entity.LastStatusCode,
+		LastError:      entity.LastError,
+		DeliveredAt:    entity.DeliveredAt,
+		CreatedAt:      entity.CreatedAt,
+		UpdatedAt:      entity.UpdatedAt,
+	}
+
+	return d
+}
+
+// sortValue returns the value of the whitelisted column
+func (dao *WebhookDelivery) sortValue(field string) any {
+	if field == "created_at" {
+		return dao.CreatedAt
+	}
+
+	return dao.ID
+}
+
+// convertWebhookAttemptToEntity .-
+func convertWebhookAttemptToEntity(dao *WebhookAttempt) *entity.WebhookAttempt {
+	e := &entity.WebhookAttempt{
+		ID:           dao.ID,
+		DeliveryID:   dao.DeliveryID,
+		Attempt:      dao.Attempt,
+		StatusCode:   dao.StatusCode,
+		ResponseBody: dao.ResponseBody,
+		Error:        dao.Error,
+		Duration:     time.Duration(dao.DurationMS) * time.Millisecond,
+		CreatedAt:    dao.CreatedAt,
+	}
+
+	return e
+}
+
+// convertWebhookAttemptToDao .-
+func convertWebhookAttemptToDao(entity *entity.WebhookAttempt) *WebhookAttempt {
+	d := &WebhookAttempt{
+		ID:           entity.ID,
+		DeliveryID:   entity.DeliveryID,
+		Attempt:      entity.Attempt,
+		StatusCode:   entity.StatusCode,
+		ResponseBody: entity.ResponseBody,
+		Error:        entity.Error,
+		DurationMS:   entity.Duration.Milliseconds(),
+		CreatedAt:    entity.CreatedAt,
+	}
+
+	return d
+}
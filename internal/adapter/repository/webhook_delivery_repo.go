@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/repository"
+	"go-app/internal/infrastructure/constant"
+	"go-app/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// webhookDeliveryRepository ...
+type webhookDeliveryRepository struct {
+	*gorm.DB
+}
+
+// NewWebhookDeliveryRepository will implement of domain.WebhookDeliveryRepository interface
+func NewWebhookDeliveryRepository(db *gorm.DB) repository.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		DB: db,
+	}
+}
+
+// Fetch will fetch a page of deliveries of the webhook, the query must be normalized
+func (rp *webhookDeliveryRepository) Fetch(
+	ctx context.Context,
+	webhookID uint,
+	q repository.Query,
+) ([]entity.WebhookDelivery, repository.Pagination, error) {
+	db := rp.DB.WithContext(ctx).Model(&WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	dao, pg, err := paginate[WebhookDelivery](db, "webhook_deliveries", q)
+	if err != nil {
+		return nil, pg, errors.Throw(err)
+	}
+
+	deliveries := []entity.WebhookDelivery{}
+	for i := range dao {
+		deliveries = append(deliveries, *convertWebhookDeliveryToEntity(&dao[i]))
+	}
+
+	return deliveries, pg, nil
+}
+
+// Find will find the delivery of the webhook
+func (rp *webhookDeliveryRepository) Find(ctx context.Context, webhookID, id uint) (*entity.WebhookDelivery, error) {
+	dao := WebhookDelivery{}
+	if err := rp.DB.WithContext(ctx).Where("webhook_id = ?", webhookID).First(&dao, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound.Wrap(err)
+		}
+		return nil, errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return convertWebhookDeliveryToEntity(&dao), nil
+}
+
+// Store will create the delivery, a delivery of the same event to the same webhook is ignored
+// so an event published again is not delivered twice
+func (rp *webhookDeliveryRepository) Store(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	dao := convertWebhookDeliveryToDao(delivery)
+	if dao.NextAttemptAt.IsZero() {
+		dao.NextAttemptAt = time.Now()
+	}
+	if err := rp.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "webhook_id"}, {Name: "event_id"}}, DoNothing: true}).
+		Create(dao).Error; err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+	*delivery = *convertWebhookDeliveryToEntity(dao)
+
+	return nil
+}
+
+// Claim will fetch the due deliveries and postpone them by lease, so other workers skip them
+// while they are sent, a delivery whose worker died is claimed again once the lease is over
+func (rp *webhookDeliveryRepository) Claim(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+) ([]entity.WebhookDelivery, error) {
+	dao := []WebhookDelivery{}
+	err := rp.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+			Where("status = ? AND next_attempt_at <= ?", constant.WebhookDeliveryPending, time.Now()).
+			Order("next_attempt_at, id").
+			Limit(limit).
+			Find(&dao).Error; err != nil {
+			return err
+		}
+		if len(dao) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, 0, len(dao))
+		for i := range dao {
+			ids = append(ids, dao[i].ID)
+		}
+
+		return tx.Model(&WebhookDelivery{}).Where("id IN ?", ids).Update("next_attempt_at", time.Now().Add(lease)).Error
+	})
+	if err != nil {
+		return nil, errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	deliveries := []entity.WebhookDelivery{}
+	for i := range dao {
+		deliveries = append(deliveries, *convertWebhookDeliveryToEntity(&dao[i]))
+	}
+
+	return deliveries, nil
+}
+
+// Update will update data to db
+func (rp *webhookDeliveryRepository) Update(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	dao := convertWebhookDeliveryToDao(delivery)
+	if err := rp.DB.WithContext(ctx).Save(dao).Error; err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+	*delivery = *convertWebhookDeliveryToEntity(dao)
+
+	return nil
+}
+
+// StoreAttempt will create the log of an attempt
+func (rp *webhookDeliveryRepository) StoreAttempt(ctx context.Context, attempt *entity.WebhookAttempt) error {
+	dao := convertWebhookAttemptToDao(attempt)
+	if err := rp.DB.WithContext(ctx).Create(dao).Error; err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+	*attempt = *convertWebhookAttemptToEntity(dao)
+
+	return nil
+}
+
+// FetchAttempts will fetch the attempts of the delivery in order
+func (rp *webhookDeliveryRepository) FetchAttempts(
+	ctx context.Context,
+	deliveryID uint,
+) ([]entity.WebhookAttempt, error) {
+	dao := []WebhookAttempt{}
+	if err := rp.DB.WithContext(ctx).Where("delivery_id = ?", deliveryID).Order("id").Find(&dao).Error; err != nil {
+		return nil, errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	attempts := []entity.WebhookAttempt{}
+	for i := range dao {
+		attempts = append(attempts, *convertWebhookAttemptToEntity(&dao[i]))
+	}
+
+	return attempts, nil
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/repository"
+	"go-app/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// webhookRepository ...
+type webhookRepository struct {
+	*gorm.DB
+}
+
+// NewWebhookRepository will implement of domain.WebhookRepository interface
+func NewWebhookRepository(db *gorm.DB) repository.WebhookRepository {
+	return &webhookRepository{
+		DB: db,
+	}
+}
+
+// Fetch will fetch a page of content from db, the query must be normalized
+func (rp *webhookRepository) Fetch(
+	ctx context.Context,
+	q repository.Query,
+) ([]entity.Webhook, repository.Pagination, error) {
+	dao, pg, err := paginate[Webhook](rp.DB.WithContext(ctx).Model(&Webhook{}), "webhooks", q)
+	if err != nil {
+		return nil, pg, errors.Throw(err)
+	}
+
+	webhooks := []entity.Webhook{}
+	for i := range dao {
+		webhooks = append(webhooks, *convertWebhookToEntity(&dao[i]))
+	}
+
+	return webhooks, pg, nil
+}
+
+// FetchByEvent will fetch the active webhooks subscribed to the event
+func (rp *webhookRepository) FetchByEvent(ctx context.Context, name string) ([]entity.Webhook, error) {
+	events, err := json.Marshal([]string{name})
+	if err != nil {
+		return nil, errors.ErrInternalServerError.Wrap(err)
+	}
+
+	dao := []Webhook{}
+	if err := rp.DB.WithContext(ctx).
+		Where("active AND events @> ?::jsonb", string(events)).
+		Order("id").
+		Find(&dao).Error; err != nil {
+		return nil, errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	webhooks := []entity.Webhook{}
+	for i := range dao {
+		webhooks = append(webhooks, *convertWebhookToEntity(&dao[i]))
+	}
+
+	return webhooks, nil
+}
+
+// Find will find content from db
+func (rp *webhookRepository) Find(ctx context.Context, id uint) (*entity.Webhook, error) {
+	dao := Webhook{}
+	if err := rp.DB.WithContext(ctx).First(&dao, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrNotFound.Wrap(err)
+		}
+		return nil, errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return convertWebhookToEntity(&dao), nil
+}
+
+// Store will create data to db
+func (rp *webhookRepository) Store(ctx context.Context, webhook *entity.Webhook) error {
+	dao := convertWebhookToDao(webhook)
+	if err := rp.DB.WithContext(ctx).Create(dao).Error; err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+	*webhook = *convertWebhookToEntity(dao)
+
+	return nil
+}
+
+// Update will update data to db
+func (rp *webhookRepository) Update(ctx context.Context, webhook *entity.Webhook) error {
+	dao := convertWebhookToDao(webhook)
+	if err := rp.DB.WithContext(ctx).Save(dao).Error; err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+	*webhook = *convertWebhookToEntity(dao)
+
+	return nil
+}
+
+// Delete will delete data from db, the deliveries are deleted in cascade
+func (rp *webhookRepository) Delete(ctx context.Context, id uint) error {
+	if err := rp.DB.WithContext(ctx).Delete(&Webhook{}, id).Error; err != nil {
+		return errors.ErrUnexpectedDBError.Wrap(err)
+	}
+
+	return nil
+}
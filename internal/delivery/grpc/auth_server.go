@@ -0,0 +1,152 @@
+package grpc
+
+import (
+	"context"
+
+	"go-app/internal/adapter/presenter"
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/usecase/auth"
+	"go-app/pkg/errors"
+	"go-app/pkg/validate"
+	authv1 "go-app/proto/auth/v1"
+	userv1 "go-app/proto/user/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// authServer represent the grpc handler of authv1.AuthServiceServer
+type authServer struct {
+	authv1.UnimplementedAuthServiceServer
+	usecase   *auth.Usecase
+	validator *validate.CustomValidate
+}
+
+// NewAuthServer will create new an authServer object
+func NewAuthServer(usecase *auth.Usecase, validator *validate.CustomValidate) authv1.AuthServiceServer {
+	return &authServer{
+		usecase:   usecase,
+		validator: validator,
+	}
+}
+
+// Login for user
+func (s *authServer) Login(ctx context.Context, req *authv1.LoginRequest) (*authv1.LoginResponse, error) {
+	userReq := &dto.UserLoginRequest{
+		Email:    req.GetEmail(),
+		Password: req.GetPassword(),
+		Device:   req.GetDevice(),
+	}
+	if err := s.validator.Validate(userReq); err != nil {
+		return nil, errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	user := presenter.ConvertLoginRequestToEntity(userReq)
+	session := presenter.ConvertLoginRequestToSession(userReq, peerIP(ctx), firstMetadata(ctx, "user-agent"))
+	token, challenge, err := s.usecase.Login(ctx, user, session)
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	if challenge != nil {
+		return presenter.ConvertChallengeToProto(challenge), nil
+	}
+
+	return presenter.ConvertUserToLoginProto(user, token), nil
+}
+
+// VerifyTwoFactor will complete the log in with the second factor
+func (s *authServer) VerifyTwoFactor(
+	ctx context.Context,
+	req *authv1.VerifyTwoFactorRequest,
+) (*authv1.LoginResponse, error) {
+	verifyReq := &dto.TwoFactorVerifyRequest{
+		MFAToken: req.GetMfaToken(),
+		Code:     req.GetCode(),
+	}
+	if err := s.validator.Validate(verifyReq); err != nil {
+		return nil, errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	user, token, err := s.usecase.VerifyTwoFactor(ctx, verifyReq.MFAToken, verifyReq.Code)
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	return presenter.ConvertUserToLoginProto(user, token), nil
+}
+
+// Refresh will rotate the refresh token and return a new access token
+func (s *authServer) Refresh(ctx context.Context, req *authv1.RefreshRequest) (*authv1.LoginResponse, error) {
+	tokenReq := &dto.RefreshTokenRequest{RefreshToken: req.GetRefreshToken()}
+	if err := s.validator.Validate(tokenReq); err != nil {
+		return nil, errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	user, token, err := s.usecase.Refresh(ctx, tokenReq.RefreshToken, peerIP(ctx))
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	return presenter.ConvertUserToLoginProto(user, token), nil
+}
+
+// Register for user
+func (s *authServer) Register(ctx context.Context, req *authv1.RegisterRequest) (*userv1.User, error) {
+	userReq := &dto.UserRegisterRequest{
+		Name:     req.GetName(),
+		Email:    req.GetEmail(),
+		Password: req.GetPassword(),
+	}
+	if err := s.validator.Validate(userReq); err != nil {
+		return nil, errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	user, err := s.usecase.Register(ctx, presenter.ConvertRegisterRequestToEntity(userReq))
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	return presenter.ConvertUserEntityToProto(user), nil
+}
+
+// Logout for user
+func (s *authServer) Logout(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	if err := s.usecase.Logout(ctx, ctx.Value(tokenKey)); err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// Me for user
+func (*authServer) Me(ctx context.Context, _ *emptypb.Empty) (*userv1.User, error) {
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	return presenter.ConvertUserEntityToProto(user), nil
+}
+
+// ListSessions will stream active sessions of user
+func (s *authServer) ListSessions(_ *emptypb.Empty, stream grpc.ServerStreamingServer[authv1.Session]) error {
+	ctx := stream.Context()
+	user, err := userFromContext(ctx)
+	if err != nil {
+		return errors.Throw(err)
+	}
+	sessionID, _ := ctx.Value(sessionKey).(string)
+
+	sessions, err := s.usecase.FetchSessions(ctx, user.ID)
+	if err != nil {
+		return errors.Throw(err)
+	}
+	for i := range sessions {
+		if err := stream.Send(presenter.ConvertSessionEntityToProto(&sessions[i], sessionID)); err != nil {
+			return errors.ErrInternalServerError.Wrap(err)
+		}
+	}
+
+	return nil
+}
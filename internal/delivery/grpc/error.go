@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"go-app/internal/infrastructure/tracing"
+	"go-app/pkg/errors"
+	"go-app/pkg/i18n"
+	"go-app/pkg/logger"
+	"go-app/pkg/validate"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain is the domain of ErrorInfo details
+const errorDomain = "go-app"
+
+// httpStatusCodes maps the http status of errors.BaseError to grpc codes
+var httpStatusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusUnprocessableEntity: codes.InvalidArgument,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusInternalServerError: codes.Internal,
+	http.StatusBadGateway:          codes.Unavailable,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+}
+
+// convertErrorToStatus converts err to a grpc status, the code of errors.BaseError and the trace id are sent in
+// ErrorInfo, the invalid fields of a validation error in BadRequest
+func convertErrorToStatus(ctx context.Context, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	be := errors.Throw(err)
+	code, ok := httpStatusCodes[be.Status]
+	if !ok {
+		code = codes.Unknown
+	}
+	if be.Status >= http.StatusInternalServerError {
+		logger.ErrorContext(ctx, "internal error", "error", fmt.Sprintf("%+v", err))
+		trace.SpanFromContext(ctx).RecordError(err)
+	}
+
+	message := be.Message
+	if be.Status == http.StatusUnprocessableEntity {
+		if beErr := be.Unwrap(); beErr != nil {
+			message = beErr.Error()
+		}
+	}
+
+	metadata := map[string]string{
+		"code":   strconv.Itoa(be.Code),
+		"status": strconv.Itoa(be.Status),
+	}
+	if traceID := tracing.TraceID(ctx); traceID != "" {
+		metadata["trace_id"] = traceID
+	}
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason:   strconv.Itoa(be.Code),
+		Domain:   errorDomain,
+		Metadata: metadata,
+	}}
+	var ve *validate.ValidationError
+	if errors.As(err, &ve) {
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range ve.Fields(i18n.Locale(ctx)) {
+			for _, msg := range field.Messages {
+				badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+					Field:       field.Field,
+					Description: msg,
+				})
+			}
+		}
+		details = append(details, badRequest)
+	}
+	st, detailErr := status.New(code, message).WithDetails(details...)
+	if detailErr != nil {
+		return status.Error(code, message)
+	}
+
+	return st.Err()
+}
@@ -0,0 +1,110 @@
+package grpc_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"go-app/internal/delivery/grpc"
+	"go-app/internal/delivery/http/dto"
+	"go-app/pkg/errors"
+	"go-app/pkg/validate"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestConvertErrorToStatus(t *testing.T) {
+	t.Parallel()
+	invalid := validate.NewValidate().Validate(&dto.UserLoginRequest{})
+	if invalid == nil {
+		t.Fatal("got no validation error")
+	}
+
+	expectedResults := []struct {
+		err     error
+		code    codes.Code
+		message string
+		appCode int
+		fields  []string
+	}{
+		{errors.ErrNotFound.Trace(), codes.NotFound, errors.ErrNotFound.Message, errors.ErrNotFound.Code, nil},
+		{
+			errors.ErrAuthThrottleLogin.Trace(),
+			codes.PermissionDenied,
+			errors.ErrAuthThrottleLogin.Message,
+			errors.ErrAuthThrottleLogin.Code,
+			nil,
+		},
+		{
+			errors.ErrTooManyRequests.Trace(),
+			codes.ResourceExhausted,
+			errors.ErrTooManyRequests.Message,
+			errors.ErrTooManyRequests.Code,
+			nil,
+		},
+		// The message of a validation error lists the invalid fields, each one is a violation
+		{
+			errors.ErrUnprocessableEntity.Wrap(invalid),
+			codes.InvalidArgument,
+			invalid.Error(),
+			errors.ErrUnprocessableEntity.Code,
+			[]string{"email", "password"},
+		},
+		// An error of another package is internal and its message is hidden
+		{
+			context.DeadlineExceeded,
+			codes.Internal,
+			errors.ErrInternalServerError.Message,
+			errors.ErrInternalServerError.Code,
+			nil,
+		},
+		{errors.New(http.StatusTeapot, 99999, "Teapot."), codes.Unknown, "Teapot.", 99999, nil},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		st, ok := status.FromError(grpc.ConvertErrorToStatus(context.Background(), testExpected.err))
+		if !ok || st.Code() != testExpected.code || st.Message() != testExpected.message {
+			t.Errorf("#%d got %v, %v %q expected", testNumber, st, testExpected.code, testExpected.message)
+			continue
+		}
+
+		var info *errdetails.ErrorInfo
+		fields := []string{}
+		for _, detail := range st.Details() {
+			switch d := detail.(type) {
+			case *errdetails.ErrorInfo:
+				info = d
+			case *errdetails.BadRequest:
+				for _, violation := range d.GetFieldViolations() {
+					fields = append(fields, violation.GetField())
+				}
+			}
+		}
+		if info == nil || info.GetReason() != strconv.Itoa(testExpected.appCode) || info.GetDomain() != "go-app" ||
+			info.GetMetadata()["code"] != strconv.Itoa(testExpected.appCode) {
+			t.Errorf("#%d got ErrorInfo %v, code %d expected", testNumber, info, testExpected.appCode)
+		}
+		if len(fields) != len(testExpected.fields) {
+			t.Errorf("#%d got violations %v, %v expected", testNumber, fields, testExpected.fields)
+			continue
+		}
+		for i := range fields {
+			if fields[i] != testExpected.fields[i] {
+				t.Errorf("#%d got violations %v, %v expected", testNumber, fields, testExpected.fields)
+				break
+			}
+		}
+	}
+}
+
+func TestConvertErrorToStatusKeepsStatus(t *testing.T) {
+	t.Parallel()
+	// The status of the error is sent unchanged
+	st, _ := status.FromError(grpc.ConvertErrorToStatus(context.Background(), status.Error(codes.Canceled, "gone")))
+	if st.Code() != codes.Canceled || st.Message() != "gone" || len(st.Details()) != 0 {
+		t.Errorf("got %v, the status unchanged expected", st)
+	}
+}
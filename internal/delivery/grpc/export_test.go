@@ -0,0 +1,21 @@
+package grpc
+
+import (
+	"go-app/internal/domain/gateway"
+
+	"google.golang.org/grpc"
+)
+
+// ConvertErrorToStatus exports convertErrorToStatus to the tests
+var ConvertErrorToStatus = convertErrorToStatus
+
+// UnaryRecoveryInterceptor exports unaryRecoveryInterceptor to the tests
+var UnaryRecoveryInterceptor = unaryRecoveryInterceptor
+
+// StreamRecoveryInterceptor exports streamRecoveryInterceptor to the tests
+var StreamRecoveryInterceptor = streamRecoveryInterceptor
+
+// NewRateLimitInterceptor exports the unary rate limit interceptor of limiter to the tests
+func NewRateLimitInterceptor(limiter gateway.RateLimiter) grpc.UnaryServerInterceptor {
+	return (&rateLimiter{limiter: limiter}).unaryRateLimitInterceptor
+}
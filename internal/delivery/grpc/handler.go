@@ -1 +1,43 @@
 package grpc
+
+import (
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/config"
+	"go-app/internal/infrastructure/registry"
+	"go-app/pkg/validate"
+	authv1 "go-app/proto/auth/v1"
+	rolev1 "go-app/proto/role/v1"
+	userv1 "go-app/proto/user/v1"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+)
+
+// NewGRPCServer registry grpc, the services reuse the usecases of the http handlers
+func NewGRPCServer(svc gateway.JWTService, registry *registry.Registry) *grpc.Server {
+	a := &authenticator{
+		keys:   registry.JWTKeys,
+		svc:    svc,
+		uc:     registry.AuthUc,
+		policy: config.GetAuthConfig().EmailVerification,
+	}
+	rl := &rateLimiter{limiter: registry.RateLimiter}
+
+	s := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			unaryRecoveryInterceptor,
+			unaryErrorInterceptor,
+			rl.unaryRateLimitInterceptor,
+			a.unaryAuthInterceptor,
+		),
+		grpc.ChainStreamInterceptor(streamRecoveryInterceptor, streamErrorInterceptor, a.streamAuthInterceptor),
+	)
+
+	validator := validate.NewValidate()
+	authv1.RegisterAuthServiceServer(s, NewAuthServer(registry.AuthUc, validator))
+	userv1.RegisterUserServiceServer(s, NewUserServer(registry.UserUc, validator))
+	rolev1.RegisterRoleServiceServer(s, NewRoleServer(registry.RoleUc, validator))
+
+	return s
+}
@@ -0,0 +1,286 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"runtime/debug"
+	"slices"
+	"strings"
+
+	"go-app/internal/adapter/gateway/service"
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/usecase/audit"
+	"go-app/internal/usecase/auth"
+	"go-app/pkg/errors"
+	"go-app/pkg/i18n"
+	"go-app/pkg/logger"
+	"go-app/pkg/utils"
+	authv1 "go-app/proto/auth/v1"
+	rolev1 "go-app/proto/role/v1"
+	userv1 "go-app/proto/user/v1"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// contextKey is the type of values set on context by interceptors
+type contextKey string
+
+const (
+	// userKey is the authenticated *entity.User
+	userKey contextKey = "user"
+	// sessionKey is the session id of the token
+	sessionKey contextKey = "session"
+	// tokenKey is the parsed *jwt.Token
+	tokenKey contextKey = "token"
+)
+
+// requestIDMaxLength is the longest x-request-id propagated from clients
+const requestIDMaxLength = 128
+
+// publicMethods are called without token
+var publicMethods = []string{
+	authv1.AuthService_Login_FullMethodName,
+	authv1.AuthService_VerifyTwoFactor_FullMethodName,
+	authv1.AuthService_Refresh_FullMethodName,
+	authv1.AuthService_Register_FullMethodName,
+}
+
+// methodPermissions are the permissions required by methods, as the http routes
+var methodPermissions = map[string]string{
+	userv1.UserService_ListUsers_FullMethodName:  constant.PermissionUsersView,
+	userv1.UserService_GetUser_FullMethodName:    constant.PermissionUsersView,
+	userv1.UserService_CreateUser_FullMethodName: constant.PermissionUsersCreate,
+	userv1.UserService_UpdateUser_FullMethodName: constant.PermissionUsersUpdate,
+	userv1.UserService_DeleteUser_FullMethodName: constant.PermissionUsersDelete,
+	rolev1.RoleService_ListRoles_FullMethodName:  constant.PermissionRolesView,
+	rolev1.RoleService_GetRole_FullMethodName:    constant.PermissionRolesView,
+	rolev1.RoleService_CreateRole_FullMethodName: constant.PermissionRolesCreate,
+	rolev1.RoleService_UpdateRole_FullMethodName: constant.PermissionRolesUpdate,
+	rolev1.RoleService_DeleteRole_FullMethodName: constant.PermissionRolesDelete,
+}
+
+// authenticator authenticates calls with the jwt token of the authorization metadata
+type authenticator struct {
+	keys   *service.JWTKeySet
+	svc    gateway.JWTService
+	uc     *auth.Usecase
+	policy string
+}
+
+// unaryRecoveryInterceptor turns a panic of the call into an internal status, so it does not stop the process
+func unaryRecoveryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	var res any
+	err := recoverPanic(ctx, info.FullMethod, func() error {
+		var err error
+		res, err = handler(ctx, req)
+		return err
+	})
+
+	return res, err
+}
+
+// streamRecoveryInterceptor turns a panic of the call into an internal status, so it does not stop the process
+func streamRecoveryInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return recoverPanic(ss.Context(), info.FullMethod, func() error {
+		return handler(srv, ss)
+	})
+}
+
+// recoverPanic runs call, a panic is logged with its stack and returned as an internal status
+func recoverPanic(ctx context.Context, method string, call func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicErr := fmt.Errorf("grpc handler panic in %s: %v\n%s", method, r, debug.Stack())
+			err = convertErrorToStatus(ctx, errors.ErrInternalServerError.Wrap(panicErr))
+		}
+	}()
+
+	return call()
+}
+
+// unaryErrorInterceptor converts errors of handlers to grpc status, the request id and method are logged
+func unaryErrorInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	ctx = withLogFields(ctx, info.FullMethod)
+	res, err := handler(ctx, req)
+	if err != nil {
+		return nil, convertErrorToStatus(ctx, err)
+	}
+
+	return res, nil
+}
+
+// streamErrorInterceptor converts errors of handlers to grpc status, the request id and method are logged
+func streamErrorInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx := withLogFields(ss.Context(), info.FullMethod)
+	if err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx}); err != nil {
+		return convertErrorToStatus(ctx, err)
+	}
+
+	return nil
+}
+
+// unaryAuthInterceptor authenticates unary calls
+func (a *authenticator) unaryAuthInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	return handler(ctx, req)
+}
+
+// streamAuthInterceptor authenticates stream calls
+func (a *authenticator) streamAuthInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return errors.Throw(err)
+	}
+
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate returns the context carrying the user of the token, as the http middlewares
+func (a *authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if slices.Contains(publicMethods, method) {
+		return ctx, nil
+	}
+
+	raw, ok := strings.CutPrefix(firstMetadata(ctx, "authorization"), "Bearer ")
+	if !ok || raw == "" {
+		return nil, errors.ErrUnauthenticated.Trace()
+	}
+	token, err := jwt.ParseWithClaims(raw, new(service.CustomClaims), a.keys.Keyfunc)
+	if err != nil {
+		return nil, errors.ErrUnauthenticated.Wrap(err)
+	}
+
+	user, err := a.svc.Decode(ctx, token)
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	// Reject revoked sessions and record last seen
+	sessionID, err := a.svc.SessionID(token)
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
+	if err := a.uc.TouchSession(ctx, sessionID, peerIP(ctx)); err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	if permission, ok := methodPermissions[method]; ok {
+		if a.policy == constant.EmailVerificationRoutes && user.EmailVerifiedAt == nil {
+			return nil, errors.ErrAuthEmailNotVerified.Trace()
+		}
+		if !slices.Contains(user.Permissions, permission) {
+			return nil, errors.ErrForbidden.Trace()
+		}
+	}
+
+	ctx = logger.WithUserID(ctx, user.ID)
+	ctx = audit.WithActorID(ctx, user.ID)
+	ctx = context.WithValue(ctx, userKey, user)
+	ctx = context.WithValue(ctx, sessionKey, sessionID)
+	ctx = context.WithValue(ctx, tokenKey, token)
+
+	return ctx, nil
+}
+
+// contextStream is a grpc.ServerStream with a context set by interceptors
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context set by interceptors
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// withLogFields attaches the x-request-id metadata, or a new id, and the method to the logs of ctx,
+// the client ip and user agent are attached to the audit actor
+func withLogFields(ctx context.Context, method string) context.Context {
+	id := firstMetadata(ctx, "x-request-id")
+	if id == "" || len(id) > requestIDMaxLength {
+		id = utils.GenerateUUID()
+	}
+	ctx = logger.WithRequestID(ctx, id)
+	ctx = audit.WithActor(ctx, audit.Actor{IP: peerIP(ctx), UserAgent: firstMetadata(ctx, "user-agent")})
+	ctx = i18n.WithLocale(ctx, firstMetadata(ctx, "accept-language"))
+
+	return logger.WithRoute(ctx, method)
+}
+
+// userFromContext returns the authenticated user
+func userFromContext(ctx context.Context) (*entity.User, error) {
+	user, ok := ctx.Value(userKey).(*entity.User)
+	if !ok {
+		return nil, errors.ErrUnauthenticated.Trace()
+	}
+
+	return user, nil
+}
+
+// firstMetadata returns the first incoming metadata value of key
+func firstMetadata(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// peerIP returns the ip of the client
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/constant"
+	"go-app/pkg/errors"
+	"go-app/pkg/logger"
+	authv1 "go-app/proto/auth/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Rate limit of the public authentication methods, each peer address shares the bucket of the http auth routes
+const (
+	rateLimitAuthName   = "auth"
+	rateLimitAuthLimit  = 10
+	rateLimitAuthPeriod = time.Minute
+)
+
+// rateLimitedMethods are limited per peer address as the http auth routes
+var rateLimitedMethods = []string{
+	authv1.AuthService_Login_FullMethodName,
+	authv1.AuthService_VerifyTwoFactor_FullMethodName,
+	authv1.AuthService_Refresh_FullMethodName,
+	authv1.AuthService_Register_FullMethodName,
+}
+
+// rateLimiter limits the calls of the rate limited methods
+type rateLimiter struct {
+	limiter gateway.RateLimiter
+}
+
+// unaryRateLimitInterceptor denies the calls over the limit with a retry-after header, the calls are allowed when
+// the limiter fails
+func (rl *rateLimiter) unaryRateLimitInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if !slices.Contains(rateLimitedMethods, info.FullMethod) {
+		return handler(ctx, req)
+	}
+	ip := peerIP(ctx)
+	if ip == "" {
+		return handler(ctx, req)
+	}
+
+	key := constant.RateLimitKeyPrefix + rateLimitAuthName + ":ip:" + ip
+	res, err := rl.limiter.Allow(ctx, key, rateLimitAuthLimit, rateLimitAuthPeriod)
+	if err != nil {
+		logger.WarnContext(ctx, "rate limit skipped", "policy", rateLimitAuthName, "error", err.Error())
+		return handler(ctx, req)
+	}
+	if !res.Allowed {
+		retryAfter := max(1, int(math.Ceil(res.RetryAfter.Seconds())))
+		_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(retryAfter)))
+		return nil, errors.ErrTooManyRequests.Trace()
+	}
+
+	return handler(ctx, req)
+}
@@ -0,0 +1,63 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"go-app/internal/adapter/gateway/limiter"
+	"go-app/internal/delivery/grpc"
+	"go-app/pkg/errors"
+	authv1 "go-app/proto/auth/v1"
+	userv1 "go-app/proto/user/v1"
+
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// peerContext is the context of a call of addr
+func peerContext(addr string) context.Context {
+	tcp, _ := net.ResolveTCPAddr("tcp", addr)
+
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: tcp})
+}
+
+func TestRateLimitInterceptor(t *testing.T) {
+	t.Parallel()
+	interceptor := grpc.NewRateLimitInterceptor(limiter.NewMemoryLimiter(0))
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+	call := func(ctx context.Context, method string) error {
+		_, err := interceptor(ctx, nil, &grpcgo.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	login := authv1.AuthService_Login_FullMethodName
+	for i := range 10 {
+		if err := call(peerContext("10.0.0.1:5000"), login); err != nil {
+			t.Fatalf("got %v at call %d, allowed expected", err, i+1)
+		}
+	}
+
+	expectedResults := []struct {
+		ctx     context.Context
+		method  string
+		allowed bool
+	}{
+		// The limit is shared by the auth methods of a peer, whatever its port
+		{peerContext("10.0.0.1:5001"), login, false},
+		{peerContext("10.0.0.1:5000"), authv1.AuthService_Register_FullMethodName, false},
+		{peerContext("10.0.0.2:5000"), login, true},
+		{peerContext("10.0.0.1:5000"), userv1.UserService_ListUsers_FullMethodName, true},
+		{context.Background(), login, true},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		err := call(testExpected.ctx, testExpected.method)
+		if testExpected.allowed != (err == nil) {
+			t.Errorf("#%d got %v, allowed %v expected", testNumber, err, testExpected.allowed)
+		}
+		if err != nil && !errors.Is(err, errors.ErrTooManyRequests.Trace()) {
+			t.Errorf("#%d got %v, ErrTooManyRequests expected", testNumber, err)
+		}
+	}
+}
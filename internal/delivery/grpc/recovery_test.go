@@ -0,0 +1,56 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	"go-app/internal/delivery/grpc"
+	"go-app/pkg/errors"
+
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// panicStream is a server stream of a background context
+type panicStream struct {
+	grpcgo.ServerStream
+}
+
+func (panicStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestRecoveryInterceptor(t *testing.T) {
+	t.Parallel()
+	unary := func(context.Context, any) (any, error) {
+		panic("nil map")
+	}
+	stream := func(any, grpcgo.ServerStream) error {
+		panic("nil map")
+	}
+
+	results := []error{
+		func() error {
+			_, err := grpc.UnaryRecoveryInterceptor(
+				context.Background(), nil, &grpcgo.UnaryServerInfo{FullMethod: "/test/Unary"}, unary,
+			)
+			return err
+		}(),
+		grpc.StreamRecoveryInterceptor(nil, panicStream{}, &grpcgo.StreamServerInfo{FullMethod: "/test/Stream"}, stream),
+	}
+
+	// The panic is answered as an internal error, its value is not sent
+	for testNumber, err := range results {
+		st, _ := status.FromError(err)
+		if st.Code() != codes.Internal || st.Message() != errors.ErrInternalServerError.Message {
+			t.Errorf("#%d got %v, an internal status expected", testNumber, st)
+		}
+	}
+
+	res, err := grpc.UnaryRecoveryInterceptor(context.Background(), nil, &grpcgo.UnaryServerInfo{},
+		func(context.Context, any) (any, error) { return "ok", nil })
+	if res != "ok" || err != nil {
+		t.Errorf("got %v %v, the response of the handler expected", res, err)
+	}
+}
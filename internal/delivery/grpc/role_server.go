@@ -0,0 +1,99 @@
+package grpc
+
+import (
+	"context"
+
+	"go-app/internal/adapter/presenter"
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/usecase/role"
+	"go-app/pkg/errors"
+	"go-app/pkg/validate"
+	rolev1 "go-app/proto/role/v1"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// roleServer represent the grpc handler of rolev1.RoleServiceServer
+type roleServer struct {
+	rolev1.UnimplementedRoleServiceServer
+	usecase   *role.Usecase
+	validator *validate.CustomValidate
+}
+
+// NewRoleServer will create new a roleServer object
+func NewRoleServer(usecase *role.Usecase, validator *validate.CustomValidate) rolev1.RoleServiceServer {
+	return &roleServer{
+		usecase:   usecase,
+		validator: validator,
+	}
+}
+
+// ListRoles will fetch data
+func (s *roleServer) ListRoles(ctx context.Context, req *rolev1.ListRolesRequest) (*rolev1.ListRolesResponse, error) {
+	listReq := presenter.ConvertListRolesProtoToRequest(req)
+	if err := s.validator.Validate(listReq); err != nil {
+		return nil, errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	q := presenter.ConvertRoleListRequestToQuery(listReq, req.GetList().GetUseCursor())
+	roles, pg, err := s.usecase.Fetch(ctx, q)
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	res := &rolev1.ListRolesResponse{Pagination: presenter.ConvertPaginationToProto(pg)}
+	for i := range roles {
+		res.Roles = append(res.Roles, presenter.ConvertRoleEntityToProto(&roles[i]))
+	}
+
+	return res, nil
+}
+
+// GetRole will Find data
+func (s *roleServer) GetRole(ctx context.Context, req *rolev1.GetRoleRequest) (*rolev1.Role, error) {
+	role, err := s.usecase.Find(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	return presenter.ConvertRoleEntityToProto(role), nil
+}
+
+// CreateRole will create data
+func (s *roleServer) CreateRole(ctx context.Context, req *rolev1.CreateRoleRequest) (*rolev1.Role, error) {
+	roleReq := &dto.RoleRequest{Name: req.GetName()}
+	if err := s.validator.Validate(roleReq); err != nil {
+		return nil, errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	role := presenter.ConvertRoleRequestToEntity(roleReq)
+	if err := s.usecase.Store(ctx, role); err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	return presenter.ConvertRoleEntityToProto(role), nil
+}
+
+// UpdateRole will update data
+func (s *roleServer) UpdateRole(ctx context.Context, req *rolev1.UpdateRoleRequest) (*emptypb.Empty, error) {
+	roleReq := &dto.RoleRequest{Name: req.GetName()}
+	if err := s.validator.Validate(roleReq); err != nil {
+		return nil, errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	role := presenter.ConvertRoleRequestToEntity(roleReq)
+	if err := s.usecase.Update(ctx, uint(req.GetId()), role); err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// DeleteRole will delete data
+func (s *roleServer) DeleteRole(ctx context.Context, req *rolev1.DeleteRoleRequest) (*emptypb.Empty, error) {
+	if err := s.usecase.Delete(ctx, uint(req.GetId())); err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
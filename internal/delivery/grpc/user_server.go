@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"context"
+
+	"go-app/internal/adapter/presenter"
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/usecase/user"
+	"go-app/pkg/errors"
+	"go-app/pkg/validate"
+	userv1 "go-app/proto/user/v1"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// userServer represent the grpc handler of userv1.UserServiceServer
+type userServer struct {
+	userv1.UnimplementedUserServiceServer
+	usecase   *user.Usecase
+	validator *validate.CustomValidate
+}
+
+// NewUserServer will create new an userServer object
+func NewUserServer(usecase *user.Usecase, validator *validate.CustomValidate) userv1.UserServiceServer {
+	return &userServer{
+		usecase:   usecase,
+		validator: validator,
+	}
+}
+
+// ListUsers will fetch data
+func (s *userServer) ListUsers(ctx context.Context, req *userv1.ListUsersRequest) (*userv1.ListUsersResponse, error) {
+	listReq := presenter.ConvertListUsersProtoToRequest(req)
+	if err := s.validator.Validate(listReq); err != nil {
+		return nil, errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	q := presenter.ConvertUserListRequestToQuery(listReq, req.GetList().GetUseCursor())
+	users, pg, err := s.usecase.Fetch(ctx, q)
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	res := &userv1.ListUsersResponse{Pagination: presenter.ConvertPaginationToProto(pg)}
+	for i := range users {
+		res.Users = append(res.Users, presenter.ConvertUserEntityToProto(&users[i]))
+	}
+
+	return res, nil
+}
+
+// GetUser will Find data
+func (s *userServer) GetUser(ctx context.Context, req *userv1.GetUserRequest) (*userv1.User, error) {
+	user, err := s.usecase.Find(ctx, uint(req.GetId()))
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	return presenter.ConvertUserEntityToProto(user), nil
+}
+
+// CreateUser will create data
+func (s *userServer) CreateUser(ctx context.Context, req *userv1.CreateUserRequest) (*userv1.User, error) {
+	userReq := &dto.UserRequest{
+		Name:     req.GetName(),
+		Email:    req.GetEmail(),
+		RoleID:   uint(req.GetRoleId()),
+		Password: req.GetPassword(),
+	}
+	if err := s.validator.Validate(userReq); err != nil {
+		return nil, errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	user := presenter.ConvertUserRequestToEntity(userReq)
+	if err := s.usecase.Store(ctx, user); err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	return presenter.ConvertUserEntityToProto(user), nil
+}
+
+// UpdateUser will update data
+func (s *userServer) UpdateUser(ctx context.Context, req *userv1.UpdateUserRequest) (*emptypb.Empty, error) {
+	userReq := &dto.UserRequest{
+		Name:     req.GetName(),
+		Email:    req.GetEmail(),
+		RoleID:   uint(req.GetRoleId()),
+		Password: req.GetPassword(),
+	}
+	if err := s.validator.Validate(userReq); err != nil {
+		return nil, errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	user := presenter.ConvertUserRequestToEntity(userReq)
+	if err := s.usecase.Update(ctx, uint(req.GetId()), user); err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// DeleteUser will delete data
+func (s *userServer) DeleteUser(ctx context.Context, req *userv1.DeleteUserRequest) (*emptypb.Empty, error) {
+	if err := s.usecase.Delete(ctx, uint(req.GetId())); err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
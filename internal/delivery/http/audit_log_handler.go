@@ -0,0 +1,47 @@
+package http
+
+import (
+	"net/http"
+
+	"go-app/internal/adapter/presenter"
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/usecase/audit"
+	"go-app/pkg/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// auditLogHandler represent the http handler
+type auditLogHandler struct {
+	usecase *audit.Usecase
+}
+
+// NewAuditLogHandler will create new an auditLogHandler object
+func NewAuditLogHandler(usecase *audit.Usecase) *auditLogHandler {
+	return &auditLogHandler{
+		usecase: usecase,
+	}
+}
+
+// Index will fetch data
+func (hl *auditLogHandler) Index(c echo.Context) error {
+	listReq := new(dto.AuditLogListRequest)
+	if err := c.Bind(listReq); err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+	if err := c.Validate(listReq); err != nil {
+		return errors.ErrUnprocessableEntity.Wrap(err)
+	}
+	q := presenter.ConvertAuditLogListRequestToQuery(listReq, c.QueryParams().Has("cursor"))
+	ctx := c.Request().Context()
+	logs, pg, err := hl.usecase.Fetch(ctx, q)
+	if err != nil {
+		return errors.Throw(err)
+	}
+	logsRes := make([]dto.AuditLogResponse, 0)
+	for i := range logs {
+		logsRes = append(logsRes, presenter.ConvertAuditLogEntityToResponse(&logs[i]))
+	}
+
+	return c.JSON(http.StatusOK, presenter.ConvertPaginationToResponse(logsRes, pg, c.Request().URL))
+}
@@ -38,12 +38,37 @@ func (hl *authHandler) Login(c echo.Context) error {
 
 	ctx := c.Request().Context()
 	user := presenter.ConvertLoginRequestToEntity(userReq)
-	tokenStr, exp, err := hl.usecase.Login(ctx, user, c.RealIP())
+	session := presenter.ConvertLoginRequestToSession(userReq, c.RealIP(), c.Request().UserAgent())
+	token, challenge, err := hl.usecase.Login(ctx, user, session)
 	if err != nil {
 		return errors.Throw(err)
 	}
 
-	return c.JSON(http.StatusOK, presenter.ConvertUserToLoginResponse(*user, tokenStr, exp))
+	if challenge != nil {
+		return c.JSON(http.StatusOK, presenter.ConvertChallengeToResponse(challenge))
+	}
+
+	return c.JSON(http.StatusOK, presenter.ConvertUserToLoginResponse(*user, token))
+}
+
+// Refresh will rotate the refresh token and return a new access token
+func (hl *authHandler) Refresh(c echo.Context) error {
+	tokenReq := new(dto.RefreshTokenRequest)
+	if err := c.Bind(tokenReq); err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+
+	if err := c.Validate(tokenReq); err != nil {
+		return errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	ctx := c.Request().Context()
+	user, token, err := hl.usecase.Refresh(ctx, tokenReq.RefreshToken, c.RealIP())
+	if err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, presenter.ConvertUserToLoginResponse(*user, token))
 }
 
 // Logout for user
@@ -99,9 +124,10 @@ func (hl *authHandler) ChangePassword(c echo.Context) error {
 	if !ok {
 		return errors.ErrBadRequest.Trace()
 	}
+	sessionID, _ := c.Get(constant.GuardSession).(string)
 
 	ctx := c.Request().Context()
-	if err := hl.usecase.ChangePassword(ctx, user, userReq.ConfirmPassword, userReq.Password); err != nil {
+	if err := hl.usecase.ChangePassword(ctx, user, sessionID, userReq.ConfirmPassword, userReq.Password); err != nil {
 		return errors.Throw(err)
 	}
 
@@ -145,3 +171,93 @@ func (hl *authHandler) ResetPassword(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, dto.StatusResponse{Status: true})
 }
+
+// Sessions will list active sessions of user
+func (hl *authHandler) Sessions(c echo.Context) error {
+	user, ok := c.Get(constant.GuardJWT).(*entity.User)
+	if !ok {
+		return errors.ErrBadRequest.Trace()
+	}
+	sessionID, _ := c.Get(constant.GuardSession).(string)
+
+	ctx := c.Request().Context()
+	sessions, err := hl.usecase.FetchSessions(ctx, user.ID)
+	if err != nil {
+		return errors.Throw(err)
+	}
+	sessionsRes := make([]dto.SessionResponse, 0)
+	for i := range sessions {
+		session := presenter.ConvertSessionEntityToResponse(&sessions[i], sessionID)
+		sessionsRes = append(sessionsRes, session)
+	}
+
+	return c.JSON(http.StatusOK, sessionsRes)
+}
+
+// RevokeSession will sign out a session of user
+func (hl *authHandler) RevokeSession(c echo.Context) error {
+	user, ok := c.Get(constant.GuardJWT).(*entity.User)
+	if !ok {
+		return errors.ErrBadRequest.Trace()
+	}
+
+	ctx := c.Request().Context()
+	if err := hl.usecase.RevokeSession(ctx, user.ID, c.Param("id")); err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.StatusResponse{Status: true})
+}
+
+// RevokeAllSessions will sign out user everywhere
+func (hl *authHandler) RevokeAllSessions(c echo.Context) error {
+	user, ok := c.Get(constant.GuardJWT).(*entity.User)
+	if !ok {
+		return errors.ErrBadRequest.Trace()
+	}
+
+	ctx := c.Request().Context()
+	if err := hl.usecase.RevokeAllSessions(ctx, user.ID); err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.StatusResponse{Status: true})
+}
+
+// VerifyEmail will verify email with values of the signed link
+func (hl *authHandler) VerifyEmail(c echo.Context) error {
+	verifyReq := &dto.VerifyEmailRequest{}
+	if err := c.Bind(verifyReq); err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+
+	if err := c.Validate(verifyReq); err != nil {
+		return errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	ctx := c.Request().Context()
+	if err := hl.usecase.VerifyEmail(ctx, verifyReq.ID, verifyReq.Expires, verifyReq.Signature); err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.StatusResponse{Status: true})
+}
+
+// ResendVerification will send the verification email again
+func (hl *authHandler) ResendVerification(c echo.Context) error {
+	resendReq := &dto.ResendVerificationRequest{}
+	if err := c.Bind(resendReq); err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+
+	if err := c.Validate(resendReq); err != nil {
+		return errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	ctx := c.Request().Context()
+	if err := hl.usecase.ResendVerificationEmail(ctx, resendReq.Email); err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.StatusResponse{Status: true})
+}
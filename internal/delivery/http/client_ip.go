@@ -0,0 +1,46 @@
+package http
+
+import (
+	"net"
+	"strings"
+
+	"go-app/pkg/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// NewIPExtractor returns the extractor of the client ip of the requests. Without trusted proxies the client is
+// the peer of the connection and X-Forwarded-For is ignored, trustedProxies lists the CIDR ranges or the ips of
+// the proxies separated by commas, the client is then the last address of X-Forwarded-For which is not one of
+// them. The loopback and private networks are not trusted unless they are listed
+func NewIPExtractor(trustedProxies string) (echo.IPExtractor, error) {
+	var options []echo.TrustOption
+	for proxy := range strings.SplitSeq(trustedProxies, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, errors.ErrInternalServerError.Wrap(&net.ParseError{Type: "IP address", Text: proxy})
+			}
+			proxy = ip.String() + "/128"
+			if ip.To4() != nil {
+				proxy = ip.String() + "/32"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, errors.ErrInternalServerError.Wrap(err)
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+	if len(options) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	options = append(options, echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false))
+
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
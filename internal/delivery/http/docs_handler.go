@@ -0,0 +1,53 @@
+package http
+
+import (
+	_ "embed"
+	"net/http"
+
+	"go-app/pkg/openapi"
+
+	"github.com/labstack/echo/v4"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+// docsPath is the path of the docs UI, its assets are served under it
+const docsPath = "/api/docs"
+
+// docsIndex is the page of the docs UI, it loads the assets of swagger ui and the document of the API
+//
+//go:embed docs/index.html
+var docsIndex []byte
+
+// docsHandler represent the http handler of the OpenAPI document and its docs UI
+type docsHandler struct {
+	spec *openapi.Document
+}
+
+// NewDocsHandler will create new a docsHandler object
+func NewDocsHandler(spec *openapi.Document) *docsHandler {
+	return &docsHandler{
+		spec: spec,
+	}
+}
+
+// Spec will return the OpenAPI document of the API
+func (hl *docsHandler) Spec(c echo.Context) error {
+	return c.JSON(http.StatusOK, hl.spec)
+}
+
+// Index will return the page of the docs UI
+func (hl *docsHandler) Index(c echo.Context) error {
+	return c.HTMLBlob(http.StatusOK, docsIndex)
+}
+
+// Asset will return an asset of the docs UI, the pages of the distribution are redirected to the index which
+// loads our document
+func (hl *docsHandler) Asset(c echo.Context) error {
+	switch name := c.Param("*"); name {
+	case "", "index.html", "swagger-initializer.js":
+		return c.Redirect(http.StatusMovedPermanently, docsPath)
+	default:
+		c.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=86400")
+		return echo.StaticFileHandler(name, swaggerFiles.FS)(c)
+	}
+}
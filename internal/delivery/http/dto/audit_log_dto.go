@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+)
+
+// AuditLogListRequest is query of audit logs listing
+type AuditLogListRequest struct {
+	ListRequest
+	ActorID     uint      `query:"actor_id"`
+	Action      string    `query:"action" validate:"max=100"`
+	TargetType  string    `query:"target_type" validate:"max=50"`
+	TargetID    string    `query:"target_id" validate:"max=100"`
+	CreatedFrom time.Time `query:"created_from"`
+	CreatedTo   time.Time `query:"created_to"`
+}
+
+// AuditLogResponse is struct used for audit log
+type AuditLogResponse struct {
+	ID         uint           `json:"id"`
+	ActorID    *uint          `json:"actor_id"`
+	Action     string         `json:"action"`
+	TargetType string         `json:"target_type"`
+	TargetID   string         `json:"target_id"`
+	Before     map[string]any `json:"before,omitempty"`
+	After      map[string]any `json:"after,omitempty"`
+	IP         string         `json:"ip"`
+	UserAgent  string         `json:"user_agent"`
+	RequestID  string         `json:"request_id"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
@@ -4,16 +4,28 @@ package dto
 type UserLoginRequest struct {
 	Email    string `json:"email" validate:"required"`
 	Password string `json:"password" validate:"required"`
+	Device   string `json:"device" validate:"max=150"`
 }
 
 // UserRegisterRequest is request for register
 type UserRegisterRequest struct {
 	Name     string `json:"name" validate:"required"`
-	Email    string `json:"email" validate:"required"`
-	RoleID   uint   `json:"role_id" validate:"required"`
+	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
 }
 
+// VerifyEmailRequest is request for verify email, values come from the signed link
+type VerifyEmailRequest struct {
+	ID        uint   `json:"id" validate:"required"`
+	Expires   int64  `json:"expires" validate:"required"`
+	Signature string `json:"signature" validate:"required"`
+}
+
+// ResendVerificationRequest is request for resend verification email
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
 // UserForgotRequest is request for forgot password
 type UserForgotRequest struct {
 	Email string `json:"email" validate:"required"`
@@ -31,6 +43,11 @@ type UserResetPasswordRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
+// RefreshTokenRequest is request for refresh token
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
 // UserLoginResponse is struct used for log in
 type UserLoginResponse struct {
 	ID     uint         `json:"id"`
@@ -42,8 +59,10 @@ type UserLoginResponse struct {
 
 // AuthResponse is struct used for token
 type AuthResponse struct {
-	AccessToken string `json:"access_token"`
-	ExpiresAt   int64  `json:"expires_at"`
+	AccessToken      string `json:"access_token"`
+	ExpiresAt        int64  `json:"expires_at"`
+	RefreshToken     string `json:"refresh_token"`
+	RefreshExpiresAt int64  `json:"refresh_expires_at"`
 }
 
 // StatusResponse is struct when success
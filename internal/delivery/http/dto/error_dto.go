@@ -0,0 +1,21 @@
+package dto
+
+// ProblemResponse is struct used for error, a problem details object of RFC 9457 extended with the code of the
+// error, the ids of the request and of its trace and the messages of the invalid fields
+type ProblemResponse struct {
+	Type      string               `json:"type"`
+	Title     string               `json:"title"`
+	Status    int                  `json:"status"`
+	Detail    string               `json:"detail,omitempty"`
+	Instance  string               `json:"instance,omitempty"`
+	Code      int                  `json:"code"`
+	RequestID string               `json:"request_id,omitempty"`
+	TraceID   string               `json:"trace_id,omitempty"`
+	Errors    []FieldErrorResponse `json:"errors,omitempty"`
+}
+
+// FieldErrorResponse is struct used for the messages of an invalid field, field is its name in the request
+type FieldErrorResponse struct {
+	Field    string   `json:"field"`
+	Messages []string `json:"messages"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+// HealthResponse is struct used for the liveness and the readiness probes
+type HealthResponse struct {
+	Status string                `json:"status"`
+	Checks []HealthCheckResponse `json:"checks"`
+}
+
+// HealthCheckResponse is struct used for the check of a dependency, the error is logged only
+type HealthCheckResponse struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	Required  bool    `json:"required"`
+	LatencyMS float64 `json:"latency_ms"`
+}
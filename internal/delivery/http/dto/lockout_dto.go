@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"time"
+)
+
+// LockoutRequest is query of the lockouts of an email, an ip or both
+type LockoutRequest struct {
+	Email string `query:"email" validate:"required_without=IP,omitempty,email"`
+	IP    string `query:"ip" validate:"required_without=Email,omitempty,ip"`
+}
+
+// LockoutResponse is struct used for lockout, RetryAfter is in seconds and LockedUntil is null when the subject
+// is not locked
+type LockoutResponse struct {
+	Scope       string     `json:"scope"`
+	Subject     string     `json:"subject"`
+	Failures    int        `json:"failures"`
+	Level       int        `json:"level"`
+	Locked      bool       `json:"locked"`
+	RetryAfter  int        `json:"retry_after"`
+	LockedUntil *time.Time `json:"locked_until"`
+}
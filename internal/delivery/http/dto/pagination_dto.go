@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+)
+
+// ListRequest is query of a paginated listing, sort is a comma separated list of fields, prefixed by - for desc
+type ListRequest struct {
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Cursor string `query:"cursor"`
+	Sort   string `query:"sort" validate:"max=255"`
+}
+
+// UserListRequest is query of users listing
+type UserListRequest struct {
+	ListRequest
+	RoleID        uint      `query:"role_id"`
+	Email         string    `query:"email"`
+	EmailContains string    `query:"email_contains" validate:"max=255"`
+	Name          string    `query:"name" validate:"max=255"`
+	CreatedFrom   time.Time `query:"created_from"`
+	CreatedTo     time.Time `query:"created_to"`
+}
+
+// RoleListRequest is query of roles listing
+type RoleListRequest struct {
+	ListRequest
+	Name        string    `query:"name" validate:"max=255"`
+	Slug        string    `query:"slug"`
+	CreatedFrom time.Time `query:"created_from"`
+	CreatedTo   time.Time `query:"created_to"`
+}
+
+// PaginatedResponse is the envelope of a paginated listing
+type PaginatedResponse[T any] struct {
+	Data  []T             `json:"data"`
+	Meta  PaginationMeta  `json:"meta"`
+	Links PaginationLinks `json:"links"`
+}
+
+// PaginationMeta is the position of the page, total and last_page are omitted with a cursor
+type PaginationMeta struct {
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	Total      *int64 `json:"total,omitempty"`
+	LastPage   int    `json:"last_page,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// PaginationLinks are urls to navigate between pages
+type PaginationLinks struct {
+	Self  string `json:"self"`
+	First string `json:"first"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last,omitempty"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"time"
+)
+
+// PermissionRequest is request for create
+type PermissionRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"max=255"`
+}
+
+// RolePermissionRequest is request for sync permissions of role
+type RolePermissionRequest struct {
+	PermissionIDs []uint `json:"permission_ids" validate:"required"`
+}
+
+// PermissionResponse is struct used for permission
+type PermissionResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
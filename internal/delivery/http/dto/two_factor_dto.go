@@ -0,0 +1,30 @@
+package dto
+
+// TwoFactorConfirmRequest is request for confirm two factor
+type TwoFactorConfirmRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// TwoFactorVerifyRequest is request for the second step of log in, code is a TOTP or recovery code
+type TwoFactorVerifyRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
+// TwoFactorSetupResponse is struct used for enrollment in authenticator apps
+type TwoFactorSetupResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+// TwoFactorRecoveryCodesResponse is struct used for recovery codes
+type TwoFactorRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorChallengeResponse is struct used when log in requires the second factor
+type TwoFactorChallengeResponse struct {
+	MFARequired bool   `json:"mfa_required"`
+	MFAToken    string `json:"mfa_token"`
+	ExpiresAt   int64  `json:"expires_at"`
+}
@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookRequest is request for create and update, an empty secret is generated on create and kept on update
+type WebhookRequest struct {
+	URL    string   `json:"url" validate:"required,url,max=2048"`
+	Events []string `json:"events" validate:"required,min=1,dive,required"`
+	Active *bool    `json:"active"`
+	Secret string   `json:"secret" validate:"omitempty,min=16,max=255"`
+}
+
+// WebhookListRequest is query of webhooks listing
+type WebhookListRequest struct {
+	ListRequest
+	URL    string `query:"url" validate:"max=2048"`
+	Active *bool  `query:"active"`
+}
+
+// WebhookDeliveryListRequest is query of webhook deliveries listing
+type WebhookDeliveryListRequest struct {
+	ListRequest
+	Status      string    `query:"status" validate:"omitempty,oneof=pending succeeded dead"`
+	EventName   string    `query:"event_name" validate:"max=100"`
+	CreatedFrom time.Time `query:"created_from"`
+	CreatedTo   time.Time `query:"created_to"`
+}
+
+// WebhookResponse is struct used for webhook, the secret is only returned on create
+type WebhookResponse struct {
+	ID        uint      `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookDeliveryResponse is struct used for webhook delivery
+type WebhookDeliveryResponse struct {
+	ID             uint                     `json:"id"`
+	WebhookID      uint                     `json:"webhook_id"`
+	EventID        uint                     `json:"event_id"`
+	EventName      string                   `json:"event_name"`
+	Payload        json.RawMessage          `json:"payload"`
+	Status         string                   `json:"status"`
+	Attempts       int                      `json:"attempts"`
+	NextAttemptAt  time.Time                `json:"next_attempt_at"`
+	LastStatusCode int                      `json:"last_status_code"`
+	LastError      string                   `json:"last_error"`
+	DeliveredAt    *time.Time               `json:"delivered_at"`
+	AttemptLogs    []WebhookAttemptResponse `json:"attempt_logs,omitempty"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+}
+
+// WebhookAttemptResponse is struct used for an attempt of webhook delivery
+type WebhookAttemptResponse struct {
+	ID           uint      `json:"id"`
+	Attempt      int       `json:"attempt"`
+	StatusCode   int       `json:"status_code"`
+	ResponseBody string    `json:"response_body"`
+	Error        string    `json:"error"`
+	DurationMS   int64     `json:"duration_ms"`
+	CreatedAt    time.Time `json:"created_at"`
+}
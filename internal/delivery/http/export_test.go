@@ -0,0 +1,4 @@
+package http
+
+// RequestMetrics exposes requestMetrics to the tests
+var RequestMetrics = requestMetrics
@@ -1,18 +1,34 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 
+	"go-app/internal/adapter/presenter"
+	"go-app/internal/delivery/http/dto"
 	"go-app/internal/domain/gateway"
 	"go-app/internal/infrastructure/config"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/metrics"
 	"go-app/internal/infrastructure/registry"
+	"go-app/internal/infrastructure/tracing"
 	"go-app/pkg/errors"
+	"go-app/pkg/i18n"
 	"go-app/pkg/logger"
 	"go-app/pkg/validate"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// mimeApplicationProblemJSON is the media type of the error responses
+	mimeApplicationProblemJSON = "application/problem+json"
+	// problemTypeBlank is the type of the problems without more semantics than their status, the code tells
+	// the errors apart
+	problemTypeBlank = "about:blank"
 )
 
 // NewHTTPHandler registry http
@@ -21,12 +37,34 @@ func NewHTTPHandler(
 	svc gateway.JWTService,
 	registry *registry.Registry,
 ) {
-	e.Use(middleware.Logger())
+	e.Use(requestID())
+	e.Use(requestTracing(config.GetAppConfig().AppName))
+	e.Use(requestMetrics())
+	e.Use(requestLogger())
+	e.Use(auditActor())
+	e.Use(locale())
 	e.Use(middleware.Recover())
 	e.Validator = validate.NewValidate()
-	e.HTTPErrorHandler = jsonErrorHandler
+	e.HTTPErrorHandler = problemErrorHandler
 	g := e.Group("/api")
 
+	// Liveness and readiness probes
+	healthHandler := NewHealthHandler(registry.HealthUc)
+	e.GET(healthzPath, healthHandler.Live)
+	e.GET(readyzPath, healthHandler.Ready)
+
+	// Prometheus metrics
+	e.GET(metricsPath, echo.WrapHandler(metrics.Handler()))
+
+	// Public keys verifying tokens
+	wellKnownHandler := NewWellKnownHandler(registry.JWTKeys)
+	e.GET("/.well-known/jwks.json", wellKnownHandler.JWKS)
+
+	// OpenAPI document and its docs UI
+	docsHandler := NewDocsHandler(NewOpenAPIDocument())
+	e.GET(docsPath, docsHandler.Index)
+	e.GET(docsPath+"/*", docsHandler.Asset)
+
 	// CORS restricted with a custom function to allow origins
 	// and with the GET, PUT, POST or DELETE methods allowed.
 	g.Use(middleware.CORSWithConfig(middleware.CORSConfig{
@@ -34,39 +72,116 @@ func NewHTTPHandler(
 		AllowMethods:    []string{http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete},
 	}))
 
+	g.GET("/openapi.json", docsHandler.Spec)
+
 	// Middleware
 	au := g.Group("")
-	au.Use(setupJWT())
-	au.Use(authenticated(svc))
+	au.Use(setupJWT(registry.JWTKeys))
+	au.Use(authenticated(svc, registry.AuthUc))
+	au.Use(RateLimit(registry.RateLimiter, rateLimitAPI))
+
+	// Routes which require a verified email depending on policy
+	vu := au.Group("")
+	vu.Use(RequireVerifiedEmail(config.GetAuthConfig().EmailVerification))
 
 	// Init Handler
 	authHandler := NewAuthHandler(registry.AuthUc)
 	userHandler := NewUserHandler(registry.UserUc)
 	roleHandler := NewRoleHandler(registry.RoleUc)
+	permissionHandler := NewPermissionHandler(registry.PermissionUc)
+	twoFactorHandler := NewTwoFactorHandler(registry.AuthUc)
+	auditLogHandler := NewAuditLogHandler(registry.AuditUc)
+	webhookHandler := NewWebhookHandler(registry.WebhookUc)
+	lockoutHandler := NewLockoutHandler(registry.AuthUc)
 
 	// Authenticated routes
-	g.POST("/login", authHandler.Login)
-	g.POST("/register", authHandler.Register)
-	g.POST("/forgot-password", authHandler.ForgotPassword)
-	g.POST("/reset-password", authHandler.ResetPassword)
+	authLimit := RateLimit(registry.RateLimiter, rateLimitAuth)
+	g.POST("/login", authHandler.Login, authLimit)
+	g.POST("/refresh", authHandler.Refresh, authLimit)
+	g.POST("/register", authHandler.Register, authLimit)
+	g.POST("/forgot-password", authHandler.ForgotPassword, authLimit)
+	g.POST("/reset-password", authHandler.ResetPassword, authLimit)
+	g.POST("/2fa/verify", twoFactorHandler.Verify, authLimit)
+	g.POST("/email/verify", authHandler.VerifyEmail, authLimit)
+	g.POST("/email/resend", authHandler.ResendVerification, authLimit)
 
 	au.POST("/logout", authHandler.Logout)
 	au.POST("/change-password", authHandler.ChangePassword)
 	au.GET("/me", authHandler.Me)
 
+	// Two factor routes
+	vu.POST("/2fa/setup", twoFactorHandler.Setup)
+	vu.POST("/2fa/confirm", twoFactorHandler.Confirm)
+
+	// Session routes
+	au.GET("/sessions", authHandler.Sessions)
+	au.DELETE("/sessions", authHandler.RevokeAllSessions)
+	au.DELETE("/sessions/:id", authHandler.RevokeSession)
+
 	// User routes
-	au.GET("/users", userHandler.Index)
-	au.GET("/users/:id", userHandler.Show)
-	au.POST("/users", userHandler.Store)
-	au.PATCH("/users/:id", userHandler.Update)
-	au.DELETE("/users/:id", userHandler.Delete)
+	vu.GET("/users", userHandler.Index, RequirePermission(constant.PermissionUsersView))
+	vu.GET("/users/:id", userHandler.Show, RequirePermission(constant.PermissionUsersView))
+	vu.POST("/users", userHandler.Store, RequirePermission(constant.PermissionUsersCreate))
+	vu.PATCH("/users/:id", userHandler.Update, RequirePermission(constant.PermissionUsersUpdate))
+	vu.DELETE("/users/:id", userHandler.Delete, RequirePermission(constant.PermissionUsersDelete))
 
 	// Role routes
-	au.GET("/roles", roleHandler.Index)
-	au.GET("/roles/:id", roleHandler.Show)
-	au.POST("/roles", roleHandler.Store)
-	au.PATCH("/roles/:id", roleHandler.Update)
-	au.DELETE("/roles/:id", roleHandler.Delete)
+	vu.GET("/roles", roleHandler.Index, RequirePermission(constant.PermissionRolesView))
+	vu.GET("/roles/:id", roleHandler.Show, RequirePermission(constant.PermissionRolesView))
+	vu.POST("/roles", roleHandler.Store, RequirePermission(constant.PermissionRolesCreate))
+	vu.PATCH("/roles/:id", roleHandler.Update, RequirePermission(constant.PermissionRolesUpdate))
+	vu.DELETE("/roles/:id", roleHandler.Delete, RequirePermission(constant.PermissionRolesDelete))
+
+	// Role permission routes
+	vu.GET("/roles/:id/permissions", permissionHandler.IndexByRole, RequirePermission(constant.PermissionRolesView))
+	vu.PUT("/roles/:id/permissions", permissionHandler.SyncRole, RequirePermission(constant.PermissionRolesUpdate))
+	vu.POST(
+		"/roles/:id/permissions/:permission_id",
+		permissionHandler.AttachRole,
+		RequirePermission(constant.PermissionRolesUpdate),
+	)
+	vu.DELETE(
+		"/roles/:id/permissions/:permission_id",
+		permissionHandler.DetachRole,
+		RequirePermission(constant.PermissionRolesUpdate),
+	)
+
+	// Permission routes
+	vu.GET("/permissions", permissionHandler.Index, RequirePermission(constant.PermissionPermissionsView))
+	vu.GET("/permissions/:id", permissionHandler.Show, RequirePermission(constant.PermissionPermissionsView))
+	vu.POST("/permissions", permissionHandler.Store, RequirePermission(constant.PermissionPermissionsCreate))
+	vu.PATCH("/permissions/:id", permissionHandler.Update, RequirePermission(constant.PermissionPermissionsUpdate))
+	vu.DELETE("/permissions/:id", permissionHandler.Delete, RequirePermission(constant.PermissionPermissionsDelete))
+
+	// Audit log routes
+	vu.GET("/audit-logs", auditLogHandler.Index, RequirePermission(constant.PermissionAuditLogsView))
+
+	// Login lockout routes
+	vu.GET("/lockouts", lockoutHandler.Index, RequirePermission(constant.PermissionLockoutsView))
+	vu.DELETE("/lockouts", lockoutHandler.Delete, RequirePermission(constant.PermissionLockoutsDelete))
+
+	// Webhook routes
+	vu.GET("/webhooks", webhookHandler.Index, RequirePermission(constant.PermissionWebhooksView))
+	vu.GET("/webhooks/:id", webhookHandler.Show, RequirePermission(constant.PermissionWebhooksView))
+	vu.POST("/webhooks", webhookHandler.Store, RequirePermission(constant.PermissionWebhooksCreate))
+	vu.PATCH("/webhooks/:id", webhookHandler.Update, RequirePermission(constant.PermissionWebhooksUpdate))
+	vu.DELETE("/webhooks/:id", webhookHandler.Delete, RequirePermission(constant.PermissionWebhooksDelete))
+	vu.GET(
+		"/webhooks/:id/deliveries",
+		webhookHandler.IndexDeliveries,
+		RequirePermission(constant.PermissionWebhooksView),
+	)
+	vu.GET(
+		"/webhooks/:id/deliveries/:delivery_id",
+		webhookHandler.ShowDelivery,
+		RequirePermission(constant.PermissionWebhooksView),
+	)
+	vu.POST(
+		"/webhooks/:id/deliveries/:delivery_id/redeliver",
+		webhookHandler.Redeliver,
+		RequirePermission(constant.PermissionWebhooksUpdate),
+		RateLimit(registry.RateLimiter, rateLimitRedeliver),
+	)
 }
 
 func corsAllowOrigin(origin string) (bool, error) {
@@ -81,46 +196,59 @@ func corsAllowOrigin(origin string) (bool, error) {
 	return false, nil
 }
 
-func jsonErrorHandler(err error, ctx echo.Context) {
+// problemErrorHandler responds with the problem details of err in application/problem+json, the validation
+// errors list the messages of every invalid field in the locale of the request
+func problemErrorHandler(err error, ctx echo.Context) {
+	req := ctx.Request()
 	status := http.StatusInternalServerError
-	responseError := struct {
-		Code    int    `json:"code"`
-		Message string `json:"message"`
-	}{
-		Code:    status,
-		Message: http.StatusText(status),
+	problem := dto.ProblemResponse{
+		Type:      problemTypeBlank,
+		Status:    status,
+		Detail:    http.StatusText(status),
+		Instance:  req.URL.Path,
+		Code:      status,
+		RequestID: logger.RequestID(req.Context()),
+		TraceID:   tracing.TraceID(req.Context()),
 	}
 
 	var he *echo.HTTPError
 	if errors.As(err, &he) {
 		status = he.Code
-		responseError.Code = status
+		problem.Code = status
+		problem.Detail = http.StatusText(status)
 		if m, ok := he.Message.(string); ok {
-			responseError.Message = m
+			problem.Detail = m
 		}
 	}
 
 	var be *errors.BaseError
 	if errors.As(err, &be) {
 		status = be.Status
-		responseError.Message = be.Message
-		if status == http.StatusUnprocessableEntity {
-			if beErr := be.Unwrap(); beErr != nil {
-				responseError.Message = beErr.Error()
-			}
+		problem.Code = be.Code
+		problem.Detail = be.Message
+		var ve *validate.ValidationError
+		switch {
+		case errors.As(be, &ve):
+			problem.Errors = presenter.ConvertFieldErrorsToResponse(ve.Fields(i18n.Locale(req.Context())))
+		case status == http.StatusUnprocessableEntity && be.Unwrap() != nil:
+			problem.Detail = be.Unwrap().Error()
 		}
-		responseError.Code = be.Code
 	}
+	// about:blank problems are titled by their status
+	problem.Status = status
+	problem.Title = http.StatusText(status)
 
 	if !ctx.Response().Committed {
-		// Logger if status >= 500
+		// Logger and trace if status >= 500
 		if status >= http.StatusInternalServerError {
-			logger.Debugf("%+v", err)
+			logger.ErrorContext(req.Context(), "internal error", "error", fmt.Sprintf("%+v", err))
+			trace.SpanFromContext(req.Context()).RecordError(err)
 		}
-		if ctx.Request().Method == http.MethodHead { // Issue #608
+		if req.Method == http.MethodHead { // Issue #608
 			err = ctx.NoContent(status)
 		} else {
-			err = ctx.JSON(status, responseError)
+			ctx.Response().Header().Set(echo.HeaderContentType, mimeApplicationProblemJSON)
+			err = ctx.JSON(status, problem)
 		}
 		if err != nil {
 			ctx.Logger().Error(err)
@@ -0,0 +1,95 @@
+package http_test
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-app/internal/adapter/gateway/limiter"
+	"go-app/internal/delivery/http"
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/infrastructure/registry"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestProblemResponse(t *testing.T) {
+	e := echo.New()
+	http.NewHTTPHandler(e, nil, &registry.Registry{RateLimiter: limiter.NewMemoryLimiter(0)})
+
+	expectedResults := []struct {
+		body     string
+		locale   string
+		expected dto.ProblemResponse
+	}{
+		{
+			`{"name":"Jane","email":"jane"}`,
+			"",
+			dto.ProblemResponse{
+				Type:     "about:blank",
+				Title:    "Unprocessable Entity",
+				Status:   nethttp.StatusUnprocessableEntity,
+				Detail:   "Unprocessable entity.",
+				Instance: "/api/register",
+				Code:     10007,
+				Errors: []dto.FieldErrorResponse{
+					{Field: "email", Messages: []string{"email invalid email!"}},
+					{Field: "password", Messages: []string{"password must have a value!"}},
+				},
+			},
+		},
+		{
+			`{"name":"Jane","email":"jane@example.com"}`,
+			"vi",
+			dto.ProblemResponse{
+				Type:     "about:blank",
+				Title:    "Unprocessable Entity",
+				Status:   nethttp.StatusUnprocessableEntity,
+				Detail:   "Unprocessable entity.",
+				Instance: "/api/register",
+				Code:     10007,
+				Errors: []dto.FieldErrorResponse{
+					{Field: "password", Messages: []string{"password không được bỏ trống"}},
+				},
+			},
+		},
+		{
+			`{"name":`,
+			"",
+			dto.ProblemResponse{
+				Type:     "about:blank",
+				Title:    "Bad Request",
+				Status:   nethttp.StatusBadRequest,
+				Detail:   "Bad request.",
+				Instance: "/api/register",
+				Code:     10002,
+			},
+		},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		req := httptest.NewRequest(nethttp.MethodPost, "/api/register", strings.NewReader(testExpected.body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("Accept-Language", testExpected.locale)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Header().Get(echo.HeaderContentType) != "application/problem+json" {
+			t.Errorf("#%d got content type %q", testNumber, rec.Header().Get(echo.HeaderContentType))
+		}
+		var got dto.ProblemResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.RequestID == "" || got.RequestID != rec.Header().Get(echo.HeaderXRequestID) {
+			t.Errorf("#%d got request id %q", testNumber, got.RequestID)
+		}
+		got.RequestID = ""
+		if rec.Code != testExpected.expected.Status || !reflect.DeepEqual(got, testExpected.expected) {
+			t.Errorf("#%d got %d %+v, %+v expected", testNumber, rec.Code, got, testExpected.expected)
+		}
+	}
+}
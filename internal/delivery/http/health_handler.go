@@ -0,0 +1,52 @@
+package http
+
+import (
+	"net/http"
+
+	"go-app/internal/adapter/presenter"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/usecase/health"
+	"go-app/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+// healthHandler represent the http handler of the probes
+type healthHandler struct {
+	usecase *health.Usecase
+}
+
+// NewHealthHandler will create new a healthHandler object
+func NewHealthHandler(usecase *health.Usecase) *healthHandler {
+	return &healthHandler{
+		usecase: usecase,
+	}
+}
+
+// Live will report the process is serving
+func (hl *healthHandler) Live(c echo.Context) error {
+	report := hl.usecase.Live()
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	return c.JSON(http.StatusOK, presenter.ConvertHealthReportToResponse(&report))
+}
+
+// Ready will report the checks of the dependencies, 503 when a required one is down, the errors of the checks which
+// are not up are logged rather than answered so the probe does not expose them
+func (hl *healthHandler) Ready(c echo.Context) error {
+	ctx := c.Request().Context()
+	report := hl.usecase.Ready(ctx)
+	for _, check := range report.Checks {
+		if check.Status != constant.HealthStatusUp {
+			logger.WarnContext(ctx, "health check", "name", check.Name, "status", check.Status,
+				"required", check.Required, "latency", check.Latency, "error", check.Error)
+		}
+	}
+	status := http.StatusOK
+	if report.Status == constant.HealthStatusDown {
+		status = http.StatusServiceUnavailable
+	}
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	return c.JSON(status, presenter.ConvertHealthReportToResponse(&report))
+}
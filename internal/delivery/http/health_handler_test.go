@@ -0,0 +1,55 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-app/internal/adapter/gateway/limiter"
+	"go-app/internal/delivery/http"
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/registry"
+	"go-app/internal/usecase/health"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestReadyHidesErrors(t *testing.T) {
+	down := gateway.HealthCheckFunc(func(context.Context) error { return errors.New("dial tcp 10.0.0.5:5432") })
+
+	expectedResults := []struct {
+		required bool
+		status   int
+		report   string
+	}{
+		{false, nethttp.StatusOK, constant.HealthStatusDegraded},
+		{true, nethttp.StatusServiceUnavailable, constant.HealthStatusDown},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		uc := health.NewUsecase(0)
+		uc.Register("postgres", down, testExpected.required)
+		e := echo.New()
+		http.NewHTTPHandler(e, nil, &registry.Registry{RateLimiter: limiter.NewMemoryLimiter(0), HealthUc: uc})
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, "/readyz", nil))
+		if rec.Code != testExpected.status || strings.Contains(rec.Body.String(), "10.0.0.5") {
+			t.Errorf("#%d got %d %s, %d without the error expected", testNumber, rec.Code, rec.Body, testExpected.status)
+		}
+		res := dto.HealthResponse{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+			t.Fatal(err)
+		}
+		if res.Status != testExpected.report || len(res.Checks) != 1 || res.Checks[0].Name != "postgres" ||
+			res.Checks[0].Status != constant.HealthStatusDown || res.Checks[0].Required != testExpected.required {
+			t.Errorf("#%d got %+v, the check of postgres expected", testNumber, res)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"go-app/internal/adapter/presenter"
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/usecase/auth"
+	"go-app/pkg/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// lockoutHandler represent the http handler
+type lockoutHandler struct {
+	usecase *auth.Usecase
+}
+
+// NewLockoutHandler will create new an lockoutHandler object
+func NewLockoutHandler(usecase *auth.Usecase) *lockoutHandler {
+	return &lockoutHandler{
+		usecase: usecase,
+	}
+}
+
+// Index will show the failed logins and the lockouts of the email and the ip
+func (hl *lockoutHandler) Index(c echo.Context) error {
+	req := new(dto.LockoutRequest)
+	if err := c.Bind(req); err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+	if err := c.Validate(req); err != nil {
+		return errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	ctx := c.Request().Context()
+	lockouts, err := hl.usecase.Lockouts(ctx, req.Email, req.IP)
+	if err != nil {
+		return errors.Throw(err)
+	}
+	now := time.Now()
+	lockoutsRes := make([]dto.LockoutResponse, 0, len(lockouts))
+	for i := range lockouts {
+		lockoutsRes = append(lockoutsRes, presenter.ConvertLockoutEntityToResponse(&lockouts[i], now))
+	}
+
+	return c.JSON(http.StatusOK, lockoutsRes)
+}
+
+// Delete will clear the failed logins and the lockouts of the email and the ip
+func (hl *lockoutHandler) Delete(c echo.Context) error {
+	req := new(dto.LockoutRequest)
+	if err := c.Bind(req); err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+	if err := c.Validate(req); err != nil {
+		return errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	ctx := c.Request().Context()
+	if err := hl.usecase.Unlock(ctx, req.Email, req.IP); err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.StatusResponse{Status: true})
+}
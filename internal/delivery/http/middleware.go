@@ -1,40 +1,265 @@
 package http
 
 import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
 	"go-app/internal/adapter/gateway/service"
+	"go-app/internal/domain/entity"
 	"go-app/internal/domain/gateway"
-	"go-app/internal/infrastructure/config"
 	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/metrics"
+	"go-app/internal/usecase/audit"
+	"go-app/internal/usecase/auth"
 	"go-app/pkg/errors"
+	"go-app/pkg/i18n"
+	"go-app/pkg/logger"
+	"go-app/pkg/utils"
 
 	"github.com/golang-jwt/jwt/v5"
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+)
+
+const (
+	// requestIDMaxLength is the longest X-Request-ID propagated from clients
+	requestIDMaxLength = 128
+	// healthzPath is the path of the liveness probe
+	healthzPath = "/healthz"
+	// readyzPath is the path of the readiness probe
+	readyzPath = "/readyz"
+	// metricsPath is the path of the prometheus metrics
+	metricsPath = "/metrics"
 )
 
+// requestID propagates the X-Request-ID header, an id is generated when it is missing or invalid,
+// the id and the route are attached to the logs of the request
+func requestID() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			id := c.Request().Header.Get(echo.HeaderXRequestID)
+			if id == "" || len(id) > requestIDMaxLength || strings.ContainsFunc(id, unicode.IsControl) {
+				id = utils.GenerateUUID()
+			}
+			c.Response().Header().Set(echo.HeaderXRequestID, id)
+
+			ctx := logger.WithRequestID(c.Request().Context(), id)
+			ctx = logger.WithRoute(ctx, c.Path())
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+// requestTracing starts the server span of the requests, continuing the trace context of the caller, the probes
+// and the scrapes are not traced
+func requestTracing(service string) echo.MiddlewareFunc {
+	return otelecho.Middleware(service, otelecho.WithSkipper(func(c echo.Context) bool {
+		return c.Path() == healthzPath || c.Path() == readyzPath || c.Path() == metricsPath
+	}))
+}
+
+// requestMetrics observes the requests by route template, the unknown routes share one label so scanners
+// do not create a series per path
+func requestMetrics() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			metrics.HTTPRequestsInFlight.Inc()
+			defer metrics.HTTPRequestsInFlight.Dec()
+
+			start := time.Now()
+			err := next(c)
+
+			// The catch-all routes of the groups match the unknown paths, before or after their middleware fails
+			route := c.Path()
+			if route == "" || route == "/*" || route == "/api/*" {
+				route = "unmatched"
+			}
+			method := c.Request().Method
+			metrics.HTTPRequestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+			metrics.HTTPRequests.WithLabelValues(method, route, strconv.Itoa(responseStatus(c, err))).Inc()
+
+			return err
+		}
+	}
+}
+
+// responseStatus returns the status the error handler answers err with, the written one when the response is
+// committed or there is no error
+func responseStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+
+	var be *errors.BaseError
+	if errors.As(err, &be) {
+		return be.Status
+	}
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		return he.Code
+	}
+
+	return http.StatusInternalServerError
+}
+
+// requestLogger logs every request with the fields of its context, the successful probes and scrapes are left out
+func requestLogger() echo.MiddlewareFunc {
+	return middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
+		LogMethod:       true,
+		LogURI:          true,
+		LogStatus:       true,
+		LogLatency:      true,
+		LogRemoteIP:     true,
+		LogUserAgent:    true,
+		LogResponseSize: true,
+		LogError:        true,
+		HandleError:     true,
+		LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
+			probe := c.Path() == healthzPath || c.Path() == readyzPath || c.Path() == metricsPath
+			if probe && v.Status < http.StatusBadRequest {
+				return nil
+			}
+			attrs := []any{
+				"method", v.Method,
+				"uri", v.URI,
+				"status", v.Status,
+				"latency", v.Latency,
+				"ip", v.RemoteIP,
+				"user_agent", v.UserAgent,
+				"bytes_out", v.ResponseSize,
+			}
+			if v.Error != nil {
+				attrs = append(attrs, "error", v.Error.Error())
+			}
+
+			ctx := c.Request().Context()
+			switch {
+			case v.Status >= http.StatusInternalServerError:
+				logger.ErrorContext(ctx, "request", attrs...)
+			case v.Status >= http.StatusBadRequest:
+				logger.WarnContext(ctx, "request", attrs...)
+			default:
+				logger.InfoContext(ctx, "request", attrs...)
+			}
+
+			return nil
+		},
+	})
+}
+
+// auditActor attaches the client ip and user agent to the audit actor of the request
+func auditActor() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := audit.WithActor(c.Request().Context(), audit.Actor{
+				IP:        c.RealIP(),
+				UserAgent: c.Request().UserAgent(),
+			})
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+// locale carries the Accept-Language of the request to the usecases, the mails are sent in this locale
+func locale() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := i18n.WithLocale(c.Request().Context(), c.Request().Header.Get("Accept-Language"))
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
 // setupJWT .-
-func setupJWT() echo.MiddlewareFunc {
+func setupJWT(keys *service.JWTKeySet) echo.MiddlewareFunc {
 	jwtConf := echojwt.Config{
 		NewClaimsFunc: func(c echo.Context) jwt.Claims {
 			return new(service.CustomClaims)
 		},
-		SigningKey: []byte(config.GetAppConfig().AppJWTKey),
+		KeyFunc: keys.Keyfunc,
 	}
 
 	return echojwt.WithConfig(jwtConf)
 }
 
 // authenticated .-
-func authenticated(svc gateway.JWTService) echo.MiddlewareFunc {
+func authenticated(svc gateway.JWTService, uc *auth.Usecase) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			token := c.Get("user")
-			user, err := svc.Decode(c.Request().Context(), token)
+			ctx := c.Request().Context()
+			user, err := svc.Decode(ctx, token)
 			if err != nil {
 				return errors.Throw(err)
 			}
 
+			// Reject revoked sessions and record last seen
+			sessionID, err := svc.SessionID(token)
+			if err != nil {
+				return errors.Throw(err)
+			}
+			if err := uc.TouchSession(ctx, sessionID, c.RealIP()); err != nil {
+				return errors.Throw(err)
+			}
+
 			c.Set(constant.GuardJWT, user)
+			c.Set(constant.GuardSession, sessionID)
+			ctx = logger.WithUserID(ctx, user.ID)
+			ctx = audit.WithActorID(ctx, user.ID)
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+// RequirePermission allows the request only when the authenticated user has the permission
+func RequirePermission(permission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user, ok := c.Get(constant.GuardJWT).(*entity.User)
+			if !ok {
+				return errors.ErrUnauthenticated.Trace()
+			}
+
+			if !slices.Contains(user.Permissions, permission) {
+				return errors.ErrForbidden.Trace()
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RequireVerifiedEmail allows the request only when the email of user is verified,
+// it is enforced only when the policy is constant.EmailVerificationRoutes
+func RequireVerifiedEmail(policy string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if policy != constant.EmailVerificationRoutes {
+				return next(c)
+			}
+
+			user, ok := c.Get(constant.GuardJWT).(*entity.User)
+			if !ok {
+				return errors.ErrUnauthenticated.Trace()
+			}
+
+			if user.EmailVerifiedAt == nil {
+				return errors.ErrAuthEmailNotVerified.Trace()
+			}
 
 			return next(c)
 		}
@@ -0,0 +1,103 @@
+package http_test
+
+import (
+	"context"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"go-app/internal/adapter/gateway/limiter"
+	"go-app/internal/delivery/http"
+	"go-app/internal/infrastructure/metrics"
+	"go-app/internal/infrastructure/registry"
+	"go-app/pkg/errors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRequestMetrics(t *testing.T) {
+	e := echo.New()
+	http.NewHTTPHandler(e, nil, &registry.Registry{RateLimiter: limiter.NewMemoryLimiter(0)})
+
+	expectedResults := []struct {
+		path   string
+		route  string
+		status int
+	}{
+		// The requests of a route share the label of its template
+		{"/api/users/7", "/api/users/:id", nethttp.StatusBadRequest},
+		{"/api/users/8", "/api/users/:id", nethttp.StatusBadRequest},
+		// The unknown paths share one label, the api ones are rejected without a token first
+		{"/wp-login.php", "unmatched", nethttp.StatusNotFound},
+		{"/api/unknown/9", "unmatched", nethttp.StatusBadRequest},
+	}
+
+	counts := map[[2]string]float64{}
+	for _, testExpected := range expectedResults {
+		key := [2]string{testExpected.route, strconv.Itoa(testExpected.status)}
+		counts[key] = testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues(nethttp.MethodGet, key[0], key[1]))
+	}
+	for testNumber, testExpected := range expectedResults {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, testExpected.path, nil))
+		if rec.Code != testExpected.status {
+			t.Errorf("#%d got status %d, %d expected", testNumber, rec.Code, testExpected.status)
+		}
+		counts[[2]string{testExpected.route, strconv.Itoa(testExpected.status)}]++
+	}
+
+	for key, expected := range counts {
+		got := testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues(nethttp.MethodGet, key[0], key[1]))
+		if got != expected {
+			t.Errorf("got %v requests of %s with %s, %v expected", got, key[0], key[1], expected)
+		}
+	}
+	for _, route := range []string{"/wp-login.php", "/api/unknown/9", "/api/*", "/*"} {
+		if n := testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues(nethttp.MethodGet, route, "400")) +
+			testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues(nethttp.MethodGet, route, "404")); n != 0 {
+			t.Errorf("got %v requests labelled %s, none expected", n, route)
+		}
+	}
+}
+
+func TestRequestMetricsReturnsError(t *testing.T) {
+	expectedResults := []struct {
+		err    error
+		status int
+	}{
+		{nil, nethttp.StatusNoContent},
+		{errors.ErrNotFound.Trace(), nethttp.StatusNotFound},
+		{echo.NewHTTPError(nethttp.StatusTeapot), nethttp.StatusTeapot},
+		{context.DeadlineExceeded, nethttp.StatusInternalServerError},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		e := echo.New()
+		route := "/metrics-error/" + strconv.Itoa(testNumber)
+		c := e.NewContext(httptest.NewRequest(nethttp.MethodGet, route, nil), httptest.NewRecorder())
+		c.SetPath(route)
+		before := testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues(nethttp.MethodGet, route,
+			strconv.Itoa(testExpected.status)))
+
+		// The error is left to the error handler, the status it answers is counted
+		err := http.RequestMetrics()(func(c echo.Context) error {
+			if testExpected.err == nil {
+				return c.NoContent(nethttp.StatusNoContent)
+			}
+			return testExpected.err
+		})(c)
+		if !errors.Is(err, testExpected.err) || (err == nil) != (testExpected.err == nil) {
+			t.Errorf("#%d got %v, %v expected", testNumber, err, testExpected.err)
+		}
+		if c.Response().Committed != (testExpected.err == nil) {
+			t.Errorf("#%d got the response committed %v", testNumber, c.Response().Committed)
+		}
+		got := testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues(nethttp.MethodGet, route,
+			strconv.Itoa(testExpected.status)))
+		if got != before+1 {
+			t.Errorf("#%d got %v requests with %d, %v expected", testNumber, got, testExpected.status, before+1)
+		}
+	}
+}
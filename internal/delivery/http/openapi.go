@@ -0,0 +1,677 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/infrastructure/config"
+	"go-app/internal/infrastructure/constant"
+	"go-app/pkg/openapi"
+)
+
+const (
+	// apiVersion is the version of the API in the OpenAPI document
+	apiVersion = "1.0.0"
+	// bearerAuth is the security scheme of the routes requiring an access token
+	bearerAuth = "bearerAuth"
+)
+
+// Tags grouping the operations of the OpenAPI document
+const (
+	tagAuth        = "Auth"
+	tagTwoFactor   = "Two factor"
+	tagSessions    = "Sessions"
+	tagUsers       = "Users"
+	tagRoles       = "Roles"
+	tagPermissions = "Permissions"
+	tagAuditLogs   = "Audit logs"
+	tagLockouts    = "Lockouts"
+	tagWebhooks    = "Webhooks"
+	tagSystem      = "System"
+)
+
+// idParam is the id of the resource of a route
+type idParam struct {
+	ID uint `param:"id"`
+}
+
+// rolePermissionParam is the role and the permission of a route
+type rolePermissionParam struct {
+	idParam
+	PermissionID uint `param:"permission_id"`
+}
+
+// webhookDeliveryParam is the webhook and the delivery of a route
+type webhookDeliveryParam struct {
+	idParam
+	DeliveryID uint `param:"delivery_id"`
+}
+
+// jwksResponse is the schema of the JWK set returned by the well-known handler
+type jwksResponse struct {
+	Keys []map[string]any `json:"keys"`
+}
+
+// NewOpenAPIDocument returns the OpenAPI document of the routes registered by NewHTTPHandler, a route
+// registered without its operation in the routes below fails the tests
+func NewOpenAPIDocument() *openapi.Document {
+	title := config.GetAppConfig().AppName
+	if title == "" {
+		title = "API"
+	}
+	doc := openapi.New(openapi.Info{Title: title, Version: apiVersion})
+	doc.AddSecurityScheme(bearerAuth, &openapi.SecurityScheme{
+		Type:         "http",
+		Scheme:       "bearer",
+		BearerFormat: "JWT",
+		Description:  "Access token returned by log in or refresh",
+	})
+	for _, routes := range [][]openapi.Route{systemRoutes(), authRoutes(), accessRoutes(), adminRoutes()} {
+		for _, route := range routes {
+			doc.AddRoute(route)
+		}
+	}
+
+	return doc
+}
+
+// systemRoutes documents the probes, the metrics, the public keys and the docs
+func systemRoutes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:    http.MethodGet,
+			Path:      healthzPath,
+			Summary:   "Liveness probe",
+			Tags:      []string{tagSystem},
+			Responses: responses(http.StatusOK, dto.HealthResponse{}),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    readyzPath,
+			Summary: "Readiness probe",
+			Tags:    []string{tagSystem},
+			Responses: append(
+				responses(http.StatusOK, dto.HealthResponse{}),
+				openapi.RouteResponse{
+					Status:      strconv.Itoa(http.StatusServiceUnavailable),
+					Description: "A required dependency is down",
+					Body:        dto.HealthResponse{},
+				},
+			),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    metricsPath,
+			Summary: "Prometheus metrics",
+			Tags:    []string{tagSystem},
+			Responses: []openapi.RouteResponse{
+				{Status: strconv.Itoa(http.StatusOK), Body: "", MediaType: "text/plain"},
+			},
+		},
+		{
+			Method:    http.MethodGet,
+			Path:      "/.well-known/jwks.json",
+			Summary:   "Public keys verifying the access tokens",
+			Tags:      []string{tagSystem},
+			Responses: responses(http.StatusOK, jwksResponse{}),
+		},
+		{
+			Method:    http.MethodGet,
+			Path:      "/api/openapi.json",
+			Summary:   "OpenAPI document of the API",
+			Tags:      []string{tagSystem},
+			Responses: responses(http.StatusOK, map[string]any{}),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    docsPath,
+			Summary: "Docs UI of the API",
+			Tags:    []string{tagSystem},
+			Responses: []openapi.RouteResponse{
+				{Status: strconv.Itoa(http.StatusOK), Body: "", MediaType: "text/html"},
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    docsPath + "/*",
+			Summary: "Assets of the docs UI",
+			Tags:    []string{tagSystem},
+			Responses: []openapi.RouteResponse{
+				{Status: strconv.Itoa(http.StatusOK), Body: "", MediaType: "application/octet-stream"},
+				{Status: strconv.Itoa(http.StatusMovedPermanently), Description: "Redirect to the docs UI"},
+			},
+		},
+	}
+}
+
+// authRoutes documents the authentication, the two factor and the session routes
+func authRoutes() []openapi.Route {
+	login := openapi.OneOf{dto.UserLoginResponse{}, dto.TwoFactorChallengeResponse{}}
+
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        "/api/login",
+			Summary:     "Log in",
+			Description: "Returns a challenge instead of the tokens when two factor authentication is enabled.",
+			Tags:        []string{tagAuth},
+			Body:        dto.UserLoginRequest{},
+			Responses:   responses(http.StatusOK, login, publicErrors...),
+		},
+		{
+			Method:    http.MethodPost,
+			Path:      "/api/refresh",
+			Summary:   "Refresh the tokens",
+			Tags:      []string{tagAuth},
+			Body:      dto.RefreshTokenRequest{},
+			Responses: responses(http.StatusOK, dto.UserLoginResponse{}, publicErrors...),
+		},
+		{
+			Method:    http.MethodPost,
+			Path:      "/api/register",
+			Summary:   "Register",
+			Tags:      []string{tagAuth},
+			Body:      dto.UserRegisterRequest{},
+			Responses: responses(http.StatusCreated, dto.UserResponse{}, publicErrors...),
+		},
+		{
+			Method:    http.MethodPost,
+			Path:      "/api/forgot-password",
+			Summary:   "Send a reset password link",
+			Tags:      []string{tagAuth},
+			Body:      dto.UserForgotRequest{},
+			Responses: responses(http.StatusOK, dto.StatusResponse{}, publicErrors...),
+		},
+		{
+			Method:    http.MethodPost,
+			Path:      "/api/reset-password",
+			Summary:   "Reset the password",
+			Tags:      []string{tagAuth},
+			Body:      dto.UserResetPasswordRequest{},
+			Responses: responses(http.StatusOK, dto.StatusResponse{}, publicErrors...),
+		},
+		{
+			Method:    http.MethodPost,
+			Path:      "/api/2fa/verify",
+			Summary:   "Verify the second factor of log in",
+			Tags:      []string{tagTwoFactor},
+			Body:      dto.TwoFactorVerifyRequest{},
+			Responses: responses(http.StatusOK, dto.UserLoginResponse{}, publicErrors...),
+		},
+		{
+			Method:    http.MethodPost,
+			Path:      "/api/email/verify",
+			Summary:   "Verify the email",
+			Tags:      []string{tagAuth},
+			Body:      dto.VerifyEmailRequest{},
+			Responses: responses(http.StatusOK, dto.StatusResponse{}, publicErrors...),
+		},
+		{
+			Method:    http.MethodPost,
+			Path:      "/api/email/resend",
+			Summary:   "Resend the verification email",
+			Tags:      []string{tagAuth},
+			Body:      dto.ResendVerificationRequest{},
+			Responses: responses(http.StatusOK, dto.StatusResponse{}, publicErrors...),
+		},
+		{
+			Method:    http.MethodPost,
+			Path:      "/api/logout",
+			Summary:   "Log out",
+			Tags:      []string{tagAuth},
+			Security:  bearerAuth,
+			Responses: responses(http.StatusOK, dto.StatusResponse{}, authErrors...),
+		},
+		{
+			Method:    http.MethodPost,
+			Path:      "/api/change-password",
+			Summary:   "Change the password",
+			Tags:      []string{tagAuth},
+			Security:  bearerAuth,
+			Body:      dto.UserChangePasswordRequest{},
+			Responses: responses(http.StatusOK, dto.StatusResponse{}, bodyErrors(authErrors)...),
+		},
+		{
+			Method:    http.MethodGet,
+			Path:      "/api/me",
+			Summary:   "Authenticated user",
+			Tags:      []string{tagAuth},
+			Security:  bearerAuth,
+			Responses: responses(http.StatusOK, dto.UserResponse{}, authErrors...),
+		},
+
+		// Two factor routes
+		{
+			Method:    http.MethodPost,
+			Path:      "/api/2fa/setup",
+			Summary:   "Set up two factor authentication",
+			Tags:      []string{tagTwoFactor},
+			Security:  bearerAuth,
+			Responses: responses(http.StatusOK, dto.TwoFactorSetupResponse{}, verifiedErrors...),
+		},
+		{
+			Method:    http.MethodPost,
+			Path:      "/api/2fa/confirm",
+			Summary:   "Confirm two factor authentication",
+			Tags:      []string{tagTwoFactor},
+			Security:  bearerAuth,
+			Body:      dto.TwoFactorConfirmRequest{},
+			Responses: responses(http.StatusOK, dto.TwoFactorRecoveryCodesResponse{}, bodyErrors(verifiedErrors)...),
+		},
+
+		// Session routes
+		{
+			Method:    http.MethodGet,
+			Path:      "/api/sessions",
+			Summary:   "List the sessions",
+			Tags:      []string{tagSessions},
+			Security:  bearerAuth,
+			Responses: responses(http.StatusOK, []dto.SessionResponse{}, authErrors...),
+		},
+		{
+			Method:    http.MethodDelete,
+			Path:      "/api/sessions",
+			Summary:   "Revoke every session",
+			Tags:      []string{tagSessions},
+			Security:  bearerAuth,
+			Responses: responses(http.StatusOK, dto.StatusResponse{}, authErrors...),
+		},
+		{
+			Method:    http.MethodDelete,
+			Path:      "/api/sessions/:id",
+			Summary:   "Revoke a session",
+			Tags:      []string{tagSessions},
+			Security:  bearerAuth,
+			Responses: responses(http.StatusOK, dto.StatusResponse{}, resourceErrors(authErrors)...),
+		},
+	}
+}
+
+// accessRoutes documents the user, the role and the permission routes
+func accessRoutes() []openapi.Route {
+	return []openapi.Route{
+		// User routes
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/users",
+			Summary:     "List the users",
+			Description: requires(constant.PermissionUsersView),
+			Tags:        []string{tagUsers},
+			Security:    bearerAuth,
+			Params:      dto.UserListRequest{},
+			Responses:   responses(http.StatusOK, dto.PaginatedResponse[dto.UserResponse]{}, listErrors...),
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/users/:id",
+			Summary:     "Show a user",
+			Description: requires(constant.PermissionUsersView),
+			Tags:        []string{tagUsers},
+			Security:    bearerAuth,
+			Params:      idParam{},
+			Responses:   responses(http.StatusOK, dto.UserResponse{}, resourceErrors(verifiedErrors)...),
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/api/users",
+			Summary:     "Create a user",
+			Description: requires(constant.PermissionUsersCreate),
+			Tags:        []string{tagUsers},
+			Security:    bearerAuth,
+			Body:        dto.UserRequest{},
+			Responses:   responses(http.StatusCreated, dto.StatusResponse{}, bodyErrors(verifiedErrors)...),
+		},
+		{
+			Method:      http.MethodPatch,
+			Path:        "/api/users/:id",
+			Summary:     "Update a user",
+			Description: requires(constant.PermissionUsersUpdate),
+			Tags:        []string{tagUsers},
+			Security:    bearerAuth,
+			Params:      idParam{},
+			Body:        dto.UserRequest{},
+			Responses:   responses(http.StatusOK, dto.StatusResponse{}, updateErrors...),
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/api/users/:id",
+			Summary:     "Delete a user",
+			Description: requires(constant.PermissionUsersDelete),
+			Tags:        []string{tagUsers},
+			Security:    bearerAuth,
+			Params:      idParam{},
+			Responses:   responses(http.StatusOK, dto.StatusResponse{}, resourceErrors(verifiedErrors)...),
+		},
+
+		// Role routes
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/roles",
+			Summary:     "List the roles",
+			Description: requires(constant.PermissionRolesView),
+			Tags:        []string{tagRoles},
+			Security:    bearerAuth,
+			Params:      dto.RoleListRequest{},
+			Responses:   responses(http.StatusOK, dto.PaginatedResponse[dto.RoleResponse]{}, listErrors...),
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/roles/:id",
+			Summary:     "Show a role",
+			Description: requires(constant.PermissionRolesView),
+			Tags:        []string{tagRoles},
+			Security:    bearerAuth,
+			Params:      idParam{},
+			Responses:   responses(http.StatusOK, dto.RoleResponse{}, resourceErrors(verifiedErrors)...),
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/api/roles",
+			Summary:     "Create a role",
+			Description: requires(constant.PermissionRolesCreate),
+			Tags:        []string{tagRoles},
+			Security:    bearerAuth,
+			Body:        dto.RoleRequest{},
+			Responses:   responses(http.StatusCreated, dto.StatusResponse{}, bodyErrors(verifiedErrors)...),
+		},
+		{
+			Method:      http.MethodPatch,
+			Path:        "/api/roles/:id",
+			Summary:     "Update a role",
+			Description: requires(constant.PermissionRolesUpdate),
+			Tags:        []string{tagRoles},
+			Security:    bearerAuth,
+			Params:      idParam{},
+			Body:        dto.RoleRequest{},
+			Responses:   responses(http.StatusOK, dto.StatusResponse{}, updateErrors...),
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/api/roles/:id",
+			Summary:     "Delete a role",
+			Description: requires(constant.PermissionRolesDelete),
+			Tags:        []string{tagRoles},
+			Security:    bearerAuth,
+			Params:      idParam{},
+			Responses:   responses(http.StatusOK, dto.StatusResponse{}, resourceErrors(verifiedErrors)...),
+		},
+
+		// Role permission routes
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/roles/:id/permissions",
+			Summary:     "List the permissions of a role",
+			Description: requires(constant.PermissionRolesView),
+			Tags:        []string{tagRoles},
+			Security:    bearerAuth,
+			Params:      idParam{},
+			Responses:   responses(http.StatusOK, []dto.PermissionResponse{}, resourceErrors(verifiedErrors)...),
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/api/roles/:id/permissions",
+			Summary:     "Sync the permissions of a role",
+			Description: requires(constant.PermissionRolesUpdate),
+			Tags:        []string{tagRoles},
+			Security:    bearerAuth,
+			Params:      idParam{},
+			Body:        dto.RolePermissionRequest{},
+			Responses:   responses(http.StatusOK, dto.StatusResponse{}, updateErrors...),
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/api/roles/:id/permissions/:permission_id",
+			Summary:     "Attach a permission to a role",
+			Description: requires(constant.PermissionRolesUpdate),
+			Tags:        []string{tagRoles},
+			Security:    bearerAuth,
+			Params:      rolePermissionParam{},
+			Responses:   responses(http.StatusOK, dto.StatusResponse{}, resourceErrors(verifiedErrors)...),
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/api/roles/:id/permissions/:permission_id",
+			Summary:     "Detach a permission from a role",
+			Description: requires(constant.PermissionRolesUpdate),
+			Tags:        []string{tagRoles},
+			Security:    bearerAuth,
+			Params:      rolePermissionParam{},
+			Responses:   responses(http.StatusOK, dto.StatusResponse{}, resourceErrors(verifiedErrors)...),
+		},
+
+		// Permission routes
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/permissions",
+			Summary:     "List the permissions",
+			Description: requires(constant.PermissionPermissionsView),
+			Tags:        []string{tagPermissions},
+			Security:    bearerAuth,
+			Responses:   responses(http.StatusOK, []dto.PermissionResponse{}, verifiedErrors...),
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/permissions/:id",
+			Summary:     "Show a permission",
+			Description: requires(constant.PermissionPermissionsView),
+			Tags:        []string{tagPermissions},
+			Security:    bearerAuth,
+			Params:      idParam{},
+			Responses:   responses(http.StatusOK, dto.PermissionResponse{}, resourceErrors(verifiedErrors)...),
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/api/permissions",
+			Summary:     "Create a permission",
+			Description: requires(constant.PermissionPermissionsCreate),
+			Tags:        []string{tagPermissions},
+			Security:    bearerAuth,
+			Body:        dto.PermissionRequest{},
+			Responses:   responses(http.StatusCreated, dto.StatusResponse{}, bodyErrors(verifiedErrors)...),
+		},
+		{
+			Method:      http.MethodPatch,
+			Path:        "/api/permissions/:id",
+			Summary:     "Update a permission",
+			Description: requires(constant.PermissionPermissionsUpdate),
+			Tags:        []string{tagPermissions},
+			Security:    bearerAuth,
+			Params:      idParam{},
+			Body:        dto.PermissionRequest{},
+			Responses:   responses(http.StatusOK, dto.StatusResponse{}, updateErrors...),
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/api/permissions/:id",
+			Summary:     "Delete a permission",
+			Description: requires(constant.PermissionPermissionsDelete),
+			Tags:        []string{tagPermissions},
+			Security:    bearerAuth,
+			Params:      idParam{},
+			Responses:   responses(http.StatusOK, dto.StatusResponse{}, resourceErrors(verifiedErrors)...),
+		},
+	}
+}
+
+// adminRoutes documents the audit log, the login lockout and the webhook routes
+func adminRoutes() []openapi.Route {
+	return []openapi.Route{
+		// Audit log routes
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/audit-logs",
+			Summary:     "List the audit logs",
+			Description: requires(constant.PermissionAuditLogsView),
+			Tags:        []string{tagAuditLogs},
+			Security:    bearerAuth,
+			Params:      dto.AuditLogListRequest{},
+			Responses:   responses(http.StatusOK, dto.PaginatedResponse[dto.AuditLogResponse]{}, listErrors...),
+		},
+
+		// Login lockout routes
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/lockouts",
+			Summary:     "List the lockouts of an email and an ip",
+			Description: requires(constant.PermissionLockoutsView),
+			Tags:        []string{tagLockouts},
+			Security:    bearerAuth,
+			Params:      dto.LockoutRequest{},
+			Responses:   responses(http.StatusOK, []dto.LockoutResponse{}, listErrors...),
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/api/lockouts",
+			Summary:     "Unlock an email and an ip",
+			Description: requires(constant.PermissionLockoutsDelete),
+			Tags:        []string{tagLockouts},
+			Security:    bearerAuth,
+			Params:      dto.LockoutRequest{},
+			Responses:   responses(http.StatusOK, dto.StatusResponse{}, listErrors...),
+		},
+
+		// Webhook routes
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/webhooks",
+			Summary:     "List the webhooks",
+			Description: requires(constant.PermissionWebhooksView),
+			Tags:        []string{tagWebhooks},
+			Security:    bearerAuth,
+			Params:      dto.WebhookListRequest{},
+			Responses:   responses(http.StatusOK, dto.PaginatedResponse[dto.WebhookResponse]{}, listErrors...),
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/webhooks/:id",
+			Summary:     "Show a webhook",
+			Description: requires(constant.PermissionWebhooksView),
+			Tags:        []string{tagWebhooks},
+			Security:    bearerAuth,
+			Params:      idParam{},
+			Responses:   responses(http.StatusOK, dto.WebhookResponse{}, resourceErrors(verifiedErrors)...),
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/api/webhooks",
+			Summary:     "Create a webhook",
+			Description: requires(constant.PermissionWebhooksCreate) + " The secret is only returned here.",
+			Tags:        []string{tagWebhooks},
+			Security:    bearerAuth,
+			Body:        dto.WebhookRequest{},
+			Responses:   responses(http.StatusCreated, dto.WebhookResponse{}, bodyErrors(verifiedErrors)...),
+		},
+		{
+			Method:      http.MethodPatch,
+			Path:        "/api/webhooks/:id",
+			Summary:     "Update a webhook",
+			Description: requires(constant.PermissionWebhooksUpdate),
+			Tags:        []string{tagWebhooks},
+			Security:    bearerAuth,
+			Params:      idParam{},
+			Body:        dto.WebhookRequest{},
+			Responses:   responses(http.StatusOK, dto.StatusResponse{}, updateErrors...),
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/api/webhooks/:id",
+			Summary:     "Delete a webhook",
+			Description: requires(constant.PermissionWebhooksDelete),
+			Tags:        []string{tagWebhooks},
+			Security:    bearerAuth,
+			Params:      idParam{},
+			Responses:   responses(http.StatusOK, dto.StatusResponse{}, resourceErrors(verifiedErrors)...),
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/webhooks/:id/deliveries",
+			Summary:     "List the deliveries of a webhook",
+			Description: requires(constant.PermissionWebhooksView),
+			Tags:        []string{tagWebhooks},
+			Security:    bearerAuth,
+			Params: struct {
+				idParam
+				dto.WebhookDeliveryListRequest
+			}{},
+			Responses: responses(
+				http.StatusOK,
+				dto.PaginatedResponse[dto.WebhookDeliveryResponse]{},
+				resourceErrors(listErrors)...,
+			),
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/webhooks/:id/deliveries/:delivery_id",
+			Summary:     "Show a delivery of a webhook with its attempts",
+			Description: requires(constant.PermissionWebhooksView),
+			Tags:        []string{tagWebhooks},
+			Security:    bearerAuth,
+			Params:      webhookDeliveryParam{},
+			Responses: responses(
+				http.StatusOK,
+				dto.WebhookDeliveryResponse{},
+				resourceErrors(verifiedErrors)...,
+			),
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/api/webhooks/:id/deliveries/:delivery_id/redeliver",
+			Summary:     "Redeliver a dead delivery of a webhook",
+			Description: requires(constant.PermissionWebhooksUpdate),
+			Tags:        []string{tagWebhooks},
+			Security:    bearerAuth,
+			Params:      webhookDeliveryParam{},
+			Responses:   responses(http.StatusAccepted, dto.StatusResponse{}, updateErrors...),
+		},
+	}
+}
+
+// Error statuses of the groups of routes
+var (
+	// publicErrors are of the public routes with a body, rate limited by ip
+	publicErrors = []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusUnprocessableEntity,
+		http.StatusTooManyRequests}
+	// authErrors are of the routes requiring an access token, rate limited by user
+	authErrors = []int{http.StatusUnauthorized, http.StatusTooManyRequests}
+	// verifiedErrors are of the routes which may require a verified email and a permission
+	verifiedErrors = []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests}
+	// listErrors are of the listings, the query is validated
+	listErrors = bodyErrors(verifiedErrors)
+	// updateErrors are of the updates of a resource with a body
+	updateErrors = resourceErrors(bodyErrors(verifiedErrors))
+)
+
+// bodyErrors returns the statuses with the errors of a body failing binding or validation
+func bodyErrors(statuses []int) []int {
+	return append([]int{http.StatusBadRequest, http.StatusUnprocessableEntity}, statuses...)
+}
+
+// resourceErrors returns the statuses with the error of a resource which is not found
+func resourceErrors(statuses []int) []int {
+	return append([]int{http.StatusNotFound}, statuses...)
+}
+
+// responses returns the response status with body of a route and the error responses of the statuses, the
+// default response is the error of any other status
+func responses(status int, body any, errs ...int) []openapi.RouteResponse {
+	res := []openapi.RouteResponse{{Status: strconv.Itoa(status), Body: body}}
+	for _, code := range errs {
+		res = append(res, openapi.RouteResponse{
+			Status:    strconv.Itoa(code),
+			Body:      dto.ProblemResponse{},
+			MediaType: mimeApplicationProblemJSON,
+		})
+	}
+
+	return append(res, openapi.RouteResponse{
+		Status:    "default",
+		Body:      dto.ProblemResponse{},
+		MediaType: mimeApplicationProblemJSON,
+	})
+}
+
+// requires is the description of a route requiring the permission
+func requires(permission string) string {
+	return "Requires the " + permission + " permission."
+}
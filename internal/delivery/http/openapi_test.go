@@ -0,0 +1,87 @@
+package http_test
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-app/internal/delivery/http"
+	"go-app/internal/infrastructure/registry"
+	"go-app/pkg/openapi"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestOpenAPICoversRoutes(t *testing.T) {
+	e := echo.New()
+	http.NewHTTPHandler(e, nil, &registry.Registry{})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, "/api/openapi.json", nil))
+	if rec.Code != nethttp.StatusOK {
+		t.Fatalf("got status %d, %d expected", rec.Code, nethttp.StatusOK)
+	}
+	var doc openapi.Document
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.OpenAPI != openapi.Version {
+		t.Errorf("got openapi %q, %q expected", doc.OpenAPI, openapi.Version)
+	}
+
+	for _, route := range e.Routes() {
+		if route.Method == echo.RouteNotFound || !strings.HasPrefix(route.Path, "/") {
+			continue
+		}
+		if doc.Operation(route.Method, route.Path) == nil {
+			t.Errorf("route %s %s is missing from the OpenAPI document", route.Method, route.Path)
+		}
+	}
+}
+
+func TestOpenAPIReferences(t *testing.T) {
+	doc := http.NewOpenAPIDocument()
+	b, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every reference resolves to a schema of the components
+	for _, ref := range strings.Split(string(b), `"$ref":"`)[1:] {
+		name := strings.TrimPrefix(ref[:strings.IndexByte(ref, '"')], "#/components/schemas/")
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("schema %q is referenced but missing", name)
+		}
+	}
+}
+
+func TestDocsUI(t *testing.T) {
+	e := echo.New()
+	http.NewHTTPHandler(e, nil, &registry.Registry{})
+
+	expectedResults := []struct {
+		path        string
+		status      int
+		contentType string
+	}{
+		{"/api/docs", nethttp.StatusOK, echo.MIMETextHTMLCharsetUTF8},
+		{"/api/docs/swagger-ui-bundle.js", nethttp.StatusOK, "text/javascript; charset=utf-8"},
+		{"/api/docs/swagger-ui.css", nethttp.StatusOK, "text/css; charset=utf-8"},
+		{"/api/docs/", nethttp.StatusMovedPermanently, ""},
+		{"/api/docs/swagger-initializer.js", nethttp.StatusMovedPermanently, ""},
+		{"/api/docs/missing.js", nethttp.StatusNotFound, ""},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, testExpected.path, nil))
+		if rec.Code != testExpected.status {
+			t.Errorf("#%d got status %d, %d expected", testNumber, rec.Code, testExpected.status)
+		}
+		if testExpected.contentType != "" && rec.Header().Get(echo.HeaderContentType) != testExpected.contentType {
+			t.Errorf("#%d got content type %q", testNumber, rec.Header().Get(echo.HeaderContentType))
+		}
+	}
+}
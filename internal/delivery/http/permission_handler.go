@@ -0,0 +1,209 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-app/internal/adapter/presenter"
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/domain/entity"
+	"go-app/internal/usecase/permission"
+	"go-app/pkg/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// permissionHandler represent the http handler
+type permissionHandler struct {
+	usecase *permission.Usecase
+}
+
+// NewPermissionHandler will create new an permissionHandler object
+func NewPermissionHandler(usecase *permission.Usecase) *permissionHandler {
+	return &permissionHandler{
+		usecase: usecase,
+	}
+}
+
+// Index will fetch data
+func (hl *permissionHandler) Index(c echo.Context) error {
+	ctx := c.Request().Context()
+	permissions, err := hl.usecase.Fetch(ctx)
+	if err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, convertPermissionsToResponse(permissions))
+}
+
+// Show will Find data
+func (hl *permissionHandler) Show(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+	ctx := c.Request().Context()
+	permission, err := hl.usecase.Find(ctx, uint(id))
+	if err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, presenter.ConvertPermissionEntityToResponse(permission))
+}
+
+// Store will create data
+func (hl *permissionHandler) Store(c echo.Context) error {
+	permissionReq := new(dto.PermissionRequest)
+	if err := c.Bind(permissionReq); err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+
+	if err := c.Validate(permissionReq); err != nil {
+		return errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	permission := presenter.ConvertPermissionRequestToEntity(permissionReq)
+
+	ctx := c.Request().Context()
+	if err := hl.usecase.Store(ctx, permission); err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusCreated, dto.StatusResponse{Status: true})
+}
+
+// Update will update data
+func (hl *permissionHandler) Update(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+
+	permissionReq := new(dto.PermissionRequest)
+	if err := c.Bind(permissionReq); err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+
+	if err := c.Validate(permissionReq); err != nil {
+		return errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	permission := presenter.ConvertPermissionRequestToEntity(permissionReq)
+
+	ctx := c.Request().Context()
+	if err := hl.usecase.Update(ctx, uint(id), permission); err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.StatusResponse{Status: true})
+}
+
+// Delete will delete data
+func (hl *permissionHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+
+	ctx := c.Request().Context()
+	if err := hl.usecase.Delete(ctx, uint(id)); err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.StatusResponse{Status: true})
+}
+
+// IndexByRole will fetch permissions of role
+func (hl *permissionHandler) IndexByRole(c echo.Context) error {
+	roleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+
+	ctx := c.Request().Context()
+	permissions, err := hl.usecase.FetchByRole(ctx, uint(roleID))
+	if err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, convertPermissionsToResponse(permissions))
+}
+
+// SyncRole will replace permissions of role
+func (hl *permissionHandler) SyncRole(c echo.Context) error {
+	roleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+
+	permissionReq := new(dto.RolePermissionRequest)
+	if err := c.Bind(permissionReq); err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+
+	if err := c.Validate(permissionReq); err != nil {
+		return errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	ctx := c.Request().Context()
+	if err := hl.usecase.SyncRole(ctx, uint(roleID), permissionReq.PermissionIDs); err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.StatusResponse{Status: true})
+}
+
+// AttachRole will grant a permission to role
+func (hl *permissionHandler) AttachRole(c echo.Context) error {
+	roleID, permissionID, err := parseRolePermissionParams(c)
+	if err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+
+	ctx := c.Request().Context()
+	if err := hl.usecase.AttachRole(ctx, roleID, permissionID); err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.StatusResponse{Status: true})
+}
+
+// DetachRole will revoke a permission from role
+func (hl *permissionHandler) DetachRole(c echo.Context) error {
+	roleID, permissionID, err := parseRolePermissionParams(c)
+	if err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+
+	ctx := c.Request().Context()
+	if err := hl.usecase.DetachRole(ctx, roleID, permissionID); err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.StatusResponse{Status: true})
+}
+
+// parseRolePermissionParams parses role id and permission id from path
+func parseRolePermissionParams(c echo.Context) (uint, uint, error) {
+	roleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	permissionID, err := strconv.ParseUint(c.Param("permission_id"), 10, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return uint(roleID), uint(permissionID), nil
+}
+
+// convertPermissionsToResponse .-
+func convertPermissionsToResponse(permissions []entity.Permission) []dto.PermissionResponse {
+	permissionsRes := make([]dto.PermissionResponse, 0)
+	for i := range permissions {
+		permission := presenter.ConvertPermissionEntityToResponse(&permissions[i])
+		permissionsRes = append(permissionsRes, permission)
+	}
+
+	return permissionsRes
+}
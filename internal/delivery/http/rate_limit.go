@@ -0,0 +1,102 @@
+package http
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/constant"
+	"go-app/pkg/errors"
+	"go-app/pkg/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RateLimitPolicy allows Limit requests per Period to each client of the policy, Key returns the client of a
+// request, a request without a client or a policy without a limit is not limited
+type RateLimitPolicy struct {
+	Name   string
+	Limit  int
+	Period time.Duration
+	Key    func(c echo.Context) string
+}
+
+// Rate limit policies of the api, every route limited uses one of them: auth limits each ip on the public
+// authentication routes, api each user on the authenticated routes and redeliver each user on the manual
+// webhook redeliveries on top of api
+var (
+	rateLimitAuth = RateLimitPolicy{
+		Name:   "auth",
+		Limit:  10,
+		Period: time.Minute,
+		Key:    rateLimitByIP,
+	}
+	rateLimitAPI = RateLimitPolicy{
+		Name:   "api",
+		Limit:  300,
+		Period: time.Minute,
+		Key:    rateLimitByUser,
+	}
+	rateLimitRedeliver = RateLimitPolicy{
+		Name:   "redeliver",
+		Limit:  10,
+		Period: time.Minute,
+		Key:    rateLimitByUser,
+	}
+)
+
+// RateLimit limits the requests by the policy, the X-RateLimit-* headers are set on every response, the innermost
+// policy wins, and Retry-After on the denied ones. The requests are allowed when the limiter fails
+func RateLimit(limiter gateway.RateLimiter, policy RateLimitPolicy) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if policy.Limit <= 0 {
+				return next(c)
+			}
+			client := policy.Key(c)
+			if client == "" {
+				return next(c)
+			}
+
+			ctx := c.Request().Context()
+			key := constant.RateLimitKeyPrefix + policy.Name + ":" + client
+			res, err := limiter.Allow(ctx, key, policy.Limit, policy.Period)
+			if err != nil {
+				logger.WarnContext(ctx, "rate limit skipped", "policy", policy.Name, "error", err.Error())
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set(constant.HeaderRateLimitLimit, strconv.Itoa(res.Limit))
+			header.Set(constant.HeaderRateLimitRemaining, strconv.Itoa(res.Remaining))
+			header.Set(constant.HeaderRateLimitReset, strconv.Itoa(seconds(res.ResetAfter)))
+			if !res.Allowed {
+				header.Set(echo.HeaderRetryAfter, strconv.Itoa(max(1, seconds(res.RetryAfter))))
+				return errors.ErrTooManyRequests.Trace()
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// rateLimitByIP is the client ip
+func rateLimitByIP(c echo.Context) string {
+	return "ip:" + c.RealIP()
+}
+
+// rateLimitByUser is the authenticated user, the client ip without one
+func rateLimitByUser(c echo.Context) string {
+	if user, ok := c.Get(constant.GuardJWT).(*entity.User); ok {
+		return "user:" + strconv.FormatUint(uint64(user.ID), 10)
+	}
+
+	return rateLimitByIP(c)
+}
+
+// seconds rounds d up to whole seconds
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,110 @@
+package http_test
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go-app/internal/adapter/gateway/limiter"
+	"go-app/internal/delivery/http"
+	"go-app/internal/infrastructure/registry"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+	e := echo.New()
+	rl := limiter.NewMemoryLimiter(0)
+	http.NewHTTPHandler(e, nil, &registry.Registry{RateLimiter: rl})
+	policy := http.RateLimitPolicy{
+		Name:   "test",
+		Limit:  2,
+		Period: time.Minute,
+		Key:    func(c echo.Context) string { return c.Request().Header.Get("X-Client") },
+	}
+	noContent := func(c echo.Context) error { return c.NoContent(nethttp.StatusNoContent) }
+	e.GET("/limited", noContent, http.RateLimit(rl, policy))
+
+	expectedResults := []struct {
+		client     string
+		status     int
+		remaining  string
+		reset      string
+		retryAfter string
+	}{
+		{"a", nethttp.StatusNoContent, "1", "30", ""},
+		{"a", nethttp.StatusNoContent, "0", "60", ""},
+		{"a", nethttp.StatusTooManyRequests, "0", "60", "30"},
+		{"b", nethttp.StatusNoContent, "1", "30", ""},
+		{"", nethttp.StatusNoContent, "", "", ""},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		req := httptest.NewRequest(nethttp.MethodGet, "/limited", nil)
+		req.Header.Set("X-Client", testExpected.client)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		limit := strconv.Itoa(policy.Limit)
+		if testExpected.client == "" {
+			limit = ""
+		}
+		header := rec.Header()
+		if rec.Code != testExpected.status || header.Get("X-RateLimit-Limit") != limit ||
+			header.Get("X-RateLimit-Remaining") != testExpected.remaining ||
+			header.Get("X-RateLimit-Reset") != testExpected.reset ||
+			header.Get(echo.HeaderRetryAfter) != testExpected.retryAfter {
+			t.Errorf("#%d got %d %v", testNumber, rec.Code, header)
+		}
+	}
+}
+
+func TestRateLimitClientIP(t *testing.T) {
+	t.Parallel()
+	const requests = 11
+
+	expectedResults := []struct {
+		trustedProxies string
+		remoteAddr     string
+		limited        bool
+	}{
+		// X-Forwarded-For is ignored without trusted proxies
+		{"", "203.0.113.7:4242", true},
+		// and from a peer which is not one of them, even a private one
+		{"192.0.2.0/24", "10.0.0.1:4242", true},
+		// the client of X-Forwarded-For is limited behind a trusted proxy
+		{"192.0.2.0/24, 198.51.100.1", "198.51.100.1:4242", false},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		e := echo.New()
+		extractor, err := http.NewIPExtractor(testExpected.trustedProxies)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e.IPExtractor = extractor
+		http.NewHTTPHandler(e, nil, &registry.Registry{RateLimiter: limiter.NewMemoryLimiter(0)})
+
+		limited := false
+		for i := range requests {
+			req := httptest.NewRequest(nethttp.MethodPost, "/api/login", strings.NewReader(`{}`))
+			req.RemoteAddr = testExpected.remoteAddr
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(echo.HeaderXForwardedFor, "203.0.113."+strconv.Itoa(i))
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			limited = limited || rec.Code == nethttp.StatusTooManyRequests
+		}
+		if limited != testExpected.limited {
+			t.Errorf("#%d got limited %v, %v expected", testNumber, limited, testExpected.limited)
+		}
+	}
+
+	if _, err := http.NewIPExtractor("10.0.0.0/33"); err == nil {
+		t.Error("got no error for an invalid range")
+	}
+}
@@ -26,8 +26,16 @@ func NewRoleHandler(usecase *role.Usecase) *roleHandler {
 
 // Index will fetch data
 func (hl *roleHandler) Index(c echo.Context) error {
+	listReq := new(dto.RoleListRequest)
+	if err := c.Bind(listReq); err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+	if err := c.Validate(listReq); err != nil {
+		return errors.ErrUnprocessableEntity.Wrap(err)
+	}
+	q := presenter.ConvertRoleListRequestToQuery(listReq, c.QueryParams().Has("cursor"))
 	ctx := c.Request().Context()
-	roles, err := hl.usecase.Fetch(ctx)
+	roles, pg, err := hl.usecase.Fetch(ctx, q)
 	if err != nil {
 		return errors.Throw(err)
 	}
@@ -37,7 +45,7 @@ func (hl *roleHandler) Index(c echo.Context) error {
 		rolesRes = append(rolesRes, role)
 	}
 
-	return c.JSON(http.StatusOK, rolesRes)
+	return c.JSON(http.StatusOK, presenter.ConvertPaginationToResponse(rolesRes, pg, c.Request().URL))
 }
 
 // Show will Find data
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"net/http"
+
+	"go-app/internal/adapter/presenter"
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/domain/entity"
+	"go-app/internal/infrastructure/config"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/usecase/auth"
+	"go-app/pkg/errors"
+	"go-app/pkg/totp"
+
+	"github.com/labstack/echo/v4"
+)
+
+// twoFactorHandler represent the http handler
+type twoFactorHandler struct {
+	usecase *auth.Usecase
+}
+
+// NewTwoFactorHandler will create new an twoFactorHandler object
+func NewTwoFactorHandler(usecase *auth.Usecase) *twoFactorHandler {
+	return &twoFactorHandler{
+		usecase: usecase,
+	}
+}
+
+// Setup will generate a secret to enroll in authenticator apps
+func (hl *twoFactorHandler) Setup(c echo.Context) error {
+	user, ok := c.Get(constant.GuardJWT).(*entity.User)
+	if !ok {
+		return errors.ErrBadRequest.Trace()
+	}
+
+	ctx := c.Request().Context()
+	secret, err := hl.usecase.SetupTwoFactor(ctx, user.ID)
+	if err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.TwoFactorSetupResponse{
+		Secret: secret,
+		URI:    totp.URI(config.GetAppConfig().AppName, user.Email, secret),
+	})
+}
+
+// Confirm will enable two factor and return recovery codes
+func (hl *twoFactorHandler) Confirm(c echo.Context) error {
+	confirmReq := new(dto.TwoFactorConfirmRequest)
+	if err := c.Bind(confirmReq); err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+
+	if err := c.Validate(confirmReq); err != nil {
+		return errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	user, ok := c.Get(constant.GuardJWT).(*entity.User)
+	if !ok {
+		return errors.ErrBadRequest.Trace()
+	}
+
+	ctx := c.Request().Context()
+	codes, err := hl.usecase.ConfirmTwoFactor(ctx, user.ID, confirmReq.Code)
+	if err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.TwoFactorRecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// Verify will complete the log in with the second factor
+func (hl *twoFactorHandler) Verify(c echo.Context) error {
+	verifyReq := new(dto.TwoFactorVerifyRequest)
+	if err := c.Bind(verifyReq); err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+
+	if err := c.Validate(verifyReq); err != nil {
+		return errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	ctx := c.Request().Context()
+	user, token, err := hl.usecase.VerifyTwoFactor(ctx, verifyReq.MFAToken, verifyReq.Code)
+	if err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, presenter.ConvertUserToLoginResponse(*user, token))
+}
@@ -26,8 +26,16 @@ func NewUserHandler(usecase *user.Usecase) *userHandler {
 
 // Index will fetch data
 func (hl *userHandler) Index(c echo.Context) error {
+	listReq := new(dto.UserListRequest)
+	if err := c.Bind(listReq); err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+	if err := c.Validate(listReq); err != nil {
+		return errors.ErrUnprocessableEntity.Wrap(err)
+	}
+	q := presenter.ConvertUserListRequestToQuery(listReq, c.QueryParams().Has("cursor"))
 	ctx := c.Request().Context()
-	users, err := hl.usecase.Fetch(ctx)
+	users, pg, err := hl.usecase.Fetch(ctx, q)
 	if err != nil {
 		return errors.Throw(err)
 	}
@@ -37,7 +45,7 @@ func (hl *userHandler) Index(c echo.Context) error {
 		usersRes = append(usersRes, user)
 	}
 
-	return c.JSON(http.StatusOK, usersRes)
+	return c.JSON(http.StatusOK, presenter.ConvertPaginationToResponse(usersRes, pg, c.Request().URL))
 }
 
 // Show will Find data
@@ -0,0 +1,193 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-app/internal/adapter/presenter"
+	"go-app/internal/delivery/http/dto"
+	"go-app/internal/usecase/webhook"
+	"go-app/pkg/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// webhookHandler represent the http handler
+type webhookHandler struct {
+	usecase *webhook.Usecase
+}
+
+// NewWebhookHandler will create new an webhookHandler object
+func NewWebhookHandler(usecase *webhook.Usecase) *webhookHandler {
+	return &webhookHandler{
+		usecase: usecase,
+	}
+}
+
+// Index will fetch data
+func (hl *webhookHandler) Index(c echo.Context) error {
+	listReq := new(dto.WebhookListRequest)
+	if err := c.Bind(listReq); err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+	if err := c.Validate(listReq); err != nil {
+		return errors.ErrUnprocessableEntity.Wrap(err)
+	}
+	q := presenter.ConvertWebhookListRequestToQuery(listReq, c.QueryParams().Has("cursor"))
+	ctx := c.Request().Context()
+	webhooks, pg, err := hl.usecase.Fetch(ctx, q)
+	if err != nil {
+		return errors.Throw(err)
+	}
+	webhooksRes := make([]dto.WebhookResponse, 0)
+	for i := range webhooks {
+		webhooksRes = append(webhooksRes, presenter.ConvertWebhookEntityToResponse(&webhooks[i]))
+	}
+
+	return c.JSON(http.StatusOK, presenter.ConvertPaginationToResponse(webhooksRes, pg, c.Request().URL))
+}
+
+// Show will Find data
+func (hl *webhookHandler) Show(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+	ctx := c.Request().Context()
+	webhook, err := hl.usecase.Find(ctx, uint(id))
+	if err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, presenter.ConvertWebhookEntityToResponse(webhook))
+}
+
+// Store will create data, the response is the only one carrying the secret
+func (hl *webhookHandler) Store(c echo.Context) error {
+	webhookReq := new(dto.WebhookRequest)
+	if err := c.Bind(webhookReq); err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+	if err := c.Validate(webhookReq); err != nil {
+		return errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	webhook := presenter.ConvertWebhookRequestToEntity(webhookReq)
+
+	ctx := c.Request().Context()
+	if err := hl.usecase.Store(ctx, webhook); err != nil {
+		return errors.Throw(err)
+	}
+
+	res := presenter.ConvertWebhookEntityToResponse(webhook)
+	res.Secret = webhook.Secret
+
+	return c.JSON(http.StatusCreated, res)
+}
+
+// Update will update data
+func (hl *webhookHandler) Update(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+
+	webhookReq := new(dto.WebhookRequest)
+	if err := c.Bind(webhookReq); err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+	if err := c.Validate(webhookReq); err != nil {
+		return errors.ErrUnprocessableEntity.Wrap(err)
+	}
+
+	webhook := presenter.ConvertWebhookRequestToEntity(webhookReq)
+
+	ctx := c.Request().Context()
+	if err := hl.usecase.Update(ctx, uint(id), webhook); err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.StatusResponse{Status: true})
+}
+
+// Delete will delete data
+func (hl *webhookHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+
+	ctx := c.Request().Context()
+	if err := hl.usecase.Delete(ctx, uint(id)); err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.StatusResponse{Status: true})
+}
+
+// IndexDeliveries will fetch deliveries of webhook
+func (hl *webhookHandler) IndexDeliveries(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+
+	listReq := new(dto.WebhookDeliveryListRequest)
+	if err := c.Bind(listReq); err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+	if err := c.Validate(listReq); err != nil {
+		return errors.ErrUnprocessableEntity.Wrap(err)
+	}
+	q := presenter.ConvertWebhookDeliveryListRequestToQuery(listReq, c.QueryParams().Has("cursor"))
+	ctx := c.Request().Context()
+	deliveries, pg, err := hl.usecase.FetchDeliveries(ctx, uint(id), q)
+	if err != nil {
+		return errors.Throw(err)
+	}
+	deliveriesRes := make([]dto.WebhookDeliveryResponse, 0)
+	for i := range deliveries {
+		deliveriesRes = append(deliveriesRes, presenter.ConvertWebhookDeliveryEntityToResponse(&deliveries[i], nil))
+	}
+
+	return c.JSON(http.StatusOK, presenter.ConvertPaginationToResponse(deliveriesRes, pg, c.Request().URL))
+}
+
+// ShowDelivery will find delivery of webhook with its attempts
+func (hl *webhookHandler) ShowDelivery(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+	deliveryID, err := strconv.ParseUint(c.Param("delivery_id"), 10, 32)
+	if err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+
+	ctx := c.Request().Context()
+	delivery, attempts, err := hl.usecase.FindDelivery(ctx, uint(id), uint(deliveryID))
+	if err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusOK, presenter.ConvertWebhookDeliveryEntityToResponse(delivery, attempts))
+}
+
+// Redeliver will queue a dead delivery again
+func (hl *webhookHandler) Redeliver(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+	deliveryID, err := strconv.ParseUint(c.Param("delivery_id"), 10, 32)
+	if err != nil {
+		return errors.ErrBadRequest.Wrap(err)
+	}
+
+	ctx := c.Request().Context()
+	if err := hl.usecase.Redeliver(ctx, uint(id), uint(deliveryID)); err != nil {
+		return errors.Throw(err)
+	}
+
+	return c.JSON(http.StatusAccepted, dto.StatusResponse{Status: true})
+}
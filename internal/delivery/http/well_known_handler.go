@@ -0,0 +1,28 @@
+package http
+
+import (
+	"net/http"
+
+	"go-app/internal/adapter/gateway/service"
+
+	"github.com/labstack/echo/v4"
+)
+
+// wellKnownHandler represent the http handler of well-known uris
+type wellKnownHandler struct {
+	keys *service.JWTKeySet
+}
+
+// NewWellKnownHandler will create new a wellKnownHandler object
+func NewWellKnownHandler(keys *service.JWTKeySet) *wellKnownHandler {
+	return &wellKnownHandler{
+		keys: keys,
+	}
+}
+
+// JWKS will return the public keys verifying jwt tokens
+func (hl *wellKnownHandler) JWKS(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=300")
+
+	return c.JSON(http.StatusOK, hl.keys.JWKS())
+}
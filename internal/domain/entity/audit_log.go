@@ -0,0 +1,21 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/audit_log_mock.go
+package entity
+
+import (
+	"time"
+)
+
+// AuditLog entity, Before and After hold the fields changed by the action
+type AuditLog struct {
+	ID         uint           `json:"id"`
+	ActorID    *uint          `json:"actor_id"`
+	Action     string         `json:"action"`
+	TargetType string         `json:"target_type"`
+	TargetID   string         `json:"target_id"`
+	Before     map[string]any `json:"before"`
+	After      map[string]any `json:"after"`
+	IP         string         `json:"ip"`
+	UserAgent  string         `json:"user_agent"`
+	RequestID  string         `json:"request_id"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
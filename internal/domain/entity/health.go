@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+)
+
+// HealthReport is the status of the application and the checks of its dependencies, it is up when every
+// check is up, degraded when only optional ones are down and down when a required one is down
+type HealthReport struct {
+	Status string        `json:"status"`
+	Checks []HealthCheck `json:"checks"`
+}
+
+// HealthCheck is the outcome of the check of a dependency
+type HealthCheck struct {
+	Name     string        `json:"name"`
+	Status   string        `json:"status"`
+	Required bool          `json:"required"`
+	Latency  time.Duration `json:"latency"`
+	Error    string        `json:"error"`
+}
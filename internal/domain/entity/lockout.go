@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+)
+
+// Lockout is the state of the failed logins of a subject, an account or an ip: Failures in the current window,
+// Level is number of lockouts in the history, each one longer than the previous, and RetryAfter is the
+// remaining lockout, zero when the subject is not locked
+type Lockout struct {
+	Scope      string        `json:"scope"`
+	Subject    string        `json:"subject"`
+	Failures   int           `json:"failures"`
+	Level      int           `json:"level"`
+	RetryAfter time.Duration `json:"retry_after"`
+}
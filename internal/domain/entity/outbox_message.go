@@ -0,0 +1,19 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/outbox_message_mock.go
+package entity
+
+import (
+	"time"
+)
+
+// OutboxMessage entity, an event waiting to be published, AvailableAt is the time of the next attempt
+type OutboxMessage struct {
+	ID          uint       `json:"id"`
+	EventName   string     `json:"event_name"`
+	Payload     []byte     `json:"payload"`
+	Attempts    int        `json:"attempts"`
+	AvailableAt time.Time  `json:"available_at"`
+	PublishedAt *time.Time `json:"published_at"`
+	FailedAt    *time.Time `json:"failed_at"`
+	LastError   string     `json:"last_error"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
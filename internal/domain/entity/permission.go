@@ -0,0 +1,16 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/permission_mock.go
+package entity
+
+import (
+	"time"
+)
+
+// Permission entity
+type Permission struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at"`
+}
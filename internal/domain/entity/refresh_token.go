@@ -0,0 +1,26 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/refresh_token_mock.go
+package entity
+
+import (
+	"time"
+)
+
+// RefreshToken entity
+type RefreshToken struct {
+	ID        uint       `json:"id"`
+	UserID    uint       `json:"user_id"`
+	FamilyID  string     `json:"family_id"`
+	Token     string     `json:"token"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// AuthToken is the pair of tokens issued to a user
+type AuthToken struct {
+	AccessToken      string `json:"access_token"`
+	ExpiresAt        int64  `json:"expires_at"`
+	RefreshToken     string `json:"refresh_token"`
+	RefreshExpiresAt int64  `json:"refresh_expires_at"`
+}
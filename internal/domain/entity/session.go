@@ -0,0 +1,27 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/session_mock.go
+package entity
+
+import (
+	"time"
+)
+
+// Session entity, one per login
+type Session struct {
+	ID         string     `json:"id"`
+	UserID     uint       `json:"user_id"`
+	Device     string     `json:"device"`
+	IP         string     `json:"ip"`
+	UserAgent  string     `json:"user_agent"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// TwoFactorChallenge is a pending login waiting for the second factor
+type TwoFactorChallenge struct {
+	Token     string  `json:"-"`
+	UserID    uint    `json:"user_id"`
+	Session   Session `json:"session"`
+	ExpiresAt int64   `json:"expires_at"`
+}
@@ -7,12 +7,17 @@ import (
 
 // User entity
 type User struct {
-	ID        uint       `json:"id"`
-	Name      string     `json:"name"`
-	Email     string     `json:"email"`
-	RoleID    uint       `json:"role_id"`
-	Password  string     `json:"password"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at"`
+	ID                     uint       `json:"id"`
+	Name                   string     `json:"name"`
+	Email                  string     `json:"email"`
+	RoleID                 uint       `json:"role_id"`
+	Password               string     `json:"password"`
+	Permissions            []string   `json:"permissions"`
+	EmailVerifiedAt        *time.Time `json:"email_verified_at"`
+	TwoFactorSecret        string     `json:"two_factor_secret"`
+	TwoFactorRecoveryCodes []string   `json:"two_factor_recovery_codes"`
+	TwoFactorConfirmedAt   *time.Time `json:"two_factor_confirmed_at"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              time.Time  `json:"updated_at"`
+	DeletedAt              *time.Time `json:"deleted_at"`
 }
@@ -0,0 +1,46 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/webhook_mock.go
+package entity
+
+import (
+	"time"
+)
+
+// Webhook entity, an endpoint receiving the events it is subscribed to
+type Webhook struct {
+	ID        uint      `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookDelivery entity, an event to send to a webhook, Payload is the signed body
+type WebhookDelivery struct {
+	ID             uint       `json:"id"`
+	WebhookID      uint       `json:"webhook_id"`
+	EventID        uint       `json:"event_id"`
+	EventName      string     `json:"event_name"`
+	Payload        []byte     `json:"payload"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LastStatusCode int        `json:"last_status_code"`
+	LastError      string     `json:"last_error"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// WebhookAttempt entity, the log of one request of a delivery
+type WebhookAttempt struct {
+	ID           uint          `json:"id"`
+	DeliveryID   uint          `json:"delivery_id"`
+	Attempt      int           `json:"attempt"`
+	StatusCode   int           `json:"status_code"`
+	ResponseBody string        `json:"response_body"`
+	Error        string        `json:"error"`
+	Duration     time.Duration `json:"duration"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
@@ -0,0 +1,43 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Event is a fact of the domain, it is stored in the outbox in the transaction of the change
+// and published to subscribers once committed
+type Event interface {
+	EventName() string
+}
+
+// Names lists the events which can be subscribed to by webhooks
+var Names = []string{
+	UserRegisteredName,
+	UserCreatedName,
+	UserDeletedName,
+	PasswordChangedName,
+	PasswordResetName,
+	EmailVerifiedName,
+	RoleCreatedName,
+	RoleDeletedName,
+}
+
+// Envelope is an event as delivered to subscribers, the delivery is at least once so subscribers
+// must be idempotent, ID is stable across retries
+type Envelope struct {
+	ID         uint            `json:"id"`
+	Name       string          `json:"name"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Attempt    int             `json:"attempt"`
+}
+
+// Decode unmarshals the payload of the envelope into e
+func (env Envelope) Decode(e Event) error {
+	return json.Unmarshal(env.Payload, e)
+}
+
+// Handler handles the events it is subscribed to, a returned error schedules a retry
+type Handler func(ctx context.Context, env Envelope) error
@@ -0,0 +1,27 @@
+package event
+
+const (
+	// RoleCreatedName is the name of RoleCreated
+	RoleCreatedName = "role.created"
+	// RoleDeletedName is the name of RoleDeleted
+	RoleDeletedName = "role.deleted"
+)
+
+// RoleCreated is raised when a role is created
+type RoleCreated struct {
+	RoleID uint   `json:"role_id"`
+	Name   string `json:"name"`
+	Slug   string `json:"slug"`
+}
+
+// EventName .-
+func (RoleCreated) EventName() string { return RoleCreatedName }
+
+// RoleDeleted is raised when a role is deleted
+type RoleDeleted struct {
+	RoleID uint   `json:"role_id"`
+	Slug   string `json:"slug"`
+}
+
+// EventName .-
+func (RoleDeleted) EventName() string { return RoleDeletedName }
@@ -0,0 +1,71 @@
+package event
+
+const (
+	// UserRegisteredName is the name of UserRegistered
+	UserRegisteredName = "user.registered"
+	// UserCreatedName is the name of UserCreated
+	UserCreatedName = "user.created"
+	// UserDeletedName is the name of UserDeleted
+	UserDeletedName = "user.deleted"
+	// PasswordChangedName is the name of PasswordChanged
+	PasswordChangedName = "user.password_changed"
+	// PasswordResetName is the name of PasswordReset
+	PasswordResetName = "user.password_reset"
+	// EmailVerifiedName is the name of EmailVerified
+	EmailVerifiedName = "user.email_verified"
+)
+
+// UserRegistered is raised when a user signs up
+type UserRegistered struct {
+	UserID uint   `json:"user_id"`
+	Email  string `json:"email"`
+	Name   string `json:"name"`
+}
+
+// EventName .-
+func (UserRegistered) EventName() string { return UserRegisteredName }
+
+// UserCreated is raised when a user is created by an admin
+type UserCreated struct {
+	UserID uint   `json:"user_id"`
+	Email  string `json:"email"`
+	Name   string `json:"name"`
+	RoleID uint   `json:"role_id"`
+}
+
+// EventName .-
+func (UserCreated) EventName() string { return UserCreatedName }
+
+// UserDeleted is raised when a user is deleted
+type UserDeleted struct {
+	UserID uint   `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+// EventName .-
+func (UserDeleted) EventName() string { return UserDeletedName }
+
+// PasswordChanged is raised when a user changes the password
+type PasswordChanged struct {
+	UserID uint `json:"user_id"`
+}
+
+// EventName .-
+func (PasswordChanged) EventName() string { return PasswordChangedName }
+
+// PasswordReset is raised when a password is reset with a token
+type PasswordReset struct {
+	UserID uint `json:"user_id"`
+}
+
+// EventName .-
+func (PasswordReset) EventName() string { return PasswordResetName }
+
+// EmailVerified is raised when a user verifies the email
+type EmailVerified struct {
+	UserID uint   `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+// EventName .-
+func (EmailVerified) EventName() string { return EmailVerifiedName }
@@ -0,0 +1,27 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/auth_events_mock.go
+package gateway
+
+// The authentication events recorded by AuthEvents
+const (
+	// AuthLoginSucceeded is a session started by a login
+	AuthLoginSucceeded = "login_succeeded"
+	// AuthLoginFailed is a wrong credential or two factor code
+	AuthLoginFailed = "login_failed"
+	// AuthLoginBlocked is a login rejected by a lockout
+	AuthLoginBlocked = "login_blocked"
+	// AuthLockedOut is a lockout of an account or an ip
+	AuthLockedOut = "locked_out"
+	// AuthPasswordResetRequested is a reset password token sent
+	AuthPasswordResetRequested = "password_reset_requested"
+	// AuthPasswordReset is a password reset with a token
+	AuthPasswordReset = "password_reset"
+	// AuthSessionRevoked is a session revoked with its tokens
+	AuthSessionRevoked = "session_revoked"
+	// AuthRefreshTokenReused is a refresh token used again, its session is revoked
+	AuthRefreshTokenReused = "refresh_token_reused"
+)
+
+// AuthEvents is interface to record the authentication events, event is one of the Auth* constants
+type AuthEvents interface {
+	Record(event string)
+}
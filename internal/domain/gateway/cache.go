@@ -6,10 +6,17 @@ import (
 	"time"
 )
 
-// Cache is a interface for multiple store
+// Cache is a interface for multiple store, Incr sets the expiration when it creates the counter, TTL is zero
+// for a key which never expires and GetMany leaves the missing keys out. Close stops the background work of the
+// store, the redis client is left open
 type Cache interface {
 	Get(ctx context.Context, k string) ([]byte, error)
+	GetMany(ctx context.Context, ks ...string) (map[string][]byte, error)
 	Set(ctx context.Context, k string, v any, e time.Duration) error
+	SetNX(ctx context.Context, k string, v any, e time.Duration) (bool, error)
+	Incr(ctx context.Context, k string, e time.Duration) (int64, error)
+	TTL(ctx context.Context, k string) (time.Duration, error)
 	Del(ctx context.Context, ks ...string) error
 	FlushAll(ctx context.Context) error
+	Close() error
 }
@@ -0,0 +1,20 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/event_publisher_mock.go
+package gateway
+
+import (
+	"context"
+
+	"go-app/internal/domain/event"
+)
+
+// EventPublisher is interface for the broker the outbox is dispatched to, an error keeps the event
+// in the outbox to be published again
+type EventPublisher interface {
+	Publish(ctx context.Context, env event.Envelope) error
+}
+
+// EventBus is an in process EventPublisher delivering events to subscribed handlers
+type EventBus interface {
+	EventPublisher
+	Subscribe(name string, handler event.Handler)
+}
@@ -0,0 +1,20 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/health_checker_mock.go
+package gateway
+
+import (
+	"context"
+)
+
+// HealthChecker checks that a dependency is reachable, Check returns nil when it is healthy and should give up
+// when ctx is done
+type HealthChecker interface {
+	Check(ctx context.Context) error
+}
+
+// HealthCheckFunc is a function used as a HealthChecker
+type HealthCheckFunc func(ctx context.Context) error
+
+// Check calls f
+func (f HealthCheckFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
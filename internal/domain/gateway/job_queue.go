@@ -0,0 +1,31 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/job_queue_mock.go
+package gateway
+
+import (
+	"context"
+	"time"
+)
+
+// Job is a unit of background work, Payload is decoded by the handler of Type and Trace carries the trace
+// context of the dispatcher to the worker
+type Job struct {
+	ID          string            `json:"id"`
+	Type        string            `json:"type"`
+	Payload     []byte            `json:"payload"`
+	Attempts    int               `json:"attempts"`
+	MaxAttempts int               `json:"max_attempts"`
+	RunAt       time.Time         `json:"run_at"`
+	LastError   string            `json:"last_error"`
+	Trace       map[string]string `json:"trace,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+// JobQueue is interface for a durable job queue, a reserved job which is not acknowledged, retried
+// or failed before the visibility timeout is given back to the queue so a crashed worker loses nothing
+type JobQueue interface {
+	Enqueue(ctx context.Context, job *Job) error
+	Reserve(ctx context.Context, wait time.Duration) (*Job, error)
+	Ack(ctx context.Context, job *Job) error
+	Retry(ctx context.Context, job *Job, runAt time.Time) error
+	Fail(ctx context.Context, job *Job) error
+}
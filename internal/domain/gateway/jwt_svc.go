@@ -9,7 +9,10 @@ import (
 
 // JWTService is a struct that represent the jwt's service
 type JWTService interface {
-	GenerateToken(ctx context.Context, user *entity.User) (string, int64, error)
+	GenerateToken(ctx context.Context, user *entity.User, sessionID string) (string, int64, error)
+	GenerateRefreshToken(ctx context.Context) (string, int64, error)
+	SessionID(token any) (string, error)
 	Invalidate(ctx context.Context, token any) error
+	InvalidateSession(ctx context.Context, sessionID string) error
 	Decode(ctx context.Context, token any) (*entity.User, error)
 }
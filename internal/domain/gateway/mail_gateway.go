@@ -5,7 +5,29 @@ import (
 	"context"
 )
 
-// MailService is interface for mail service
+// Mail is an email with copies, a reply address and attachments, HTML is optional
+type Mail struct {
+	To          []string
+	Cc          []string
+	Bcc         []string
+	ReplyTo     string
+	Subject     string
+	Text        string
+	HTML        string
+	Attachments []MailAttachment
+}
+
+// MailAttachment is a file attached to a Mail
+type MailAttachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// MailService is interface for mail service, SendTemplate renders the named template in the variant of
+// locale, falling back to the default locale
 type MailService interface {
 	Send(ctx context.Context, subject, body string, to []string) error
+	SendTemplate(ctx context.Context, name, locale string, data any, to []string) error
+	SendMail(ctx context.Context, mail *Mail) error
 }
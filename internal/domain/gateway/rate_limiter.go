@@ -0,0 +1,23 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/rate_limiter_mock.go
+package gateway
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimit is the outcome of a request against a limit, Remaining requests are allowed right after it,
+// RetryAfter is the wait before the next request when it is denied and ResetAfter the wait before the full
+// limit is available again
+type RateLimit struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	ResetAfter time.Duration
+}
+
+// RateLimiter allows at most limit requests of a key per period, the requests are spread as a token bucket
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, limit int, period time.Duration) (*RateLimit, error)
+}
@@ -0,0 +1,8 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/signature_svc_mock.go
+package gateway
+
+// SignatureService is interface to sign and verify payloads such as links sent by email
+type SignatureService interface {
+	Sign(payload string) string
+	Verify(payload, signature string) bool
+}
@@ -3,11 +3,18 @@ package gateway
 
 import (
 	"context"
+	"time"
+
+	"go-app/internal/domain/entity"
 )
 
-// ThrottleService is a struct that represent the throttle's service
+// ThrottleService counts the failed logins of the accounts and the ips separately, Blocked returns the longest
+// remaining lockout of both, Fail returns the lockout of the account when the failure locks it and Clear resets
+// the account after a successful login. An empty account or ip is skipped
 type ThrottleService interface {
-	Blocked(ctx context.Context, key, ip string) (bool, error)
-	Incr(ctx context.Context, key, ip string) error
-	Clear(ctx context.Context, key, ip string) error
+	Blocked(ctx context.Context, account, ip string) (time.Duration, error)
+	Fail(ctx context.Context, account, ip string) (*entity.Lockout, error)
+	Clear(ctx context.Context, account string) error
+	Lockouts(ctx context.Context, account, ip string) ([]entity.Lockout, error)
+	Unlock(ctx context.Context, account, ip string) error
 }
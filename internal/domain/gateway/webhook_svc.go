@@ -0,0 +1,28 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/webhook_svc_mock.go
+package gateway
+
+import (
+	"context"
+	"time"
+)
+
+// WebhookRequest is a signed request to a webhook endpoint
+type WebhookRequest struct {
+	URL        string
+	Secret     string
+	Event      string
+	DeliveryID string
+	Body       []byte
+}
+
+// WebhookResponse is the answer of the endpoint, StatusCode is 0 when no response was received
+type WebhookResponse struct {
+	StatusCode int
+	Body       string
+	Duration   time.Duration
+}
+
+// WebhookService is interface to send webhook requests, an error is returned for non 2xx responses
+type WebhookService interface {
+	Send(ctx context.Context, req WebhookRequest) (WebhookResponse, error)
+}
@@ -0,0 +1,14 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/audit_repo_mock.go
+package repository
+
+import (
+	"context"
+
+	"go-app/internal/domain/entity"
+)
+
+// AuditRepository represent the AuditLog's repository contract
+type AuditRepository interface {
+	Fetch(ctx context.Context, q Query) ([]entity.AuditLog, Pagination, error)
+	Store(ctx context.Context, log *entity.AuditLog) error
+}
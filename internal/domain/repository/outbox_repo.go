@@ -0,0 +1,18 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/outbox_repo_mock.go
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go-app/internal/domain/entity"
+)
+
+// OutboxRepository represent the OutboxMessage's repository contract
+type OutboxRepository interface {
+	Store(ctx context.Context, msg *entity.OutboxMessage) error
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]entity.OutboxMessage, error)
+	MarkPublished(ctx context.Context, id uint) error
+	MarkRetry(ctx context.Context, id uint, attempts int, availableAt time.Time, lastErr string) error
+	MarkFailed(ctx context.Context, id uint, attempts int, lastErr string) error
+}
@@ -0,0 +1,22 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/permission_repo_mock.go
+package repository
+
+import (
+	"context"
+
+	"go-app/internal/domain/entity"
+)
+
+// PermissionRepository represent the Permission's repository contract
+type PermissionRepository interface {
+	Fetch(context.Context) ([]entity.Permission, error)
+	Find(ctx context.Context, id uint) (*entity.Permission, error)
+	CheckExists(ctx context.Context, q entity.Permission, id *uint) (bool, error)
+	Store(ctx context.Context, p *entity.Permission) error
+	Update(ctx context.Context, p *entity.Permission) error
+	Delete(ctx context.Context, id uint) error
+	FetchByRole(ctx context.Context, roleID uint) ([]entity.Permission, error)
+	SyncRole(ctx context.Context, roleID uint, ids []uint) error
+	AttachRole(ctx context.Context, roleID, id uint) error
+	DetachRole(ctx context.Context, roleID, id uint) error
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"slices"
+
+	"go-app/pkg/errors"
+)
+
+const (
+	// DefaultLimit is number of items per page when not given
+	DefaultLimit = 20
+	// MaxLimit is the greatest number of items per page
+	MaxLimit = 100
+)
+
+// FilterOperator is a comparison used by Filter
+type FilterOperator string
+
+const (
+	// FilterEq matches equal values
+	FilterEq FilterOperator = "eq"
+	// FilterContains matches values containing the text, case insensitive
+	FilterContains FilterOperator = "contains"
+	// FilterGte matches values greater than or equal
+	FilterGte FilterOperator = "gte"
+	// FilterLte matches values less than or equal
+	FilterLte FilterOperator = "lte"
+)
+
+// Sort is an order by field
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Filter is a condition on field
+type Filter struct {
+	Field    string
+	Operator FilterOperator
+	Value    any
+}
+
+// Query is a reusable spec for listing: offset (Page) or keyset (Cursor) pagination,
+// sorting and filtering
+type Query struct {
+	Page      int
+	Limit     int
+	UseCursor bool
+	Cursor    string
+	Sorts     []Sort
+	Filters   []Filter
+}
+
+// QuerySpec is the whitelist of fields a listing can be sorted and filtered by
+type QuerySpec struct {
+	Sorts       []string
+	Filters     map[string][]FilterOperator
+	DefaultSort Sort
+}
+
+// Pagination is the result of paginating a query
+type Pagination struct {
+	Page       int
+	Limit      int
+	Total      int64
+	UseCursor  bool
+	NextCursor string
+}
+
+// Normalize validates the query against the spec and fills default values
+func (q *Query) Normalize(spec QuerySpec) error {
+	if q.Limit <= 0 {
+		q.Limit = DefaultLimit
+	}
+	if q.Limit > MaxLimit {
+		q.Limit = MaxLimit
+	}
+	if q.Page <= 0 {
+		q.Page = 1
+	}
+	if len(q.Sorts) == 0 {
+		q.Sorts = []Sort{spec.DefaultSort}
+	}
+
+	for _, s := range q.Sorts {
+		if !slices.Contains(spec.Sorts, s.Field) {
+			return errors.ErrInvalidQuery.Trace()
+		}
+	}
+	// Keyset pagination is done on one field with id as tie breaker
+	if q.UseCursor && len(q.Sorts) > 1 {
+		return errors.ErrInvalidQuery.Trace()
+	}
+
+	for _, f := range q.Filters {
+		if !slices.Contains(spec.Filters[f.Field], f.Operator) {
+			return errors.ErrInvalidQuery.Trace()
+		}
+	}
+
+	return nil
+}
+
+// UserQuerySpec is the whitelist of user listing
+var UserQuerySpec = QuerySpec{
+	Sorts: []string{"id", "name", "email", "role_id", "created_at"},
+	Filters: map[string][]FilterOperator{
+		"role_id":    {FilterEq},
+		"email":      {FilterEq, FilterContains},
+		"name":       {FilterContains},
+		"created_at": {FilterGte, FilterLte},
+	},
+	DefaultSort: Sort{Field: "id"},
+}
+
+// RoleQuerySpec is the whitelist of role listing
+var RoleQuerySpec = QuerySpec{
+	Sorts: []string{"id", "name", "slug", "created_at"},
+	Filters: map[string][]FilterOperator{
+		"name":       {FilterContains},
+		"slug":       {FilterEq},
+		"created_at": {FilterGte, FilterLte},
+	},
+	DefaultSort: Sort{Field: "id"},
+}
+
+// AuditLogQuerySpec is the whitelist of audit logs listing, newest first by default
+var AuditLogQuerySpec = QuerySpec{
+	Sorts: []string{"id", "created_at"},
+	Filters: map[string][]FilterOperator{
+		"actor_id":    {FilterEq},
+		"action":      {FilterEq},
+		"target_type": {FilterEq},
+		"target_id":   {FilterEq},
+		"created_at":  {FilterGte, FilterLte},
+	},
+	DefaultSort: Sort{Field: "id", Desc: true},
+}
+
+// WebhookQuerySpec is the whitelist of webhooks listing
+var WebhookQuerySpec = QuerySpec{
+	Sorts: []string{"id", "created_at"},
+	Filters: map[string][]FilterOperator{
+		"url":    {FilterContains},
+		"active": {FilterEq},
+	},
+	DefaultSort: Sort{Field: "id"},
+}
+
+// WebhookDeliveryQuerySpec is the whitelist of webhook deliveries listing, newest first by default
+var WebhookDeliveryQuerySpec = QuerySpec{
+	Sorts: []string{"id", "created_at"},
+	Filters: map[string][]FilterOperator{
+		"status":     {FilterEq},
+		"event_name": {FilterEq},
+		"created_at": {FilterGte, FilterLte},
+	},
+	DefaultSort: Sort{Field: "id", Desc: true},
+}
@@ -0,0 +1,89 @@
+package repository_test
+
+import (
+	"reflect"
+	"testing"
+
+	"go-app/internal/domain/repository"
+	"go-app/pkg/errors"
+)
+
+func TestQueryNormalize(t *testing.T) {
+	t.Parallel()
+
+	expectedResults := []struct {
+		query      repository.Query
+		err        error
+		normalized repository.Query
+	}{
+		// The defaults are filled and the limit is bounded
+		{
+			repository.Query{},
+			nil,
+			repository.Query{Page: 1, Limit: repository.DefaultLimit, Sorts: []repository.Sort{{Field: "id"}}},
+		},
+		{
+			repository.Query{Page: -2, Limit: 1000, Sorts: []repository.Sort{{Field: "name", Desc: true}}},
+			nil,
+			repository.Query{Page: 1, Limit: repository.MaxLimit, Sorts: []repository.Sort{{Field: "name", Desc: true}}},
+		},
+		{
+			repository.Query{
+				Page:    3,
+				Limit:   5,
+				Sorts:   []repository.Sort{{Field: "email"}, {Field: "created_at"}},
+				Filters: []repository.Filter{{Field: "email", Operator: repository.FilterContains, Value: "a"}},
+			},
+			nil,
+			repository.Query{
+				Page:    3,
+				Limit:   5,
+				Sorts:   []repository.Sort{{Field: "email"}, {Field: "created_at"}},
+				Filters: []repository.Filter{{Field: "email", Operator: repository.FilterContains, Value: "a"}},
+			},
+		},
+		// The fields and the operators out of the whitelist are refused
+		{repository.Query{Sorts: []repository.Sort{{Field: "password"}}}, errors.ErrInvalidQuery.Trace(), repository.Query{}},
+		{
+			repository.Query{Filters: []repository.Filter{{Field: "password", Operator: repository.FilterEq}}},
+			errors.ErrInvalidQuery.Trace(),
+			repository.Query{},
+		},
+		{
+			repository.Query{Filters: []repository.Filter{{Field: "name", Operator: repository.FilterEq}}},
+			errors.ErrInvalidQuery.Trace(),
+			repository.Query{},
+		},
+		// A cursor is kept on one sort
+		{
+			repository.Query{UseCursor: true, Sorts: []repository.Sort{{Field: "name"}, {Field: "email"}}},
+			errors.ErrInvalidQuery.Trace(),
+			repository.Query{},
+		},
+		{
+			repository.Query{UseCursor: true, Cursor: "c"},
+			nil,
+			repository.Query{
+				Page:      1,
+				Limit:     repository.DefaultLimit,
+				UseCursor: true,
+				Cursor:    "c",
+				Sorts:     []repository.Sort{{Field: "id"}},
+			},
+		},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		q := testExpected.query
+		err := q.Normalize(repository.UserQuerySpec)
+		if testExpected.err != nil {
+			if !errors.Is(err, testExpected.err) {
+				t.Errorf("#%d got %v, %v expected", testNumber, err, testExpected.err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(q, testExpected.normalized) {
+			t.Errorf("#%d got %+v %v, %+v expected", testNumber, q, err, testExpected.normalized)
+		}
+	}
+}
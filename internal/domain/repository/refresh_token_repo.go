@@ -0,0 +1,16 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/refresh_token_repo_mock.go
+package repository
+
+import (
+	"context"
+
+	"go-app/internal/domain/entity"
+)
+
+// RefreshTokenRepository represent the RefreshToken's repository contract
+type RefreshTokenRepository interface {
+	Store(ctx context.Context, rt *entity.RefreshToken) error
+	FindByToken(ctx context.Context, token string) (*entity.RefreshToken, error)
+	Revoke(ctx context.Context, id uint) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+}
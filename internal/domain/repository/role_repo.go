@@ -9,7 +9,7 @@ import (
 
 // RoleRepository represent the Role's repository contract
 type RoleRepository interface {
-	Fetch(context.Context) ([]entity.Role, error)
+	Fetch(ctx context.Context, q Query) ([]entity.Role, Pagination, error)
 	Find(ctx context.Context, id uint) (*entity.Role, error)
 	CheckExists(ctx context.Context, q entity.Role, id *uint) (bool, error)
 	Store(ctx context.Context, u *entity.Role) error
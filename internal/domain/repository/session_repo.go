@@ -0,0 +1,18 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/session_repo_mock.go
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go-app/internal/domain/entity"
+)
+
+// SessionRepository represent the Session's repository contract
+type SessionRepository interface {
+	FetchActiveByUser(ctx context.Context, userID uint, since time.Time) ([]entity.Session, error)
+	Find(ctx context.Context, id string) (*entity.Session, error)
+	Store(ctx context.Context, s *entity.Session) error
+	Touch(ctx context.Context, id, ip string) (bool, error)
+	Revoke(ctx context.Context, id string) error
+}
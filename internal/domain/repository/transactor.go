@@ -0,0 +1,21 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/transactor_mock.go
+package repository
+
+import (
+	"context"
+)
+
+// Repositories represent the repositories bound to a unit of work
+type Repositories interface {
+	User() UserRepository
+	Role() RoleRepository
+	PasswordReset() PasswordResetRepository
+	Audit() AuditRepository
+	Outbox() OutboxRepository
+}
+
+// Transactor represent the unit of work contract, the changes made through repos are committed
+// when fn returns nil and rolled back otherwise
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context, repos Repositories) error) error
+}
@@ -7,13 +7,19 @@ import (
 	"go-app/internal/domain/entity"
 )
 
-// UserRepository represent the User's repository contract
+// UserRepository represent the User's repository contract, Find may leave out the password and the two factor
+// secrets which FindCredentials always returns
 type UserRepository interface {
-	Fetch(context.Context) ([]entity.User, error)
+	Fetch(ctx context.Context, q Query) ([]entity.User, Pagination, error)
 	Find(ctx context.Context, id uint) (*entity.User, error)
+	FindCredentials(ctx context.Context, id uint) (*entity.User, error)
 	Store(ctx context.Context, u *entity.User) error
 	FindByQuery(ctx context.Context, q entity.User) (*entity.User, error)
 	CheckExists(ctx context.Context, q entity.User, id *uint) (bool, error)
 	Update(ctx context.Context, u *entity.User) error
+	UpdateTwoFactor(ctx context.Context, u *entity.User) error
+	ConsumeRecoveryCode(ctx context.Context, id uint, hash string) (bool, error)
+	VerifyEmail(ctx context.Context, id uint) error
+	UnverifyEmail(ctx context.Context, id uint) error
 	Delete(ctx context.Context, id uint) error
 }
@@ -0,0 +1,30 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/webhook_repo_mock.go
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go-app/internal/domain/entity"
+)
+
+// WebhookRepository represent the Webhook's repository contract
+type WebhookRepository interface {
+	Fetch(ctx context.Context, q Query) ([]entity.Webhook, Pagination, error)
+	FetchByEvent(ctx context.Context, name string) ([]entity.Webhook, error)
+	Find(ctx context.Context, id uint) (*entity.Webhook, error)
+	Store(ctx context.Context, webhook *entity.Webhook) error
+	Update(ctx context.Context, webhook *entity.Webhook) error
+	Delete(ctx context.Context, id uint) error
+}
+
+// WebhookDeliveryRepository represent the WebhookDelivery's repository contract
+type WebhookDeliveryRepository interface {
+	Fetch(ctx context.Context, webhookID uint, q Query) ([]entity.WebhookDelivery, Pagination, error)
+	Find(ctx context.Context, webhookID, id uint) (*entity.WebhookDelivery, error)
+	Store(ctx context.Context, delivery *entity.WebhookDelivery) error
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDelivery, error)
+	Update(ctx context.Context, delivery *entity.WebhookDelivery) error
+	StoreAttempt(ctx context.Context, attempt *entity.WebhookAttempt) error
+	FetchAttempts(ctx context.Context, deliveryID uint) ([]entity.WebhookAttempt, error)
+}
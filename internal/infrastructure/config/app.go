@@ -14,13 +14,18 @@ var (
 	appConf AppConfig
 )
 
-// AppConfig App Common
+// AppConfig App Common, X-Forwarded-For gives the client ip only behind the CIDR ranges of TrustedProxies,
+// AppSignatureKey signs the links sent by email apart from the tokens
 type AppConfig struct {
-	App           string `mapstructure:"APP_ENV"`
-	AllowedOrigin string `mapstructure:"APP_ALLOWED_ORIGIN"`
-	AppHost       string `mapstructure:"APP_HOST"`
-	AppJWTKey     string `mapstructure:"APP_JWT_KEY"`
-	AppTimeZone   string `mapstructure:"APP_TIME_ZONE"`
+	App             string `mapstructure:"APP_ENV"`
+	AppName         string `mapstructure:"APP_NAME"`
+	AllowedOrigin   string `mapstructure:"APP_ALLOWED_ORIGIN"`
+	AppHost         string `mapstructure:"APP_HOST"`
+	AppGRPCHost     string `mapstructure:"APP_GRPC_HOST"`
+	AppJWTKey       string `mapstructure:"APP_JWT_KEY"`
+	AppSignatureKey string `mapstructure:"APP_SIGNATURE_KEY"`
+	AppTimeZone     string `mapstructure:"APP_TIME_ZONE"`
+	TrustedProxies  string `mapstructure:"APP_TRUSTED_PROXIES"`
 }
 
 // LoadConfig config setting from .env.
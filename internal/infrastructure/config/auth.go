@@ -0,0 +1,32 @@
+package config
+
+import (
+	"sync"
+
+	"go-app/pkg/logger"
+
+	"github.com/spf13/viper"
+)
+
+var (
+	onceAuth sync.Once
+	authConf Auth
+)
+
+// Auth config struct
+type Auth struct {
+	DefaultRoleID     uint   `mapstructure:"AUTH_DEFAULT_ROLE_ID"`
+	EmailVerification string `mapstructure:"AUTH_EMAIL_VERIFICATION"`
+	VerifyURL         string `mapstructure:"AUTH_VERIFY_URL"`
+}
+
+// GetAuthConfig Unmarshal Auth Config from env
+func GetAuthConfig() Auth {
+	onceAuth.Do(func() {
+		if err := viper.Unmarshal(&authConf); err != nil {
+			logger.Error(err)
+		}
+	})
+
+	return authConf
+}
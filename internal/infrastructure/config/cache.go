@@ -0,0 +1,36 @@
+package config
+
+import (
+	"sync"
+	"time"
+
+	"go-app/pkg/logger"
+
+	"github.com/spf13/viper"
+)
+
+var (
+	onceCache sync.Once
+	cacheConf Cache
+)
+
+// Cache config struct, Driver is redis, memory or tiered, Repositories caches the users and the roles,
+// zero values fall back to the defaults
+type Cache struct {
+	Driver        string        `mapstructure:"CACHE_DRIVER"`
+	Size          int           `mapstructure:"CACHE_SIZE"`
+	LocalTTL      time.Duration `mapstructure:"CACHE_LOCAL_TTL"`
+	Repositories  bool          `mapstructure:"CACHE_REPOSITORIES"`
+	RepositoryTTL time.Duration `mapstructure:"CACHE_REPOSITORY_TTL"`
+}
+
+// GetCacheConfig Unmarshal Cache Config from env
+func GetCacheConfig() Cache {
+	onceCache.Do(func() {
+		if err := viper.Unmarshal(&cacheConf); err != nil {
+			logger.Error(err)
+		}
+	})
+
+	return cacheConf
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"sync"
+	"time"
 
 	"go-app/pkg/logger"
 
@@ -13,13 +14,17 @@ var (
 	mailConf  Email
 )
 
-// Email config struct
+// Email config struct, Driver is smtp, log, file or memory, Encryption is none, starttls or tls
 type Email struct {
-	Host     string `mapstructure:"MAIL_HOST"`
-	Port     int    `mapstructure:"MAIL_PORT"`
-	Username string `mapstructure:"MAIL_USERNAME"`
-	Password string `mapstructure:"MAIL_PASSWORD"`
-	From     string `mapstructure:"MAIL_FROM_ADDRESS"`
+	Driver     string        `mapstructure:"MAIL_DRIVER"`
+	Host       string        `mapstructure:"MAIL_HOST"`
+	Port       int           `mapstructure:"MAIL_PORT"`
+	Username   string        `mapstructure:"MAIL_USERNAME"`
+	Password   string        `mapstructure:"MAIL_PASSWORD"`
+	Encryption string        `mapstructure:"MAIL_ENCRYPTION"`
+	Timeout    time.Duration `mapstructure:"MAIL_TIMEOUT"`
+	FilePath   string        `mapstructure:"MAIL_FILE_PATH"`
+	From       string        `mapstructure:"MAIL_FROM_ADDRESS"`
 }
 
 // GetEmailConfig Unmarshal Email Config from env
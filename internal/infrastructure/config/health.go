@@ -0,0 +1,33 @@
+package config
+
+import (
+	"sync"
+	"time"
+
+	"go-app/pkg/logger"
+
+	"github.com/spf13/viper"
+)
+
+var (
+	onceHealth sync.Once
+	healthConf Health
+)
+
+// Health config struct, Timeout bounds every check of a readiness probe and CheckMail adds the SMTP server to
+// the optional checks, zero values fall back to the defaults
+type Health struct {
+	Timeout   time.Duration `mapstructure:"HEALTH_CHECK_TIMEOUT"`
+	CheckMail bool          `mapstructure:"HEALTH_CHECK_MAIL"`
+}
+
+// GetHealthConfig Unmarshal Health Config from env
+func GetHealthConfig() Health {
+	onceHealth.Do(func() {
+		if err := viper.Unmarshal(&healthConf); err != nil {
+			logger.Error(err)
+		}
+	})
+
+	return healthConf
+}
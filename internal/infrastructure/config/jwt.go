@@ -0,0 +1,32 @@
+package config
+
+import (
+	"sync"
+
+	"go-app/pkg/logger"
+
+	"github.com/spf13/viper"
+)
+
+var (
+	onceJWT sync.Once
+	jwtConf JWT
+)
+
+// JWT config struct, Keys is a comma separated list of kid=path of PEM files
+type JWT struct {
+	Algorithm    string `mapstructure:"JWT_ALGORITHM"`
+	SigningKeyID string `mapstructure:"JWT_SIGNING_KEY_ID"`
+	Keys         string `mapstructure:"JWT_KEYS"`
+}
+
+// GetJWTConfig Unmarshal JWT Config from env
+func GetJWTConfig() JWT {
+	onceJWT.Do(func() {
+		if err := viper.Unmarshal(&jwtConf); err != nil {
+			logger.Error(err)
+		}
+	})
+
+	return jwtConf
+}
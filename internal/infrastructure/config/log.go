@@ -0,0 +1,31 @@
+package config
+
+import (
+	"sync"
+
+	"go-app/pkg/logger"
+
+	"github.com/spf13/viper"
+)
+
+var (
+	onceLog sync.Once
+	logConf Log
+)
+
+// Log config struct, Level is trace, debug, info, warn or error and Format is text or json
+type Log struct {
+	Level  string `mapstructure:"LOG_LEVEL"`
+	Format string `mapstructure:"LOG_FORMAT"`
+}
+
+// GetLogConfig Unmarshal Log Config from env
+func GetLogConfig() Log {
+	onceLog.Do(func() {
+		if err := viper.Unmarshal(&logConf); err != nil {
+			logger.Error(err)
+		}
+	})
+
+	return logConf
+}
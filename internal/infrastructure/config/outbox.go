@@ -0,0 +1,34 @@
+package config
+
+import (
+	"sync"
+	"time"
+
+	"go-app/pkg/logger"
+
+	"github.com/spf13/viper"
+)
+
+var (
+	onceOutbox sync.Once
+	outboxConf Outbox
+)
+
+// Outbox config struct, zero values fall back to the defaults of the dispatcher
+type Outbox struct {
+	BatchSize    int           `mapstructure:"OUTBOX_BATCH_SIZE"`
+	PollInterval time.Duration `mapstructure:"OUTBOX_POLL_INTERVAL"`
+	MaxAttempts  int           `mapstructure:"OUTBOX_MAX_ATTEMPTS"`
+	RetryBackoff time.Duration `mapstructure:"OUTBOX_RETRY_BACKOFF"`
+}
+
+// GetOutboxConfig Unmarshal Outbox Config from env
+func GetOutboxConfig() Outbox {
+	onceOutbox.Do(func() {
+		if err := viper.Unmarshal(&outboxConf); err != nil {
+			logger.Error(err)
+		}
+	})
+
+	return outboxConf
+}
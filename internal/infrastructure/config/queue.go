@@ -0,0 +1,35 @@
+package config
+
+import (
+	"sync"
+	"time"
+
+	"go-app/pkg/logger"
+
+	"github.com/spf13/viper"
+)
+
+var (
+	onceQueue sync.Once
+	queueConf Queue
+)
+
+// Queue config struct, Driver is redis or memory, zero values fall back to the defaults of the worker
+type Queue struct {
+	Driver       string        `mapstructure:"QUEUE_DRIVER"`
+	Name         string        `mapstructure:"QUEUE_NAME"`
+	Concurrency  int           `mapstructure:"QUEUE_CONCURRENCY"`
+	MaxAttempts  int           `mapstructure:"QUEUE_MAX_ATTEMPTS"`
+	RetryBackoff time.Duration `mapstructure:"QUEUE_RETRY_BACKOFF"`
+}
+
+// GetQueueConfig Unmarshal Queue Config from env
+func GetQueueConfig() Queue {
+	onceQueue.Do(func() {
+		if err := viper.Unmarshal(&queueConf); err != nil {
+			logger.Error(err)
+		}
+	})
+
+	return queueConf
+}
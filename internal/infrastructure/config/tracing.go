@@ -0,0 +1,35 @@
+package config
+
+import (
+	"sync"
+
+	"go-app/pkg/logger"
+
+	"github.com/spf13/viper"
+)
+
+var (
+	onceTracing sync.Once
+	tracingConf Tracing
+)
+
+// Tracing config struct, Exporter is otlp, stdout or none, Endpoint is the address of the collector of the otlp
+// exporter reached in plain text when Insecure is set, SampleRatio is the ratio of the new traces sampled, zero
+// values fall back to the defaults
+type Tracing struct {
+	Exporter    string  `mapstructure:"TRACING_EXPORTER"`
+	Endpoint    string  `mapstructure:"TRACING_ENDPOINT"`
+	Insecure    bool    `mapstructure:"TRACING_INSECURE"`
+	SampleRatio float64 `mapstructure:"TRACING_SAMPLE_RATIO"`
+}
+
+// GetTracingConfig Unmarshal Tracing Config from env
+func GetTracingConfig() Tracing {
+	onceTracing.Do(func() {
+		if err := viper.Unmarshal(&tracingConf); err != nil {
+			logger.Error(err)
+		}
+	})
+
+	return tracingConf
+}
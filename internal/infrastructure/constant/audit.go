@@ -0,0 +1,71 @@
+package constant
+
+const (
+	// AuditTargetUser is the target type of users
+	AuditTargetUser = "user"
+	// AuditTargetRole is the target type of roles
+	AuditTargetRole = "role"
+	// AuditTargetPermission is the target type of permissions
+	AuditTargetPermission = "permission"
+	// AuditTargetSession is the target type of sessions
+	AuditTargetSession = "session"
+	// AuditTargetWebhook is the target type of webhooks
+	AuditTargetWebhook = "webhook"
+	// AuditTargetLockout is the target type of login lockouts, the id is the email or the ip
+	AuditTargetLockout = "lockout"
+)
+
+const (
+	// AuditUserCreated is recorded when a user is created by an admin
+	AuditUserCreated = "user.created"
+	// AuditUserUpdated is recorded when a user is updated by an admin
+	AuditUserUpdated = "user.updated"
+	// AuditUserDeleted is recorded when a user is deleted by an admin
+	AuditUserDeleted = "user.deleted"
+
+	// AuditRoleCreated is recorded when a role is created
+	AuditRoleCreated = "role.created"
+	// AuditRoleUpdated is recorded when a role is updated
+	AuditRoleUpdated = "role.updated"
+	// AuditRoleDeleted is recorded when a role is deleted
+	AuditRoleDeleted = "role.deleted"
+	// AuditRolePermissionsUpdated is recorded when permissions are granted to or revoked from a role
+	AuditRolePermissionsUpdated = "role.permissions_updated"
+
+	// AuditPermissionCreated is recorded when a permission is created
+	AuditPermissionCreated = "permission.created"
+	// AuditPermissionUpdated is recorded when a permission is updated
+	AuditPermissionUpdated = "permission.updated"
+	// AuditPermissionDeleted is recorded when a permission is deleted
+	AuditPermissionDeleted = "permission.deleted"
+
+	// AuditWebhookCreated is recorded when a webhook is created
+	AuditWebhookCreated = "webhook.created"
+	// AuditWebhookUpdated is recorded when a webhook is updated
+	AuditWebhookUpdated = "webhook.updated"
+	// AuditWebhookDeleted is recorded when a webhook is deleted
+	AuditWebhookDeleted = "webhook.deleted"
+
+	// AuditAuthRegistered is recorded when a user registers
+	AuditAuthRegistered = "auth.registered"
+	// AuditAuthLoggedIn is recorded when a session is started
+	AuditAuthLoggedIn = "auth.logged_in"
+	// AuditAuthLoggedOut is recorded when a user logs out
+	AuditAuthLoggedOut = "auth.logged_out"
+	// AuditAuthPasswordChanged is recorded when a user changes the password
+	AuditAuthPasswordChanged = "auth.password_changed"
+	// AuditAuthPasswordReset is recorded when a password is reset with a token
+	AuditAuthPasswordReset = "auth.password_reset"
+	// AuditAuthEmailVerified is recorded when an email is verified
+	AuditAuthEmailVerified = "auth.email_verified"
+	// AuditAuthTwoFactorEnabled is recorded when two factor authentication is confirmed
+	AuditAuthTwoFactorEnabled = "auth.two_factor_enabled"
+	// AuditAuthSessionRevoked is recorded when a user signs out a session
+	AuditAuthSessionRevoked = "auth.session_revoked"
+	// AuditAuthSessionsRevoked is recorded when a user signs out everywhere
+	AuditAuthSessionsRevoked = "auth.sessions_revoked"
+	// AuditAuthLockedOut is recorded when failed logins lock an account or an ip
+	AuditAuthLockedOut = "auth.locked_out"
+	// AuditAuthLockoutCleared is recorded when an admin clears the lockout of an account or an ip
+	AuditAuthLockoutCleared = "auth.lockout_cleared"
+)
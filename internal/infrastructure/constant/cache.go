@@ -0,0 +1,35 @@
+package constant
+
+import (
+	"time"
+)
+
+const (
+	// CacheDriverRedis keeps the cache in redis
+	CacheDriverRedis = "redis"
+	// CacheDriverMemory keeps the cache in the process, for tests and single instance runs
+	CacheDriverMemory = "memory"
+	// CacheDriverTiered keeps a local copy of the cache of redis, invalidated through redis pub/sub
+	CacheDriverTiered = "tiered"
+)
+
+const (
+	// CacheSize is number of keys kept in memory by the memory and tiered drivers
+	CacheSize = 10000
+	// CacheLocalTTL is the longest time a key is kept by the local tier 1m
+	CacheLocalTTL = time.Minute
+	// CacheInvalidationChannel is the redis channel of the invalidations of the tiered driver
+	CacheInvalidationChannel = "cache:invalidate"
+)
+
+const (
+	// RepositoryCacheVersion is part of the keys of the cached entities, bump it when their shape changes
+	RepositoryCacheVersion = "v1"
+	// RepositoryCacheTTL is the time a user or a role is cached 5m
+	RepositoryCacheTTL = time.Minute * 5
+	// RepositoryCacheNegativeTTL is the time a missing user or role is cached 30s
+	RepositoryCacheNegativeTTL = time.Second * 30
+	// RepositoryCacheVersionTTL is the time the version of a cached user or role is kept 24h, longer than its
+	// entries so a version starting over never finds one of them
+	RepositoryCacheVersionTTL = time.Hour * 24
+)
@@ -0,0 +1,25 @@
+package constant
+
+import (
+	"time"
+)
+
+const (
+	// HealthStatusUp is the status of a healthy application or dependency
+	HealthStatusUp = "up"
+	// HealthStatusDegraded is the status of an application whose optional dependencies are down
+	HealthStatusDegraded = "degraded"
+	// HealthStatusDown is the status of an application whose required dependencies are down
+	HealthStatusDown = "down"
+)
+
+const (
+	// HealthCheckTimeout is the time given to every check of a readiness probe 2s
+	HealthCheckTimeout = time.Second * 2
+	// HealthCheckPostgres is the name of the check of the database
+	HealthCheckPostgres = "postgres"
+	// HealthCheckRedis is the name of the check of redis
+	HealthCheckRedis = "redis"
+	// HealthCheckMail is the name of the check of the SMTP server
+	HealthCheckMail = "mail"
+)
@@ -11,22 +11,67 @@ const (
 	// ConnectWaitDuration for database sleep reconnect 5s
 	ConnectWaitDuration = time.Second * 5
 
+	// ConnectMaxAttempts is number of connections to the database tried at start 12, 1m with the wait
+	ConnectMaxAttempts = 12
+
 	// ConnectReadTimeout 30s
 	ConnectReadTimeout = time.Second * 30
 )
 
 const (
-	// TokenLifetime 7days
-	TokenLifetime = time.Hour * 7 * 24
+	// TokenLifetime 15m
+	TokenLifetime = time.Minute * 15
+	// RefreshTokenLifetime 30days
+	RefreshTokenLifetime = time.Hour * 30 * 24
+	// RefreshTokenLength is length of the opaque refresh token
+	RefreshTokenLength = 64
 	// GuardJWT use for context
 	GuardJWT = "jwt_object_user"
+	// GuardSession use for context
+	GuardSession = "jwt_session_id"
+	// SessionTouchInterval is how often last seen of a session is written 1m
+	SessionTouchInterval = time.Minute
 )
 
 const (
 	// TokenResetPasswordLifetime 5m
 	TokenResetPasswordLifetime = time.Minute * 5
-	// MaxLoginAttempt is max attempts for login
-	MaxLoginAttempt = 5
-	// ThrottleBlockExpireDuration is duration for 60 minutes
-	ThrottleBlockExpireDuration = time.Minute * 60
+	// LoginAccountMaxFailures is number of failed logins of an account in a window which locks it
+	LoginAccountMaxFailures = 5
+	// LoginIPMaxFailures is number of failed logins of an ip in a window which locks it, across every account
+	LoginIPMaxFailures = 20
+	// LoginFailureWindow is the time the failed logins are counted from the first one 15m
+	LoginFailureWindow = time.Minute * 15
+	// LoginLockoutDuration is the first lockout 5m, every next lockout doubles it
+	LoginLockoutDuration = time.Minute * 5
+	// LoginLockoutMaxDuration is the longest lockout 24h
+	LoginLockoutMaxDuration = time.Hour * 24
+	// LoginLockoutHistory is the time the lockouts escalate from the first one 24h
+	LoginLockoutHistory = time.Hour * 24
+	// LoginLockoutScopeAccount is the scope of the lockouts of an account
+	LoginLockoutScopeAccount = "account"
+	// LoginLockoutScopeIP is the scope of the lockouts of an ip
+	LoginLockoutScopeIP = "ip"
+)
+
+const (
+	// TwoFactorChallengeLifetime 5m
+	TwoFactorChallengeLifetime = time.Minute * 5
+	// TwoFactorRecoveryCodeCount is number of recovery codes generated on confirm
+	TwoFactorRecoveryCodeCount = 8
+	// TwoFactorRecoveryCodeLength is length of a recovery code
+	TwoFactorRecoveryCodeLength = 10
+	// TwoFactorCodeUsedDuration is how long a used code is rejected 90s
+	TwoFactorCodeUsedDuration = time.Second * 90
+)
+
+const (
+	// EmailVerificationLifetime 24h
+	EmailVerificationLifetime = time.Hour * 24
+	// EmailVerificationNone does not enforce verified email
+	EmailVerificationNone = "none"
+	// EmailVerificationLogin blocks log in until email is verified
+	EmailVerificationLogin = "login"
+	// EmailVerificationRoutes blocks selected routes until email is verified
+	EmailVerificationRoutes = "routes"
 )
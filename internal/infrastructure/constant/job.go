@@ -0,0 +1,34 @@
+package constant
+
+import (
+	"time"
+)
+
+const (
+	// QueueDriverRedis keeps the jobs in redis, the jobs are processed by cmd/worker
+	QueueDriverRedis = "redis"
+	// QueueDriverMemory keeps the jobs in the process, the app runs the workers itself
+	QueueDriverMemory = "memory"
+	// QueueDefaultName is the name of the queue when none is configured
+	QueueDefaultName = "default"
+)
+
+const (
+	// JobConcurrency is number of jobs processed at once
+	JobConcurrency = 4
+	// JobMaxAttempts is number of attempts before a job is dead
+	JobMaxAttempts = 5
+	// JobRetryBackoff is the wait before the first retry, doubled on every attempt 10s
+	JobRetryBackoff = time.Second * 10
+	// JobMaxRetryBackoff caps the wait between two attempts 1h
+	JobMaxRetryBackoff = time.Hour
+	// JobTimeout is the time given to a handler 1m
+	JobTimeout = time.Minute
+	// JobVisibilityTimeout is the time a reserved job is hidden from other workers 5m
+	JobVisibilityTimeout = time.Minute * 5
+	// JobReserveWait is how long a worker waits for a job before checking for shutdown 1s
+	JobReserveWait = time.Second
+	// WorkerShutdownTimeout is the time given to the workers to drain, longer than JobTimeout so a job in progress
+	// always gets its full time 1m15s
+	WorkerShutdownTimeout = JobTimeout + time.Second*15
+)
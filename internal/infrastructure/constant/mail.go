@@ -0,0 +1,40 @@
+package constant
+
+import (
+	"time"
+)
+
+const (
+	// MailDriverSMTP sends the mails with the SMTP server
+	MailDriverSMTP = "smtp"
+	// MailDriverLog writes the mails to the logger
+	MailDriverLog = "log"
+	// MailDriverFile writes the mails as .eml files to MAIL_FILE_PATH
+	MailDriverFile = "file"
+	// MailDriverMemory keeps the mails in the process, for tests
+	MailDriverMemory = "memory"
+)
+
+const (
+	// MailEncryptionNone sends the mails in plain text
+	MailEncryptionNone = "none"
+	// MailEncryptionStartTLS upgrades the connection with STARTTLS, usually on port 587
+	MailEncryptionStartTLS = "starttls"
+	// MailEncryptionTLS connects with implicit TLS, usually on port 465
+	MailEncryptionTLS = "tls"
+)
+
+const (
+	// MailTimeout is the time given to the SMTP server to accept a mail 10s
+	MailTimeout = time.Second * 10
+	// MailFilePath is the directory of the .eml files of the file driver
+	MailFilePath = "storage/mails"
+	// MailDefaultLocale is the locale of the templates used when the recipient has no supported locale
+	MailDefaultLocale = "en"
+	// MailTemplateResetPassword is the template of the reset password token
+	MailTemplateResetPassword = "reset_password"
+	// MailTemplateVerifyEmail is the template of the email verification link
+	MailTemplateVerifyEmail = "verify_email"
+	// MailTemplateAccountLocked is the notice of a lockout of the account after failed logins
+	MailTemplateAccountLocked = "account_locked"
+)
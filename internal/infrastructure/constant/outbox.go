@@ -0,0 +1,22 @@
+package constant
+
+import (
+	"time"
+)
+
+const (
+	// OutboxBatchSize is number of messages claimed at once
+	OutboxBatchSize = 100
+	// OutboxPollInterval is the wait when the outbox is drained 1s
+	OutboxPollInterval = time.Second
+	// OutboxMaxAttempts is number of attempts before a message is dead
+	OutboxMaxAttempts = 10
+	// OutboxRetryBackoff is the wait before the first retry, doubled on every attempt 5s
+	OutboxRetryBackoff = time.Second * 5
+	// OutboxMaxRetryBackoff caps the wait between two attempts 1h
+	OutboxMaxRetryBackoff = time.Hour
+	// OutboxPublishTimeout is the time given to publish a message 30s
+	OutboxPublishTimeout = time.Second * 30
+	// OutboxClaimMargin is added to the publish timeouts of a batch to lease it, the time to record the results 1m
+	OutboxClaimMargin = time.Minute
+)
@@ -0,0 +1,57 @@
+package constant
+
+const (
+	// PermissionUsersView allows listing and showing users
+	PermissionUsersView = "users.view"
+	// PermissionUsersCreate allows creating users
+	PermissionUsersCreate = "users.create"
+	// PermissionUsersUpdate allows updating users
+	PermissionUsersUpdate = "users.update"
+	// PermissionUsersDelete allows deleting users
+	PermissionUsersDelete = "users.delete"
+)
+
+const (
+	// PermissionRolesView allows listing and showing roles
+	PermissionRolesView = "roles.view"
+	// PermissionRolesCreate allows creating roles
+	PermissionRolesCreate = "roles.create"
+	// PermissionRolesUpdate allows updating roles and their permissions
+	PermissionRolesUpdate = "roles.update"
+	// PermissionRolesDelete allows deleting roles
+	PermissionRolesDelete = "roles.delete"
+)
+
+const (
+	// PermissionPermissionsView allows listing and showing permissions
+	PermissionPermissionsView = "permissions.view"
+	// PermissionPermissionsCreate allows creating permissions
+	PermissionPermissionsCreate = "permissions.create"
+	// PermissionPermissionsUpdate allows updating permissions
+	PermissionPermissionsUpdate = "permissions.update"
+	// PermissionPermissionsDelete allows deleting permissions
+	PermissionPermissionsDelete = "permissions.delete"
+)
+
+const (
+	// PermissionAuditLogsView allows listing audit logs
+	PermissionAuditLogsView = "audit_logs.view"
+)
+
+const (
+	// PermissionWebhooksView allows listing webhooks and their deliveries
+	PermissionWebhooksView = "webhooks.view"
+	// PermissionWebhooksCreate allows creating webhooks
+	PermissionWebhooksCreate = "webhooks.create"
+	// PermissionWebhooksUpdate allows updating webhooks and redelivering events
+	PermissionWebhooksUpdate = "webhooks.update"
+	// PermissionWebhooksDelete allows deleting webhooks
+	PermissionWebhooksDelete = "webhooks.delete"
+)
+
+const (
+	// PermissionLockoutsView allows inspecting the login lockouts
+	PermissionLockoutsView = "lockouts.view"
+	// PermissionLockoutsDelete allows clearing the login lockouts
+	PermissionLockoutsDelete = "lockouts.delete"
+)
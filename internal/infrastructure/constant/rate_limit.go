@@ -0,0 +1,14 @@
+package constant
+
+const (
+	// RateLimitKeyPrefix prefixes the keys of the rate limits
+	RateLimitKeyPrefix = "ratelimit:"
+	// RateLimitSize is number of keys tracked by the memory rate limiter
+	RateLimitSize = 100000
+	// HeaderRateLimitLimit is the number of requests allowed per period
+	HeaderRateLimitLimit = "X-RateLimit-Limit"
+	// HeaderRateLimitRemaining is the number of requests allowed right after the response
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	// HeaderRateLimitReset is the number of seconds before the full limit is available again
+	HeaderRateLimitReset = "X-RateLimit-Reset"
+)
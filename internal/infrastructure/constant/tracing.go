@@ -0,0 +1,17 @@
+package constant
+
+const (
+	// TracingExporterOTLP exports the spans to an OpenTelemetry collector over gRPC
+	TracingExporterOTLP = "otlp"
+	// TracingExporterStdout writes the spans to the standard output
+	TracingExporterStdout = "stdout"
+	// TracingExporterNone records no span, the trace context of the requests is still propagated
+	TracingExporterNone = "none"
+)
+
+const (
+	// TracingEndpoint is the address of the collector of the otlp exporter
+	TracingEndpoint = "localhost:4317"
+	// TracingSampleRatio is the ratio of the traces started by the application which are sampled
+	TracingSampleRatio = 1.0
+)
@@ -0,0 +1,36 @@
+package constant
+
+import (
+	"time"
+)
+
+const (
+	// WebhookDeliveryPending is a delivery waiting for its next attempt
+	WebhookDeliveryPending = "pending"
+	// WebhookDeliverySucceeded is a delivery accepted by the receiver
+	WebhookDeliverySucceeded = "succeeded"
+	// WebhookDeliveryDead is a delivery which failed every attempt
+	WebhookDeliveryDead = "dead"
+)
+
+const (
+	// WebhookBatchSize is number of deliveries claimed at once
+	WebhookBatchSize = 20
+	// WebhookPollInterval is the wait when no delivery is due 2s
+	WebhookPollInterval = time.Second * 2
+	// WebhookMaxAttempts is number of attempts before a delivery is dead
+	WebhookMaxAttempts = 8
+	// WebhookRetryBackoff is the wait before the first retry, doubled on every attempt 30s
+	WebhookRetryBackoff = time.Second * 30
+	// WebhookMaxRetryBackoff caps the wait between two attempts 6h
+	WebhookMaxRetryBackoff = time.Hour * 6
+	// WebhookTimeout is the time given to the receiver to answer 10s
+	WebhookTimeout = time.Second * 10
+	// WebhookClaimLease is the time the claimed deliveries are skipped by the other workers, a batch is sent in turn
+	// so it covers a timeout for every delivery of the batch and a minute to record them
+	WebhookClaimLease = WebhookBatchSize*WebhookTimeout + time.Minute
+	// WebhookSecretLength is length of the generated secrets
+	WebhookSecretLength = 32
+	// WebhookUserAgent is the user agent of webhook requests
+	WebhookUserAgent = "go-app-webhook/1.0"
+)
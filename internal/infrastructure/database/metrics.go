@@ -0,0 +1,90 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"go-app/internal/infrastructure/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+)
+
+// metricsStartKey is the key of the start of a statement in the gorm instance
+const metricsStartKey = "metrics:start"
+
+// metricsPlugin observes the duration and the errors of the gorm statements by operation and table
+type metricsPlugin struct{}
+
+// Name implements gorm.Plugin
+func (metricsPlugin) Name() string {
+	return "metrics"
+}
+
+// Initialize registers the callbacks around every operation
+func (p metricsPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	return errors.Join(
+		cb.Create().Before("*").Register("metrics:before_create", p.before),
+		cb.Create().After("*").Register("metrics:after_create", p.after("create")),
+		cb.Query().Before("*").Register("metrics:before_query", p.before),
+		cb.Query().After("*").Register("metrics:after_query", p.after("query")),
+		cb.Update().Before("*").Register("metrics:before_update", p.before),
+		cb.Update().After("*").Register("metrics:after_update", p.after("update")),
+		cb.Delete().Before("*").Register("metrics:before_delete", p.before),
+		cb.Delete().After("*").Register("metrics:after_delete", p.after("delete")),
+		cb.Row().Before("*").Register("metrics:before_row", p.before),
+		cb.Row().After("*").Register("metrics:after_row", p.after("row")),
+		cb.Raw().Before("*").Register("metrics:before_raw", p.before),
+		cb.Raw().After("*").Register("metrics:after_raw", p.after("raw")),
+	)
+}
+
+// before records the start of the statement
+func (metricsPlugin) before(db *gorm.DB) {
+	db.InstanceSet(metricsStartKey, time.Now())
+}
+
+// after observes the statement of operation
+func (metricsPlugin) after(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(metricsStartKey)
+		if !ok {
+			return
+		}
+		start, ok := v.(time.Time)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		metrics.DBQueryDuration.WithLabelValues(operation, table).Observe(time.Since(start).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			metrics.DBQueryErrors.WithLabelValues(operation, table).Inc()
+		}
+	}
+}
+
+// registerMetrics observes the statements of db and the connections of its pool
+func registerMetrics(db *gorm.DB, name string) error {
+	if err := db.Use(metricsPlugin{}); err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	// A pool of the same database is observed once
+	err = metrics.Registry.Register(collectors.NewDBStatsCollector(sqlDB, name))
+	if are := (prometheus.AlreadyRegisteredError{}); errors.As(err, &are) {
+		return nil
+	}
+
+	return err
+}
@@ -13,7 +13,8 @@ import (
 	"gorm.io/gorm/logger"
 )
 
-// NewGormDB setup Gorm
+// NewGormDB setup Gorm, the statements and the connection pool are observed by the metrics and the statements
+// are traced
 func NewGormDB(db config.Database) (*gorm.DB, error) {
 	uri := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		db.Host,
@@ -35,6 +36,12 @@ func NewGormDB(db config.Database) (*gorm.DB, error) {
 	if err != nil {
 		return nil, errors.ErrUnexpectedDBError.Wrap(err)
 	}
+	if err := registerMetrics(dbConnect, db.DBName); err != nil {
+		return nil, errors.ErrUnexpectedDBError.Wrap(err)
+	}
+	if err := dbConnect.Use(tracingPlugin{}); err != nil {
+		return nil, errors.ErrUnexpectedDBError.Wrap(err)
+	}
 
 	return dbConnect, nil
 }
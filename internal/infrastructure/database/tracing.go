@@ -0,0 +1,84 @@
+package database
+
+import (
+	"errors"
+
+	"go-app/internal/infrastructure/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// tracingSpanKey is the key of the span of a statement in the gorm instance
+const tracingSpanKey = "tracing:span"
+
+// tracingPlugin starts a client span per gorm statement of a traced context, the query is recorded with its
+// placeholders so the values, which may be passwords or tokens, are left out
+type tracingPlugin struct{}
+
+// Name implements gorm.Plugin
+func (tracingPlugin) Name() string {
+	return "tracing"
+}
+
+// Initialize registers the callbacks around every operation
+func (p tracingPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	return errors.Join(
+		cb.Create().Before("*").Register("tracing:before_create", p.before("create")),
+		cb.Create().After("*").Register("tracing:after_create", p.after),
+		cb.Query().Before("*").Register("tracing:before_query", p.before("query")),
+		cb.Query().After("*").Register("tracing:after_query", p.after),
+		cb.Update().Before("*").Register("tracing:before_update", p.before("update")),
+		cb.Update().After("*").Register("tracing:after_update", p.after),
+		cb.Delete().Before("*").Register("tracing:before_delete", p.before("delete")),
+		cb.Delete().After("*").Register("tracing:after_delete", p.after),
+		cb.Row().Before("*").Register("tracing:before_row", p.before("row")),
+		cb.Row().After("*").Register("tracing:after_row", p.after),
+		cb.Raw().Before("*").Register("tracing:before_raw", p.before("raw")),
+		cb.Raw().After("*").Register("tracing:after_raw", p.after),
+	)
+}
+
+// before starts the span of the statement of operation
+func (tracingPlugin) before(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		// The statements of the polling loops outside of a trace start no span
+		if db.Statement.Context == nil || !trace.SpanContextFromContext(db.Statement.Context).IsValid() {
+			return
+		}
+		_, span := tracing.Start(db.Statement.Context, "db."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemNamePostgreSQL, semconv.DBOperationName(operation)),
+		)
+		db.InstanceSet(tracingSpanKey, span)
+	}
+}
+
+// after ends the span of the statement with its query and its error, a missing record is not an error
+func (tracingPlugin) after(db *gorm.DB) {
+	v, ok := db.InstanceGet(tracingSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := v.(trace.Span)
+	if !ok {
+		return
+	}
+
+	if db.Statement.Table != "" {
+		span.SetAttributes(semconv.DBCollectionName(db.Statement.Table))
+	}
+	span.SetAttributes(
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+	err := db.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+	tracing.End(span, err)
+}
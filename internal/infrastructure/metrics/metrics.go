@@ -0,0 +1,83 @@
+// Package metrics is the catalog of the prometheus metrics of the application, they are registered on
+// Registry which is served by Handler
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry holds the metrics of the application with the go runtime and the process ones
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequests counts the requests by route template and status
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+	// HTTPRequestDuration observes the latency of the requests by route template
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency by method and route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+	// HTTPRequestsInFlight is number of requests being served
+	HTTPRequestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "HTTP requests being served.",
+	})
+
+	// DBQueryDuration observes the duration of the queries by operation and table
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Database query duration by operation and table.",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+	// DBQueryErrors counts the failed queries by operation and table, missing records are not errors
+	DBQueryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_query_errors_total",
+		Help: "Database query errors by operation and table.",
+	}, []string{"operation", "table"})
+
+	// RedisCommandDuration observes the duration of the redis commands, a pipeline is observed as "pipeline"
+	RedisCommandDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "redis_command_duration_seconds",
+		Help:    "Redis command duration by command.",
+		Buckets: []float64{.0001, .0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5},
+	}, []string{"command"})
+	// RedisCommandErrors counts the failed redis commands, a missing key is not an error
+	RedisCommandErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "redis_command_errors_total",
+		Help: "Redis command errors by command.",
+	}, []string{"command"})
+
+	// AuthEvents counts the authentication events, one of the gateway.Auth* constants
+	AuthEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_events_total",
+		Help: "Authentication events by event.",
+	}, []string{"event"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		HTTPRequestsInFlight,
+		DBQueryDuration,
+		DBQueryErrors,
+		RedisCommandDuration,
+		RedisCommandErrors,
+		AuthEvents,
+	)
+}
+
+// Handler serves the metrics of Registry in the prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
@@ -0,0 +1,95 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-app/internal/infrastructure/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+// metricsHook observes the duration and the errors of the commands
+type metricsHook struct{}
+
+// poolCollector exposes the statistics of the connection pool of the client
+type poolCollector struct {
+	client   *redis.Client
+	hits     *prometheus.Desc
+	misses   *prometheus.Desc
+	timeouts *prometheus.Desc
+	conns    *prometheus.Desc
+}
+
+// DialHook implements redis.Hook
+func (metricsHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook observes a command
+func (metricsHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		observe(cmd.Name(), start, err)
+
+		return err
+	}
+}
+
+// ProcessPipelineHook observes a pipeline as a whole
+func (metricsHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		observe("pipeline", start, err)
+
+		return err
+	}
+}
+
+// observe records the command, a missing key is not an error
+func observe(command string, start time.Time, err error) {
+	metrics.RedisCommandDuration.WithLabelValues(command).Observe(time.Since(start).Seconds())
+	if err != nil && !errors.Is(err, redis.Nil) {
+		metrics.RedisCommandErrors.WithLabelValues(command).Inc()
+	}
+}
+
+// newPoolCollector returns the collector of the pool of client
+func newPoolCollector(client *redis.Client) *poolCollector {
+	return &poolCollector{
+		client:   client,
+		hits:     prometheus.NewDesc("redis_pool_hits_total", "Free connections found in the pool.", nil, nil),
+		misses:   prometheus.NewDesc("redis_pool_misses_total", "Free connections not found in the pool.", nil, nil),
+		timeouts: prometheus.NewDesc("redis_pool_timeouts_total", "Waits for a connection timed out.", nil, nil),
+		conns:    prometheus.NewDesc("redis_pool_connections", "Connections of the pool by state.", []string{"state"}, nil),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.timeouts
+	ch <- c.conns
+}
+
+// Collect implements prometheus.Collector
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.client.PoolStats()
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.timeouts, prometheus.CounterValue, float64(stats.Timeouts))
+	ch <- prometheus.MustNewConstMetric(c.conns, prometheus.GaugeValue, float64(stats.IdleConns), "idle")
+	ch <- prometheus.MustNewConstMetric(c.conns, prometheus.GaugeValue, float64(stats.TotalConns-stats.IdleConns), "used")
+	ch <- prometheus.MustNewConstMetric(c.conns, prometheus.GaugeValue, float64(stats.StaleConns), "stale")
+}
+
+// registerMetrics observes the commands of client and its pool, the pool of the first client only
+func registerMetrics(client *redis.Client) {
+	client.AddHook(metricsHook{})
+	_ = metrics.Registry.Register(newPoolCollector(client))
+}
@@ -9,16 +9,20 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
-// New setup Redis
+// New setup Redis, the commands and the connection pool are observed by the metrics and the commands are traced
 func New(rd config.Redis) *redis.Client {
 	uri := fmt.Sprintf("%s:%s",
 		rd.Host,
 		strconv.Itoa(rd.Port),
 	)
 
-	return redis.NewClient(&redis.Options{
+	client := redis.NewClient(&redis.Options{
 		Addr:     uri,
 		Password: rd.Password, // no password set
 		DB:       0,           // use default DB
 	})
+	registerMetrics(client)
+	client.AddHook(tracingHook{})
+
+	return client
 }
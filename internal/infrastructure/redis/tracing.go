@@ -0,0 +1,67 @@
+package redis
+
+import (
+	"context"
+	"errors"
+
+	"go-app/internal/infrastructure/tracing"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingHook starts a client span per command of a traced context, the commands of the polling loops outside
+// of a trace start none, the arguments are left out since they may be tokens
+type tracingHook struct{}
+
+// DialHook implements redis.Hook
+func (tracingHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook traces a command
+func (tracingHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if !trace.SpanContextFromContext(ctx).IsValid() {
+			return next(ctx, cmd)
+		}
+		ctx, span := startSpan(ctx, cmd.Name())
+		err := next(ctx, cmd)
+		endSpan(span, err)
+
+		return err
+	}
+}
+
+// ProcessPipelineHook traces a pipeline as a whole
+func (tracingHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if !trace.SpanContextFromContext(ctx).IsValid() {
+			return next(ctx, cmds)
+		}
+		ctx, span := startSpan(ctx, "pipeline")
+		span.SetAttributes(attribute.Int("db.operation.batch.size", len(cmds)))
+		err := next(ctx, cmds)
+		endSpan(span, err)
+
+		return err
+	}
+}
+
+// startSpan starts the span of command
+func startSpan(ctx context.Context, command string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "redis."+command,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemNameRedis, semconv.DBOperationName(command)),
+	)
+}
+
+// endSpan ends the span, a missing key is not an error
+func endSpan(span trace.Span, err error) {
+	if errors.Is(err, redis.Nil) {
+		err = nil
+	}
+	tracing.End(span, err)
+}
@@ -1,14 +1,29 @@
 package registry
 
 import (
+	"fmt"
+
 	"go-app/internal/adapter/gateway/cache"
+	eventgw "go-app/internal/adapter/gateway/event"
+	"go-app/internal/adapter/gateway/limiter"
 	"go-app/internal/adapter/gateway/mail"
+	"go-app/internal/adapter/gateway/queue"
 	"go-app/internal/adapter/gateway/service"
 	"go-app/internal/adapter/repository"
+	"go-app/internal/domain/event"
 	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/config"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/usecase/audit"
 	"go-app/internal/usecase/auth"
+	"go-app/internal/usecase/health"
+	"go-app/internal/usecase/job"
+	"go-app/internal/usecase/outbox"
+	"go-app/internal/usecase/permission"
 	"go-app/internal/usecase/role"
 	"go-app/internal/usecase/user"
+	"go-app/internal/usecase/webhook"
+	"go-app/pkg/errors"
 
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
@@ -16,29 +31,251 @@ import (
 
 // Registry struct
 type Registry struct {
-	AuthUc *auth.Usecase
-	UserUc *user.Usecase
-	RoleUc *role.Usecase
-	JWTSvc gateway.JWTService
+	AuthUc       *auth.Usecase
+	AuditUc      *audit.Usecase
+	UserUc       *user.Usecase
+	RoleUc       *role.Usecase
+	PermissionUc *permission.Usecase
+	OutboxUc     *outbox.Usecase
+	WebhookUc    *webhook.Usecase
+	HealthUc     *health.Usecase
+	JobWorker    *job.Worker
+	JobQueue     gateway.JobQueue
+	EventBus     gateway.EventBus
+	RateLimiter  gateway.RateLimiter
+	JWTSvc       gateway.JWTService
+	JWTKeys      *service.JWTKeySet
+
+	cache gateway.Cache
 }
 
 // NewRegistry will create new registry
-func NewRegistry(db *gorm.DB, rdb *redis.Client) *Registry {
+func NewRegistry(db *gorm.DB, rdb *redis.Client) (*Registry, error) {
+	appConf := config.GetAppConfig()
+	// Anyone knowing the key forges the verification links, it is kept apart from the key of the tokens
+	if appConf.AppSignatureKey == "" || appConf.AppSignatureKey == appConf.AppJWTKey {
+		return nil, errors.ErrInternalServerError.Wrap(
+			fmt.Errorf("APP_SIGNATURE_KEY is not set or equals APP_JWT_KEY"),
+		)
+	}
+	jwtKeys, err := service.NewJWTKeySet(config.GetJWTConfig(), appConf.AppJWTKey)
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
+
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(db)
 	roleRepo := repository.NewRoleRepository(db)
 	passwordResetRepo := repository.NewPasswordResetRepository(db)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	permissionRepo := repository.NewPermissionRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
+	webhookDeliveryRepo := repository.NewWebhookDeliveryRepository(db)
+	outboxRepo := repository.NewOutboxRepository(db)
+	transactor := repository.NewTransactor(db)
 
-	cm := cache.NewRedisStore(rdb)
-	mailSvc := mail.NewSMTPEmail()
+	cacheConf := config.GetCacheConfig()
+	cm := newCache(cacheConf, rdb)
+	if cacheConf.Repositories {
+		ttl := cacheConf.RepositoryTTL
+		if ttl <= 0 {
+			ttl = constant.RepositoryCacheTTL
+		}
+		userRepo = repository.NewCachedUserRepository(userRepo, cm, ttl)
+		roleRepo = repository.NewCachedRoleRepository(roleRepo, cm, ttl)
+		transactor = repository.NewCachedTransactor(transactor, cm)
+	}
+	mailSvc, err := newMailService(config.GetEmailConfig())
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
 	// Initialize gateway
-	jwtSvc := service.NewJWTService(cm)
-	throttleSvc := service.NewThrottleService(cm)
+	jwtSvc := service.NewJWTService(jwtKeys, cm)
+	throttleSvc := newThrottleService(cacheConf, cm, rdb)
+	signSvc := service.NewSignatureService(appConf.AppSignatureKey)
+	eventBus := eventgw.NewBus()
+	webhookSvc := service.NewWebhookService(constant.WebhookTimeout, constant.WebhookUserAgent)
+	queueConf := config.GetQueueConfig()
+	jobQueue := newJobQueue(queueConf, rdb)
+	jobWorker := job.NewWorker(job.Policy{
+		Concurrency:  queueConf.Concurrency,
+		MaxAttempts:  queueConf.MaxAttempts,
+		RetryBackoff: queueConf.RetryBackoff,
+	}, jobQueue)
+	job.Handle(jobWorker, job.SendMailHandler(mailSvc))
+
+	auditUc := audit.NewUsecase(auditRepo)
+	webhookUc := webhook.NewUsecase(webhookSvc, webhookRepo, webhookDeliveryRepo, auditUc)
+	for _, name := range event.Names {
+		eventBus.Subscribe(name, webhookUc.HandleEvent)
+	}
+	outboxConf := config.GetOutboxConfig()
+	outboxPolicy := outbox.Policy{
+		BatchSize:    outboxConf.BatchSize,
+		PollInterval: outboxConf.PollInterval,
+		MaxAttempts:  outboxConf.MaxAttempts,
+		RetryBackoff: outboxConf.RetryBackoff,
+	}
+
+	healthUc, err := newHealth(config.GetHealthConfig(), db, rdb, cacheConf, queueConf)
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	authConf := config.GetAuthConfig()
+	// A registered user without a role would be refused by every permission check
+	if authConf.DefaultRoleID == 0 {
+		return nil, errors.ErrInternalServerError.Wrap(fmt.Errorf("AUTH_DEFAULT_ROLE_ID is not set"))
+	}
+	authPolicy := auth.Policy{
+		DefaultRoleID:     authConf.DefaultRoleID,
+		EmailVerification: authConf.EmailVerification,
+		VerifyURL:         authConf.VerifyURL,
+	}
+
+	authUc := auth.NewUsecase(
+		authPolicy,
+		jwtSvc,
+		throttleSvc,
+		jobQueue,
+		signSvc,
+		cm,
+		service.NewAuthEvents(),
+		transactor,
+		userRepo,
+		passwordResetRepo,
+		refreshTokenRepo,
+		sessionRepo,
+		permissionRepo,
+		auditUc,
+	)
 
 	return &Registry{
-		AuthUc: auth.NewUsecase(jwtSvc, throttleSvc, mailSvc, userRepo, passwordResetRepo),
-		UserUc: user.NewUsecase(userRepo),
-		RoleUc: role.NewUsecase(roleRepo),
-		JWTSvc: jwtSvc,
+		AuthUc:       authUc,
+		AuditUc:      auditUc,
+		UserUc:       user.NewUsecase(transactor, userRepo, authUc, auditUc),
+		RoleUc:       role.NewUsecase(transactor, roleRepo, auditUc),
+		PermissionUc: permission.NewUsecase(permissionRepo, roleRepo, auditUc),
+		OutboxUc:     outbox.NewUsecase(outboxPolicy, eventBus, outboxRepo),
+		WebhookUc:    webhookUc,
+		HealthUc:     healthUc,
+		JobWorker:    jobWorker,
+		JobQueue:     jobQueue,
+		EventBus:     eventBus,
+		RateLimiter:  newRateLimiter(cacheConf, rdb),
+		JWTSvc:       jwtSvc,
+		JWTKeys:      jwtKeys,
+		cache:        cm,
+	}, nil
+}
+
+// Close stops the background work of the registry, the database and redis clients are left open
+func (r *Registry) Close() error {
+	return r.cache.Close()
+}
+
+// newHealth returns the health checks of the database, of redis unless every driver using it is memory and,
+// when enabled, of the SMTP server which is optional
+func newHealth(
+	conf config.Health,
+	db *gorm.DB,
+	rdb *redis.Client,
+	cacheConf config.Cache,
+	queueConf config.Queue,
+) (*health.Usecase, error) {
+	uc := health.NewUsecase(conf.Timeout)
+	uc.Register(constant.HealthCheckPostgres, repository.NewHealthChecker(db), true)
+	if cacheConf.Driver != constant.CacheDriverMemory || queueConf.Driver != constant.QueueDriverMemory {
+		uc.Register(constant.HealthCheckRedis, cache.NewRedisHealthChecker(rdb), true)
+	}
+
+	emailConf := config.GetEmailConfig()
+	if conf.CheckMail && (emailConf.Driver == "" || emailConf.Driver == constant.MailDriverSMTP) {
+		checker, err := mail.NewSMTPHealthChecker(emailConf)
+		if err != nil {
+			return nil, err
+		}
+		uc.Register(constant.HealthCheckMail, checker, false)
+	}
+
+	return uc, nil
+}
+
+// newCache returns the cache of the configured driver, redis by default
+func newCache(conf config.Cache, rdb *redis.Client) gateway.Cache {
+	size := conf.Size
+	if size <= 0 {
+		size = constant.CacheSize
+	}
+	localTTL := conf.LocalTTL
+	if localTTL <= 0 {
+		localTTL = constant.CacheLocalTTL
+	}
+
+	switch conf.Driver {
+	case constant.CacheDriverMemory:
+		return cache.NewMemoryStore(size)
+	case constant.CacheDriverTiered:
+		return cache.NewTieredStore(rdb, size, localTTL, constant.CacheInvalidationChannel)
+	default:
+		return cache.NewRedisStore(rdb)
 	}
 }
+
+// newRateLimiter returns the rate limiter of the cache driver, the redis limiter falls back to a memory one
+// while redis fails
+func newRateLimiter(conf config.Cache, rdb *redis.Client) gateway.RateLimiter {
+	memory := limiter.NewMemoryLimiter(constant.RateLimitSize)
+	if conf.Driver == constant.CacheDriverMemory {
+		return memory
+	}
+
+	return limiter.NewRedisLimiter(rdb, memory)
+}
+
+// newThrottleService returns the throttle of the cache driver, the failures are counted in redis unless the cache
+// is the memory one
+func newThrottleService(conf config.Cache, cm gateway.Cache, rdb *redis.Client) gateway.ThrottleService {
+	if conf.Driver == constant.CacheDriverMemory {
+		return service.NewThrottleService(cm)
+	}
+
+	return service.NewRedisThrottleService(rdb)
+}
+
+// newMailService returns the mail service of the configured driver, smtp by default, an unknown driver is an error
+// so a typo does not fall back to sending real mails
+func newMailService(conf config.Email) (gateway.MailService, error) {
+	switch conf.Driver {
+	case "", constant.MailDriverSMTP:
+		return mail.NewSMTPEmail(conf)
+	case constant.MailDriverLog:
+		return mail.NewLogMailer(conf)
+	case constant.MailDriverFile:
+		return mail.NewFileMailer(conf)
+	case constant.MailDriverMemory:
+		mailer, err := mail.NewMemoryMailer(conf)
+		if err != nil {
+			return nil, err
+		}
+
+		return mailer, nil
+	default:
+		return nil, errors.ErrInternalServerError.Wrap(fmt.Errorf("unknown mail driver %q", conf.Driver))
+	}
+}
+
+// newJobQueue returns the job queue of the configured driver
+func newJobQueue(conf config.Queue, rdb *redis.Client) gateway.JobQueue {
+	if conf.Driver == constant.QueueDriverMemory {
+		return queue.NewMemoryQueue(constant.JobVisibilityTimeout, conf.MaxAttempts)
+	}
+	name := conf.Name
+	if name == "" {
+		name = constant.QueueDefaultName
+	}
+
+	return queue.NewRedisQueue(rdb, name, constant.JobVisibilityTimeout, conf.MaxAttempts)
+}
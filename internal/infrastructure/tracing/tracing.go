@@ -0,0 +1,126 @@
+// Package tracing installs the OpenTelemetry tracer provider of the application and starts the spans of the
+// usecases and the gateways, the trace context crosses processes in the W3C traceparent format
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go-app/internal/infrastructure/config"
+	"go-app/internal/infrastructure/constant"
+	"go-app/pkg/errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of the spans started by Start
+const tracerName = "go-app"
+
+// Setup installs the propagator and the tracer provider exporting with the exporter of conf, the spans are
+// attributed to service, the returned function flushes the spans left and stops the exporter
+func Setup(ctx context.Context, conf config.Tracing, service string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch conf.Exporter {
+	case "", constant.TracingExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case constant.TracingExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case constant.TracingExporterOTLP:
+		endpoint := conf.Endpoint
+		if endpoint == "" {
+			endpoint = constant.TracingEndpoint
+		}
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint)}
+		if conf.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	default:
+		err = fmt.Errorf("unknown tracing exporter %q", conf.Exporter)
+	}
+	if err != nil {
+		return nil, errors.ErrInternalServerError.Wrap(err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(service),
+	))
+	if err != nil {
+		return nil, errors.ErrInternalServerError.Wrap(err)
+	}
+	ratio := conf.SampleRatio
+	if ratio <= 0 {
+		ratio = constant.TracingSampleRatio
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		// A sampled caller keeps the trace whole
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a span named name, child of the span of ctx
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, opts...)
+}
+
+// End marks the span failed with err when it is not nil and ends it
+func End(span trace.Span, err error) {
+	_ = Fail(span, err)
+	span.End()
+}
+
+// Fail marks the span failed with err when it is not nil and returns err, the span is left to be ended
+func Fail(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
+
+// TraceID returns the trace id of the span of ctx, empty without a span
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+
+	return sc.TraceID().String()
+}
+
+// Inject returns the trace context of ctx to be carried by a message, nil without a span
+func Inject(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+
+	return carrier
+}
+
+// Extract returns a copy of ctx continuing the trace context carried by a message
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
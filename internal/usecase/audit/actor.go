@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"context"
+)
+
+// actorKey is the context key of Actor
+type actorKey struct{}
+
+// Actor is who performs the call, set by the delivery layer
+type Actor struct {
+	UserID    uint
+	IP        string
+	UserAgent string
+}
+
+// WithActor returns a copy of ctx carrying the actor
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// WithActorID returns a copy of ctx where the actor is the user id
+func WithActorID(ctx context.Context, userID uint) context.Context {
+	actor := ActorFromContext(ctx)
+	actor.UserID = userID
+
+	return WithActor(ctx, actor)
+}
+
+// ActorFromContext returns the actor of ctx, empty for internal calls
+func ActorFromContext(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+
+	return actor
+}
@@ -0,0 +1,66 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+)
+
+// redactedFields are never written to audit logs
+var redactedFields = []string{
+	"password",
+	"two_factor_secret",
+	"two_factor_recovery_codes",
+	"secret",
+	"created_at",
+	"updated_at",
+	"deleted_at",
+}
+
+// diff returns the fields of before and after which differ, a nil side is omitted
+func diff(before, after any) (map[string]any, map[string]any) {
+	b := toMap(before)
+	a := toMap(after)
+	if b == nil || a == nil {
+		return b, a
+	}
+
+	changedBefore := map[string]any{}
+	changedAfter := map[string]any{}
+	for key, value := range a {
+		if old, ok := b[key]; !ok || !reflect.DeepEqual(old, value) {
+			changedBefore[key] = b[key]
+			changedAfter[key] = value
+		}
+	}
+	for key, old := range b {
+		if _, ok := a[key]; !ok {
+			changedBefore[key] = old
+		}
+	}
+
+	return changedBefore, changedAfter
+}
+
+// toMap converts v to its json fields without the redacted ones
+func toMap(v any) map[string]any {
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil()) {
+		return nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	m := map[string]any{}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil
+	}
+	for key := range m {
+		if slices.Contains(redactedFields, key) {
+			delete(m, key)
+		}
+	}
+
+	return m
+}
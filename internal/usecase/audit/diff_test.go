@@ -0,0 +1,86 @@
+package audit_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/repository"
+	"go-app/internal/usecase/audit"
+)
+
+// fakeAuditRepository keeps the last stored audit log
+type fakeAuditRepository struct {
+	repository.AuditRepository
+
+	log *entity.AuditLog
+}
+
+func (rp *fakeAuditRepository) Store(_ context.Context, log *entity.AuditLog) error {
+	rp.log = log
+
+	return nil
+}
+
+func TestRecordDiff(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	jane := &entity.User{ID: 1, Name: "Jane", Email: "jane@example.com", RoleID: 2, Password: "a", CreatedAt: now}
+	renamed := *jane
+	renamed.Name = "John"
+	renamed.Password = "b"
+	renamed.UpdatedAt = now.Add(time.Minute)
+	var missing *entity.User
+
+	expectedResults := []struct {
+		before any
+		after  any
+		diff   [2]map[string]any
+	}{
+		// Only the changed fields are kept, the credentials and the timestamps never are
+		{jane, &renamed, [2]map[string]any{{"name": "Jane"}, {"name": "John"}}},
+		{jane, jane, [2]map[string]any{{}, {}}},
+		// A creation keeps every field of after, a deletion every field of before
+		{nil, &entity.Role{ID: 3, Name: "Editor"}, [2]map[string]any{nil, {"id": 3.0, "name": "Editor", "slug": ""}}},
+		{&entity.Role{ID: 3, Name: "Editor"}, missing, [2]map[string]any{{"id": 3.0, "name": "Editor", "slug": ""}, nil}},
+		{nil, nil, [2]map[string]any{nil, nil}},
+		// A field missing on one side is kept on the other one
+		{
+			map[string]any{"name": "a", "gone": true},
+			map[string]any{"name": "a", "added": 1},
+			[2]map[string]any{{"gone": true, "added": nil}, {"added": 1.0}},
+		},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		repo := &fakeAuditRepository{}
+		err := audit.NewUsecase(nil).RecordWith(
+			context.Background(), repo, "test", "user", uint(1), testExpected.before, testExpected.after,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(repo.log.Before, testExpected.diff[0]) ||
+			!reflect.DeepEqual(repo.log.After, testExpected.diff[1]) {
+			t.Errorf("#%d got %v %v, %v expected", testNumber, repo.log.Before, repo.log.After, testExpected.diff)
+		}
+	}
+}
+
+func TestRecordActor(t *testing.T) {
+	t.Parallel()
+	repo := &fakeAuditRepository{}
+	ctx := audit.WithActor(context.Background(), audit.Actor{IP: "203.0.113.7", UserAgent: "test"})
+	ctx = audit.WithActorID(ctx, 5)
+
+	if err := audit.NewUsecase(nil).RecordWith(ctx, repo, "test", "lockout", "user@example.com", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	log := repo.log
+	if log.ActorID == nil || *log.ActorID != 5 || log.IP != "203.0.113.7" || log.UserAgent != "test" ||
+		log.TargetID != "user@example.com" {
+		t.Errorf("got %+v, the actor of the context expected", log)
+	}
+}
@@ -0,0 +1,102 @@
+package audit
+
+import (
+	"context"
+	"strconv"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/repository"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/pkg/errors"
+	"go-app/pkg/logger"
+)
+
+// Usecase ...
+type Usecase struct {
+	repo repository.AuditRepository
+}
+
+// NewUsecase will create new an Usecase object representation of entity.Usecase interface
+func NewUsecase(repo repository.AuditRepository) *Usecase {
+	return &Usecase{
+		repo: repo,
+	}
+}
+
+// Fetch will fetch a page of audit logs from repo
+func (uc *Usecase) Fetch(c context.Context, q repository.Query) ([]entity.AuditLog, repository.Pagination, error) {
+	c, span := tracing.Start(c, "audit.Fetch")
+	defer span.End()
+
+	if err := q.Normalize(repository.AuditLogQuerySpec); err != nil {
+		return nil, repository.Pagination{}, tracing.Fail(span, errors.Throw(err))
+	}
+
+	items, pg, err := uc.repo.Fetch(c, q)
+	if err != nil {
+		return nil, pg, tracing.Fail(span, errors.Throw(err))
+	}
+
+	return items, pg, nil
+}
+
+// Record will store who did the action on the target, before and after are entities whose changed
+// fields are kept, nil for creations and deletions. Failures are logged and never fail the caller
+func (uc *Usecase) Record(ctx context.Context, action, targetType string, targetID any, before, after any) {
+	if err := uc.record(ctx, uc.repo, action, targetType, targetID, before, after); err != nil {
+		logger.ErrorContext(ctx, "audit log not recorded", "action", action, "error", err)
+	}
+}
+
+// RecordWith will store the audit log with repo, it is used to record in a transaction
+func (uc *Usecase) RecordWith(
+	ctx context.Context,
+	repo repository.AuditRepository,
+	action, targetType string,
+	targetID any,
+	before, after any,
+) error {
+	return uc.record(ctx, repo, action, targetType, targetID, before, after)
+}
+
+// record builds the audit log from the actor of ctx
+func (*Usecase) record(
+	ctx context.Context,
+	repo repository.AuditRepository,
+	action, targetType string,
+	targetID any,
+	before, after any,
+) error {
+	actor := ActorFromContext(ctx)
+	log := &entity.AuditLog{
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   formatID(targetID),
+		IP:         actor.IP,
+		UserAgent:  actor.UserAgent,
+		RequestID:  logger.RequestID(ctx),
+	}
+	if actor.UserID != 0 {
+		id := actor.UserID
+		log.ActorID = &id
+	}
+	log.Before, log.After = diff(before, after)
+
+	if err := repo.Store(ctx, log); err != nil {
+		return errors.Throw(err)
+	}
+
+	return nil
+}
+
+// formatID converts the id of target to string
+func formatID(id any) string {
+	switch v := id.(type) {
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case string:
+		return v
+	default:
+		return ""
+	}
+}
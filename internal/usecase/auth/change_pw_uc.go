@@ -4,24 +4,42 @@ import (
 	"context"
 
 	"go-app/internal/domain/entity"
+	"go-app/internal/domain/event"
+	"go-app/internal/domain/repository"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/internal/usecase/outbox"
 	"go-app/pkg/errors"
-	"go-app/pkg/utils"
 )
 
-// ChangePassword is function used to change password
-func (uc Usecase) ChangePassword(ctx context.Context, u *entity.User, confirmPW, pw string) error {
-	user, err := uc.repo.Find(ctx, u.ID)
+// ChangePassword is function used to change password, the sessions of user other than sessionID are revoked
+func (uc *Usecase) ChangePassword(ctx context.Context, u *entity.User, sessionID, confirmPW, pw string) error {
+	ctx, span := tracing.Start(ctx, "auth.ChangePassword")
+	defer span.End()
+
+	user, err := uc.repo.FindCredentials(ctx, u.ID)
 	if err != nil {
-		return errors.Throw(err)
+		return tracing.Fail(span, errors.Throw(err))
 	}
-	if !utils.ComparePassword(confirmPW, user.Password) {
-		return errors.ErrAuthInvalidateConfirmPass.Trace()
+	if !comparePassword(ctx, confirmPW, user.Password) {
+		return tracing.Fail(span, errors.ErrAuthInvalidateConfirmPass.Trace())
 	}
 
 	user.Password = pw
-	if err := uc.repo.Update(ctx, user); err != nil {
-		return errors.Throw(err)
+	err = uc.transactor.Transaction(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		if err := repos.User().Update(ctx, user); err != nil {
+			return errors.Throw(err)
+		}
+
+		return outbox.Enqueue(ctx, repos.Outbox(), event.PasswordChanged{UserID: user.ID})
+	})
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	if err := uc.revokeSessions(ctx, user.ID, sessionID); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
 	}
+	uc.auditUc.Record(ctx, constant.AuditAuthPasswordChanged, constant.AuditTargetUser, user.ID, nil, nil)
 
 	return nil
 }
@@ -0,0 +1,4 @@
+package auth
+
+// VerifyTwoFactorCode exports verifyTwoFactorCode to the tests
+var VerifyTwoFactorCode = (*Usecase).verifyTwoFactorCode
@@ -2,48 +2,56 @@ package auth
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"go-app/internal/domain/entity"
+	"go-app/internal/domain/gateway"
 	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/internal/usecase/job"
 	"go-app/pkg/errors"
-	"go-app/pkg/logger"
+	"go-app/pkg/i18n"
 	"go-app/pkg/utils"
 )
 
 // ForgotPassword is function used to forgot password
 func (uc *Usecase) ForgotPassword(ctx context.Context, email string) error {
+	ctx, span := tracing.Start(ctx, "auth.ForgotPassword")
+	defer span.End()
+
 	userByEmail := entity.User{Email: email}
 	exists, err := uc.repo.CheckExists(ctx, userByEmail, nil)
 	if err != nil {
-		return errors.Throw(err)
+		return tracing.Fail(span, errors.Throw(err))
 	}
 	if !exists {
-		return errors.ErrAuthInvalidateEmail.Trace()
+		return tracing.Fail(span, errors.ErrAuthInvalidateEmail.Trace())
 	}
 
 	// Create token and store to database
 	token, err := utils.RandString(tokenLength)
 	if err != nil {
-		return errors.ErrBadRequest.Wrap(err)
+		return tracing.Fail(span, errors.ErrBadRequest.Wrap(err))
 	}
 
 	if err := uc.pwRepo.StoreOrUpdate(ctx, email, token); err != nil {
-		return errors.Throw(err)
+		return tracing.Fail(span, errors.Throw(err))
 	}
 
-	bodyMail := fmt.Sprintf(
-		"Your token to reset password is %s, this token will be expired in %d minutes.",
-		token,
-		constant.TokenResetPasswordLifetime/time.Minute,
-	)
-	// Send email with go routine
-	go func() {
-		if err := uc.mailSvc.Send(ctx, "Reset Password", bodyMail, []string{email}); err != nil {
-			logger.Debugf("Send Email Error: %v", err)
-		}
-	}()
+	// Send email from the worker
+	mail := job.SendMail{
+		Template: constant.MailTemplateResetPassword,
+		Locale:   i18n.Locale(ctx),
+		Data: map[string]any{
+			"Token":     token,
+			"ExpiresIn": int(constant.TokenResetPasswordLifetime / time.Minute),
+		},
+		To: []string{email},
+	}
+	if err := job.Dispatch(ctx, uc.jobQueue, mail); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	uc.events.Record(gateway.AuthPasswordResetRequested)
 
 	return nil
 }
@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/internal/usecase/job"
+	"go-app/pkg/errors"
+	"go-app/pkg/i18n"
+	"go-app/pkg/logger"
+)
+
+// Lockouts returns the failed logins and the lockouts of the email, of the two factor codes of its user and of
+// the ip
+func (uc *Usecase) Lockouts(ctx context.Context, email, ip string) ([]entity.Lockout, error) {
+	ctx, span := tracing.Start(ctx, "auth.Lockouts")
+	defer span.End()
+
+	accounts, err := uc.lockoutAccounts(ctx, email)
+	if err != nil {
+		return nil, tracing.Fail(span, errors.Throw(err))
+	}
+
+	lockouts := []entity.Lockout{}
+	for i, account := range accounts {
+		// The ip is inspected once
+		if i > 0 {
+			ip = ""
+		}
+		items, err := uc.throttleSvc.Lockouts(ctx, account, ip)
+		if err != nil {
+			return nil, tracing.Fail(span, errors.Throw(err))
+		}
+		lockouts = append(lockouts, items...)
+	}
+
+	return lockouts, nil
+}
+
+// Unlock clears the failed logins and the lockouts of the email, of the two factor codes of its user and of
+// the ip
+func (uc *Usecase) Unlock(ctx context.Context, email, ip string) error {
+	ctx, span := tracing.Start(ctx, "auth.Unlock")
+	defer span.End()
+
+	accounts, err := uc.lockoutAccounts(ctx, email)
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	for i, account := range accounts {
+		if i > 0 {
+			ip = ""
+		}
+		lockouts, err := uc.throttleSvc.Lockouts(ctx, account, ip)
+		if err != nil {
+			return tracing.Fail(span, errors.Throw(err))
+		}
+		if err := uc.throttleSvc.Unlock(ctx, account, ip); err != nil {
+			return tracing.Fail(span, errors.Throw(err))
+		}
+		for j := range lockouts {
+			if lockouts[j].Failures == 0 && lockouts[j].Level == 0 {
+				continue
+			}
+			uc.auditUc.Record(
+				ctx,
+				constant.AuditAuthLockoutCleared,
+				constant.AuditTargetLockout,
+				lockouts[j].Subject,
+				&lockouts[j],
+				nil,
+			)
+		}
+	}
+
+	return nil
+}
+
+// lockoutAccounts returns the throttled accounts of the email, the email itself and the two factor codes of
+// its user when it exists, an empty email only when there is none
+func (uc *Usecase) lockoutAccounts(ctx context.Context, email string) ([]string, error) {
+	if email == "" {
+		return []string{""}, nil
+	}
+	user, err := uc.repo.FindByQuery(ctx, entity.User{Email: email})
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound.Trace()) {
+			return []string{email}, nil
+		}
+		return nil, errors.Throw(err)
+	}
+
+	return []string{email, twoFactorThrottleKey(user.ID)}, nil
+}
+
+// failLogin counts a failed login of the account from the ip, when it locks the account the owner, if
+// known, is notified by email. Failures are logged and never fail the login
+func (uc *Usecase) failLogin(ctx context.Context, user *entity.User, account, ip string) {
+	uc.events.Record(gateway.AuthLoginFailed)
+	lockout, err := uc.throttleSvc.Fail(ctx, account, ip)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed login not counted", "error", err)
+		return
+	}
+	if lockout == nil {
+		return
+	}
+	uc.events.Record(gateway.AuthLockedOut)
+	uc.auditUc.Record(ctx, constant.AuditAuthLockedOut, constant.AuditTargetLockout, lockout.Subject, nil, lockout)
+	if user == nil {
+		return
+	}
+
+	// Send email from the worker
+	mail := job.SendMail{
+		Template: constant.MailTemplateAccountLocked,
+		Locale:   i18n.Locale(ctx),
+		Data: map[string]any{
+			"IP":        ip,
+			"ExpiresIn": int(lockout.RetryAfter / time.Minute),
+		},
+		To: []string{user.Email},
+	}
+	if err := job.Dispatch(ctx, uc.jobQueue, mail); err != nil {
+		logger.ErrorContext(ctx, "lockout mail not sent", "error", err)
+	}
+}
@@ -2,48 +2,106 @@ package auth
 
 import (
 	"context"
+	"time"
 
 	"go-app/internal/domain/entity"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/internal/usecase/audit"
 	"go-app/pkg/errors"
 	"go-app/pkg/utils"
 )
 
-// Login is function uses to log in
-func (uc *Usecase) Login(ctx context.Context, u *entity.User, ip string) (string, int64, error) {
-	// Check throttle login
-	if blocked, err := uc.throttleSvc.Blocked(ctx, u.Email, ip); err != nil {
-		return "", 0, errors.Throw(err)
-	} else if blocked {
-		return "", 0, errors.ErrAuthThrottleLogin.Trace()
+// Login is function uses to log in, a new session is recorded for the device.
+// When two factor authentication is enabled a challenge is returned instead of tokens.
+func (uc *Usecase) Login(
+	ctx context.Context,
+	u *entity.User,
+	ss *entity.Session,
+) (*entity.AuthToken, *entity.TwoFactorChallenge, error) {
+	ctx, span := tracing.Start(ctx, "auth.Login")
+	defer span.End()
+
+	// Check throttle login of the account and the ip
+	if blocked, err := uc.throttleSvc.Blocked(ctx, u.Email, ss.IP); err != nil {
+		return nil, nil, tracing.Fail(span, errors.Throw(err))
+	} else if blocked > 0 {
+		uc.events.Record(gateway.AuthLoginBlocked)
+		return nil, nil, tracing.Fail(span, errors.ErrAuthThrottleLogin.Trace())
 	}
 
 	// Retrieve user by email
 	user, err := uc.repo.FindByQuery(ctx, entity.User{Email: u.Email})
 	if err != nil {
 		if errors.Is(err, errors.ErrNotFound.Trace()) {
-			_ = uc.throttleSvc.Incr(ctx, u.Email, ip)
-			return "", 0, errors.ErrAuthLoginFailed.Trace()
+			uc.failLogin(ctx, nil, u.Email, ss.IP)
+			return nil, nil, tracing.Fail(span, errors.ErrAuthLoginFailed.Trace())
 		}
-		return "", 0, errors.Throw(err)
+		return nil, nil, tracing.Fail(span, errors.Throw(err))
 	}
 
 	// Compare passwords
-	if !utils.ComparePassword(u.Password, user.Password) {
-		_ = uc.throttleSvc.Incr(ctx, u.Email, ip)
-		return "", 0, errors.ErrAuthLoginFailed.Trace()
+	if !comparePassword(ctx, u.Password, user.Password) {
+		uc.failLogin(ctx, user, u.Email, ss.IP)
+		return nil, nil, tracing.Fail(span, errors.ErrAuthLoginFailed.Trace())
 	}
 
-	// Generate token
-	token, exp, err := uc.jwtSvc.GenerateToken(ctx, user)
-	if err != nil {
-		return "", 0, errors.Throw(err)
+	// Clear throttle data of the account, the failures of the ip are kept
+	if err := uc.throttleSvc.Clear(ctx, u.Email); err != nil {
+		return nil, nil, tracing.Fail(span, errors.Throw(err))
 	}
 
-	// Clear throttle data
-	if err := uc.throttleSvc.Clear(ctx, u.Email, ip); err != nil {
-		return "", 0, errors.Throw(err)
+	// Block unverified accounts when required by policy
+	if uc.policy.EmailVerification == constant.EmailVerificationLogin && user.EmailVerifiedAt == nil {
+		return nil, nil, tracing.Fail(span, errors.ErrAuthEmailNotVerified.Trace())
+	}
+
+	// Second step is required
+	if user.TwoFactorConfirmedAt != nil {
+		challenge, err := uc.createTwoFactorChallenge(ctx, user, ss)
+		if err != nil {
+			return nil, nil, tracing.Fail(span, errors.Throw(err))
+		}
+
+		return nil, challenge, nil
+	}
+
+	token, err := uc.startSession(ctx, user, ss)
+	if err != nil {
+		return nil, nil, tracing.Fail(span, errors.Throw(err))
 	}
 
 	*u = *user
-	return token, exp, nil
+	return token, nil, nil
+}
+
+// startSession records the session and issues its first tokens
+func (uc *Usecase) startSession(ctx context.Context, user *entity.User, ss *entity.Session) (*entity.AuthToken, error) {
+	// Record session, it is also the family of refresh tokens
+	ss.ID = utils.GenerateUUID()
+	ss.UserID = user.ID
+	ss.LastSeenAt = time.Now()
+	if err := uc.sessionRepo.Store(ctx, ss); err != nil {
+		return nil, errors.Throw(err)
+	}
+	actorCtx := audit.WithActorID(ctx, user.ID)
+	uc.auditUc.Record(actorCtx, constant.AuditAuthLoggedIn, constant.AuditTargetSession, ss.ID, nil, ss)
+	uc.events.Record(gateway.AuthLoginSucceeded)
+
+	// Generate token
+	token, err := uc.issueToken(ctx, user, ss.ID)
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	return token, nil
+}
+
+// comparePassword compares the password with the bcrypt hash in a span, bcrypt is slow by design
+func comparePassword(ctx context.Context, pw, hash string) bool {
+	_, span := tracing.Start(ctx, "bcrypt.ComparePassword")
+	defer span.End()
+
+	return utils.ComparePassword(pw, hash)
 }
@@ -3,14 +3,29 @@ package auth
 import (
 	"context"
 
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/tracing"
 	"go-app/pkg/errors"
 )
 
 // Logout is function used to logout
 func (uc *Usecase) Logout(ctx context.Context, token any) error {
+	ctx, span := tracing.Start(ctx, "auth.Logout")
+	defer span.End()
+
 	if err := uc.jwtSvc.Invalidate(ctx, token); err != nil {
-		return errors.Throw(err)
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	// Revoke the session and refresh tokens issued with the same login
+	sessionID, err := uc.jwtSvc.SessionID(token)
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	if err := uc.revokeSession(ctx, sessionID); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
 	}
+	uc.auditUc.Record(ctx, constant.AuditAuthLoggedOut, constant.AuditTargetSession, sessionID, nil, nil)
 
 	return nil
 }
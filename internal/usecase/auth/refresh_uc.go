@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/pkg/errors"
+)
+
+// Refresh is function used to rotate the refresh token and issue a new access token
+func (uc *Usecase) Refresh(ctx context.Context, token, ip string) (*entity.User, *entity.AuthToken, error) {
+	ctx, span := tracing.Start(ctx, "auth.Refresh")
+	defer span.End()
+
+	rt, err := uc.rtRepo.FindByToken(ctx, token)
+	if err != nil {
+		return nil, nil, tracing.Fail(span, errors.Throw(err))
+	}
+
+	// A revoked token presented again means it has leaked, revoke the whole family
+	if rt.RevokedAt != nil {
+		return nil, nil, tracing.Fail(span, uc.revokeReusedFamily(ctx, rt.FamilyID))
+	}
+
+	if time.Now().After(rt.ExpiresAt) {
+		return nil, nil, tracing.Fail(span, errors.ErrAuthRefreshTokenInvalid.Trace())
+	}
+
+	// Rotate, only one concurrent request may consume the token
+	revoked, err := uc.rtRepo.Revoke(ctx, rt.ID)
+	if err != nil {
+		return nil, nil, tracing.Fail(span, errors.Throw(err))
+	}
+	if !revoked {
+		return nil, nil, tracing.Fail(span, uc.revokeReusedFamily(ctx, rt.FamilyID))
+	}
+
+	// The session must still be active
+	active, err := uc.sessionRepo.Touch(ctx, rt.FamilyID, ip)
+	if err != nil {
+		return nil, nil, tracing.Fail(span, errors.Throw(err))
+	}
+	if !active {
+		return nil, nil, tracing.Fail(span, errors.ErrAuthRefreshTokenInvalid.Trace())
+	}
+
+	user, err := uc.repo.Find(ctx, rt.UserID)
+	if err != nil {
+		return nil, nil, tracing.Fail(span, errors.Throw(err))
+	}
+
+	authToken, err := uc.issueToken(ctx, user, rt.FamilyID)
+	if err != nil {
+		return nil, nil, tracing.Fail(span, errors.Throw(err))
+	}
+
+	return user, authToken, nil
+}
+
+// issueToken generates an access token and stores a new refresh token of the family
+func (uc *Usecase) issueToken(ctx context.Context, user *entity.User, familyID string) (*entity.AuthToken, error) {
+	// Permissions of the role are carried by the access token
+	permissions, err := uc.permRepo.FetchByRole(ctx, user.RoleID)
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
+	user.Permissions = make([]string, 0, len(permissions))
+	for i := range permissions {
+		user.Permissions = append(user.Permissions, permissions[i].Name)
+	}
+
+	accessToken, exp, err := uc.jwtSvc.GenerateToken(ctx, user, familyID)
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	refreshToken, refreshExp, err := uc.jwtSvc.GenerateRefreshToken(ctx)
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	rt := &entity.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		Token:     refreshToken,
+		ExpiresAt: time.Unix(refreshExp, 0),
+	}
+	if err := uc.rtRepo.Store(ctx, rt); err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	return &entity.AuthToken{
+		AccessToken:      accessToken,
+		ExpiresAt:        exp,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refreshExp,
+	}, nil
+}
+
+// revokeReusedFamily revokes the session of the family and reports the reuse
+func (uc *Usecase) revokeReusedFamily(ctx context.Context, familyID string) error {
+	if err := uc.revokeSession(ctx, familyID); err != nil {
+		return errors.Throw(err)
+	}
+	uc.events.Record(gateway.AuthRefreshTokenReused)
+
+	return errors.ErrAuthRefreshTokenReused.Trace()
+}
@@ -0,0 +1,128 @@
+package auth_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/gateway"
+	"go-app/pkg/errors"
+)
+
+func TestRefreshRotates(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	f := newFixture(t)
+	if err := f.rtRepo.Store(ctx, &entity.RefreshToken{
+		UserID: 1, FamilyID: "s1", Token: "rt-1", ExpiresAt: time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	user, token, err := f.uc.Refresh(ctx, "rt-1", "10.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.ID != 1 || token.RefreshToken == "" || token.RefreshToken == "rt-1" {
+		t.Fatalf("got %+v %+v, a new refresh token of the user 1 expected", user, token)
+	}
+	if _, err := f.decode(token.AccessToken); err != nil {
+		t.Errorf("got %v decoding the new access token", err)
+	}
+
+	// The presented token is revoked, the new one belongs to the same family
+	family := f.rtRepo.family("s1")
+	if len(family) != 2 || family[0].RevokedAt == nil || family[1].RevokedAt != nil ||
+		family[1].Token != token.RefreshToken {
+		t.Errorf("got the family %+v, the rotated token revoked and the new one active expected", family)
+	}
+	if ss := f.sessionRepo.sessions["s1"]; ss.IP != "10.0.0.1" {
+		t.Errorf("got the session %+v, the ip of the refresh expected", ss)
+	}
+
+	// The new token rotates again
+	if _, next, err := f.uc.Refresh(ctx, token.RefreshToken, "10.0.0.1"); err != nil || next.RefreshToken == "" {
+		t.Errorf("got %v %v rotating the new token", next, err)
+	}
+}
+
+func TestRefreshReuseRevokesFamily(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	f := newFixture(t)
+	if err := f.rtRepo.Store(ctx, &entity.RefreshToken{
+		UserID: 1, FamilyID: "s1", Token: "rt-1", ExpiresAt: time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	access := f.accessToken(t, "s1")
+
+	_, token, err := f.uc.Refresh(ctx, "rt-1", "10.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The rotated token presented again has leaked, the session and every token of the family are revoked
+	if _, _, err := f.uc.Refresh(ctx, "rt-1", "10.0.0.2"); !errors.Is(err, errors.ErrAuthRefreshTokenReused.Trace()) {
+		t.Fatalf("got %v, ErrAuthRefreshTokenReused expected", err)
+	}
+	if !f.sessionRepo.revoked("s1") || f.sessionRepo.revoked("s2") {
+		t.Error("got the sessions not revoked, the session of the family only expected")
+	}
+	for _, rt := range f.rtRepo.family("s1") {
+		if rt.RevokedAt == nil {
+			t.Errorf("got the token %s active, the whole family revoked expected", rt.Token)
+		}
+	}
+	for _, raw := range []string{access, token.AccessToken} {
+		if _, err := f.decode(raw); !errors.Is(err, errors.ErrJWTRevoke.Trace()) {
+			t.Errorf("got %v decoding an access token of the family, ErrJWTRevoke expected", err)
+		}
+	}
+	if !slices.Contains(f.events.events, gateway.AuthRefreshTokenReused) {
+		t.Errorf("got the events %v, the reuse expected", f.events.events)
+	}
+
+	// The token issued before the reuse was detected is revoked with its family
+	if _, _, err := f.uc.Refresh(ctx, token.RefreshToken, "10.0.0.1"); err == nil {
+		t.Error("got the token of a revoked family refreshed")
+	}
+}
+
+func TestRefreshRejects(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	f := newFixture(t)
+	tokens := []entity.RefreshToken{
+		{UserID: 1, FamilyID: "s2", Token: "rt-expired", ExpiresAt: time.Now().Add(-time.Minute)},
+		{UserID: 1, FamilyID: "s4", Token: "rt-revoked-session", ExpiresAt: time.Now().Add(time.Hour)},
+	}
+	for i := range tokens {
+		if err := f.rtRepo.Store(ctx, &tokens[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expectedResults := []struct {
+		token string
+		err   error
+	}{
+		{"rt-expired", errors.ErrAuthRefreshTokenInvalid.Trace()},
+		{"rt-revoked-session", errors.ErrAuthRefreshTokenInvalid.Trace()},
+		{"rt-unknown", errors.ErrAuthRefreshTokenInvalid.Trace()},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		user, token, err := f.uc.Refresh(ctx, testExpected.token, "10.0.0.1")
+		if !errors.Is(err, testExpected.err) || user != nil || token != nil {
+			t.Errorf("#%d got %v %v %v, %v expected", testNumber, user, token, err, testExpected.err)
+		}
+	}
+
+	// An expired token is not a reuse, its session stays active
+	if f.sessionRepo.revoked("s2") || len(f.events.events) != 0 {
+		t.Errorf("got the session of the expired token revoked or the events %v", f.events.events)
+	}
+}
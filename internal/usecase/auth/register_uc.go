@@ -4,14 +4,53 @@ import (
 	"context"
 
 	"go-app/internal/domain/entity"
+	"go-app/internal/domain/event"
+	"go-app/internal/domain/repository"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/internal/usecase/audit"
+	"go-app/internal/usecase/outbox"
 	"go-app/pkg/errors"
+	"go-app/pkg/logger"
 )
 
 // Register is function used to register user
 func (uc *Usecase) Register(ctx context.Context, user *entity.User) (*entity.User, error) {
-	// 1. Store user to database
-	if err := uc.repo.Store(ctx, user); err != nil {
-		return nil, errors.ErrBadRequest.Wrap(err)
+	ctx, span := tracing.Start(ctx, "auth.Register")
+	defer span.End()
+
+	// 1. Check exist by email
+	exists, err := uc.repo.CheckExists(ctx, entity.User{Email: user.Email}, nil)
+	if err != nil {
+		return nil, tracing.Fail(span, errors.Throw(err))
+	}
+	if exists {
+		return nil, tracing.Fail(span, errors.ErrUserExistsByEmail.Trace())
+	}
+
+	// 2. Store user to database with the default role
+	user.RoleID = uc.policy.DefaultRoleID
+	user.EmailVerifiedAt = nil
+	err = uc.transactor.Transaction(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		if err := repos.User().Store(ctx, user); err != nil {
+			return errors.ErrBadRequest.Wrap(err)
+		}
+
+		return outbox.Enqueue(ctx, repos.Outbox(), event.UserRegistered{
+			UserID: user.ID,
+			Email:  user.Email,
+			Name:   user.Name,
+		})
+	})
+	if err != nil {
+		return nil, tracing.Fail(span, errors.Throw(err))
+	}
+	actorCtx := audit.WithActorID(ctx, user.ID)
+	uc.auditUc.Record(actorCtx, constant.AuditAuthRegistered, constant.AuditTargetUser, user.ID, nil, user)
+
+	// 3. Send verification link, the user is committed so a failure only asks for a resend
+	if err := uc.SendVerificationEmail(ctx, user); err != nil {
+		logger.ErrorContext(ctx, "verification email not queued", "error", err)
 	}
 
 	return user, nil
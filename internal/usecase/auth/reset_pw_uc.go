@@ -4,31 +4,67 @@ import (
 	"context"
 
 	"go-app/internal/domain/entity"
+	"go-app/internal/domain/event"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/domain/repository"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/internal/usecase/audit"
+	"go-app/internal/usecase/outbox"
 	"go-app/pkg/errors"
 )
 
-// ResetPassword is function used to reset password
+// ResetPassword is function used to reset password, the password is changed and the token
+// revoked in one transaction so the token can not be reused after a failure, every session is then revoked
 func (uc *Usecase) ResetPassword(ctx context.Context, token, pw string) error {
-	email, err := uc.pwRepo.FindEmailByToken(ctx, token)
-	if err != nil {
-		return errors.Throw(err)
-	}
-	// Find user by email
-	userByEmail := entity.User{Email: email}
-	user, err := uc.repo.FindByQuery(ctx, userByEmail)
-	if err != nil {
-		return errors.Throw(err)
-	}
+	ctx, span := tracing.Start(ctx, "auth.ResetPassword")
+	defer span.End()
 
-	user.Password = pw
-	if err := uc.repo.Update(ctx, user); err != nil {
-		return errors.Throw(err)
-	}
+	var userID uint
+	err := uc.transactor.Transaction(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		email, err := repos.PasswordReset().FindEmailByToken(ctx, token)
+		if err != nil {
+			return errors.Throw(err)
+		}
+		// Find user by email
+		userByEmail := entity.User{Email: email}
+		user, err := repos.User().FindByQuery(ctx, userByEmail)
+		if err != nil {
+			return errors.Throw(err)
+		}
 
-	// Revoke token
-	if err := uc.pwRepo.Delete(ctx, email, token); err != nil {
-		return errors.Throw(err)
+		user.Password = pw
+		userID = user.ID
+		if err := repos.User().Update(ctx, user); err != nil {
+			return errors.Throw(err)
+		}
+
+		// Revoke token
+		if err := repos.PasswordReset().Delete(ctx, email, token); err != nil {
+			return errors.Throw(err)
+		}
+
+		if err := outbox.Enqueue(ctx, repos.Outbox(), event.PasswordReset{UserID: user.ID}); err != nil {
+			return errors.Throw(err)
+		}
+
+		return uc.auditUc.RecordWith(
+			audit.WithActorID(ctx, user.ID),
+			repos.Audit(),
+			constant.AuditAuthPasswordReset,
+			constant.AuditTargetUser,
+			user.ID,
+			nil,
+			nil,
+		)
+	})
+	if err != nil {
+		return tracing.Fail(span, err)
+	}
+	if err := uc.revokeSessions(ctx, userID, ""); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
 	}
+	uc.events.Record(gateway.AuthPasswordReset)
 
 	return nil
 }
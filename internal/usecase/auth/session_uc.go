@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/pkg/errors"
+)
+
+// sessionSeenPrefix is prefix of cache key used to throttle last seen writes
+const sessionSeenPrefix = "session_seen_"
+
+// FetchSessions is function used to list active sessions of user
+func (uc *Usecase) FetchSessions(ctx context.Context, userID uint) ([]entity.Session, error) {
+	ctx, span := tracing.Start(ctx, "auth.FetchSessions")
+	defer span.End()
+
+	since := time.Now().Add(-constant.RefreshTokenLifetime)
+	sessions, err := uc.sessionRepo.FetchActiveByUser(ctx, userID, since)
+	if err != nil {
+		return nil, tracing.Fail(span, errors.Throw(err))
+	}
+
+	return sessions, nil
+}
+
+// TouchSession is function used to check the session is active and record last seen
+func (uc *Usecase) TouchSession(ctx context.Context, sessionID, ip string) error {
+	ctx, span := tracing.Start(ctx, "auth.TouchSession")
+	defer span.End()
+
+	key := sessionSeenPrefix + sessionID
+	if _, err := uc.cm.Get(ctx, key); err == nil {
+		return nil
+	}
+
+	active, err := uc.sessionRepo.Touch(ctx, sessionID, ip)
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	if !active {
+		return tracing.Fail(span, errors.ErrJWTRevoke.Trace())
+	}
+
+	if err := uc.cm.Set(ctx, key, true, constant.SessionTouchInterval); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	return nil
+}
+
+// RevokeSession is function used to sign out a session of user
+func (uc *Usecase) RevokeSession(ctx context.Context, userID uint, sessionID string) error {
+	ctx, span := tracing.Start(ctx, "auth.RevokeSession")
+	defer span.End()
+
+	ss, err := uc.sessionRepo.Find(ctx, sessionID)
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	if ss.UserID != userID {
+		return tracing.Fail(span, errors.ErrNotFound.Trace())
+	}
+
+	if err := uc.revokeSession(ctx, ss.ID); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	uc.auditUc.Record(ctx, constant.AuditAuthSessionRevoked, constant.AuditTargetSession, ss.ID, nil, nil)
+
+	return nil
+}
+
+// RevokeAllSessions is function used to sign out user everywhere
+func (uc *Usecase) RevokeAllSessions(ctx context.Context, userID uint) error {
+	ctx, span := tracing.Start(ctx, "auth.RevokeAllSessions")
+	defer span.End()
+
+	if err := uc.revokeSessions(ctx, userID, ""); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	uc.auditUc.Record(ctx, constant.AuditAuthSessionsRevoked, constant.AuditTargetUser, userID, nil, nil)
+
+	return nil
+}
+
+// revokeSessions revokes every active session of user but the session keepID, empty to revoke them all
+func (uc *Usecase) revokeSessions(ctx context.Context, userID uint, keepID string) error {
+	sessions, err := uc.sessionRepo.FetchActiveByUser(ctx, userID, time.Time{})
+	if err != nil {
+		return errors.Throw(err)
+	}
+
+	for i := range sessions {
+		if sessions[i].ID == keepID {
+			continue
+		}
+		if err := uc.revokeSession(ctx, sessions[i].ID); err != nil {
+			return errors.Throw(err)
+		}
+	}
+
+	return nil
+}
+
+// revokeSession revokes the session, its refresh tokens and its access tokens
+func (uc *Usecase) revokeSession(ctx context.Context, sessionID string) error {
+	if err := uc.sessionRepo.Revoke(ctx, sessionID); err != nil {
+		return errors.Throw(err)
+	}
+
+	if err := uc.rtRepo.RevokeFamily(ctx, sessionID); err != nil {
+		return errors.Throw(err)
+	}
+
+	if err := uc.jwtSvc.InvalidateSession(ctx, sessionID); err != nil {
+		return errors.Throw(err)
+	}
+
+	if err := uc.cm.Del(ctx, sessionSeenPrefix+sessionID); err != nil {
+		return errors.Throw(err)
+	}
+	uc.events.Record(gateway.AuthSessionRevoked)
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/pkg/errors"
+)
+
+func TestRevokeSession(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	expectedResults := []struct {
+		userID    uint
+		sessionID string
+		err       error
+		revoked   bool
+	}{
+		{1, "s1", nil, true},
+		// A session of another user is not found, as an unknown one
+		{2, "s1", errors.ErrNotFound.Trace(), false},
+		{1, "s9", errors.ErrNotFound.Trace(), false},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		f := newFixture(t)
+		if err := f.rtRepo.Store(ctx, &entity.RefreshToken{
+			UserID: 1, FamilyID: "s1", Token: "rt-1", ExpiresAt: time.Now().Add(time.Hour),
+		}); err != nil {
+			t.Fatal(err)
+		}
+		access := f.accessToken(t, "s1")
+
+		err := f.uc.RevokeSession(ctx, testExpected.userID, testExpected.sessionID)
+		if !errors.Is(err, testExpected.err) || (err == nil) != (testExpected.err == nil) {
+			t.Errorf("#%d got %v, %v expected", testNumber, err, testExpected.err)
+		}
+		if f.sessionRepo.revoked("s1") != testExpected.revoked || f.sessionRepo.revoked("s2") {
+			t.Errorf("#%d got the session s1 revoked %v, %v expected", testNumber,
+				f.sessionRepo.revoked("s1"), testExpected.revoked)
+		}
+
+		// The access and the refresh tokens of the session are revoked with it
+		_, decodeErr := f.decode(access)
+		_, _, refreshErr := f.uc.Refresh(ctx, "rt-1", "10.0.0.1")
+		if testExpected.revoked != (decodeErr != nil) || testExpected.revoked != (refreshErr != nil) {
+			t.Errorf("#%d got %v %v using the tokens of the session", testNumber, decodeErr, refreshErr)
+		}
+	}
+}
+
+func TestRevokeAllSessions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	f := newFixture(t)
+	accesses := map[string]string{}
+	for _, id := range []string{"s1", "s2", "s3"} {
+		accesses[id] = f.accessToken(t, id)
+	}
+
+	if err := f.uc.RevokeAllSessions(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// The sessions of the other users are left active
+	expectedResults := []struct {
+		sessionID string
+		revoked   bool
+	}{
+		{"s1", true},
+		{"s2", true},
+		{"s3", false},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		if f.sessionRepo.revoked(testExpected.sessionID) != testExpected.revoked {
+			t.Errorf("#%d got the session %s revoked %v, %v expected", testNumber, testExpected.sessionID,
+				!testExpected.revoked, testExpected.revoked)
+		}
+		_, err := f.decode(accesses[testExpected.sessionID])
+		if testExpected.revoked != errors.Is(err, errors.ErrJWTRevoke.Trace()) {
+			t.Errorf("#%d got %v decoding the access token of %s", testNumber, err, testExpected.sessionID)
+		}
+	}
+
+	sessions, err := f.uc.FetchSessions(ctx, 1)
+	if err != nil || len(sessions) != 0 {
+		t.Errorf("got %v %v, no active session expected", sessions, err)
+	}
+}
+
+func TestTouchSession(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	f := newFixture(t)
+
+	expectedResults := []struct {
+		sessionID string
+		err       error
+	}{
+		{"s1", nil},
+		{"s4", errors.ErrJWTRevoke.Trace()},
+		{"s9", errors.ErrJWTRevoke.Trace()},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		err := f.uc.TouchSession(ctx, testExpected.sessionID, "10.0.0.1")
+		if !errors.Is(err, testExpected.err) || (err == nil) != (testExpected.err == nil) {
+			t.Errorf("#%d got %v, %v expected", testNumber, err, testExpected.err)
+		}
+	}
+	if ss := f.sessionRepo.sessions["s1"]; ss.IP != "10.0.0.1" {
+		t.Errorf("got the session %+v, the ip of the touch expected", ss)
+	}
+
+	// The last seen write is throttled, a revocation ends the throttle so the session is refused at once
+	if err := f.uc.TouchSession(ctx, "s1", "10.0.0.2"); err != nil || f.sessionRepo.sessions["s1"].IP != "10.0.0.1" {
+		t.Errorf("got %v %+v, the throttled touch expected", err, f.sessionRepo.sessions["s1"])
+	}
+	if err := f.uc.RevokeSession(ctx, 1, "s1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.uc.TouchSession(ctx, "s1", "10.0.0.1"); !errors.Is(err, errors.ErrJWTRevoke.Trace()) {
+		t.Errorf("got %v touching a revoked session, ErrJWTRevoke expected", err)
+	}
+}
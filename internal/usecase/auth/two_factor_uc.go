@@ -0,0 +1,232 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/pkg/errors"
+	"go-app/pkg/totp"
+	"go-app/pkg/utils"
+)
+
+const (
+	// twoFactorChallengePrefix is prefix of cache key for pending challenges
+	twoFactorChallengePrefix = "two_factor_challenge_"
+	// twoFactorUsedPrefix is prefix of cache key for codes already used
+	twoFactorUsedPrefix = "two_factor_used_"
+)
+
+// SetupTwoFactor is function used to generate a new secret waiting for confirmation
+func (uc *Usecase) SetupTwoFactor(ctx context.Context, userID uint) (string, error) {
+	ctx, span := tracing.Start(ctx, "auth.SetupTwoFactor")
+	defer span.End()
+
+	user, err := uc.repo.FindCredentials(ctx, userID)
+	if err != nil {
+		return "", tracing.Fail(span, errors.Throw(err))
+	}
+	if user.TwoFactorConfirmedAt != nil {
+		return "", tracing.Fail(span, errors.ErrTwoFactorAlreadyEnabled.Trace())
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", tracing.Fail(span, errors.ErrInternalServerError.Wrap(err))
+	}
+
+	user.TwoFactorSecret = secret
+	user.TwoFactorRecoveryCodes = nil
+	if err := uc.repo.UpdateTwoFactor(ctx, user); err != nil {
+		return "", tracing.Fail(span, errors.Throw(err))
+	}
+
+	return secret, nil
+}
+
+// ConfirmTwoFactor is function used to enable two factor with a code from the app,
+// it returns the recovery codes which are shown only once
+func (uc *Usecase) ConfirmTwoFactor(ctx context.Context, userID uint, code string) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "auth.ConfirmTwoFactor")
+	defer span.End()
+
+	user, err := uc.repo.FindCredentials(ctx, userID)
+	if err != nil {
+		return nil, tracing.Fail(span, errors.Throw(err))
+	}
+	if user.TwoFactorConfirmedAt != nil {
+		return nil, tracing.Fail(span, errors.ErrTwoFactorAlreadyEnabled.Trace())
+	}
+	if user.TwoFactorSecret == "" {
+		return nil, tracing.Fail(span, errors.ErrTwoFactorNotSetup.Trace())
+	}
+
+	if !totp.Validate(user.TwoFactorSecret, code, time.Now()) {
+		return nil, tracing.Fail(span, errors.ErrTwoFactorInvalidCode.Trace())
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, tracing.Fail(span, errors.Throw(err))
+	}
+
+	now := time.Now()
+	user.TwoFactorConfirmedAt = &now
+	user.TwoFactorRecoveryCodes = hashes
+	if err := uc.repo.UpdateTwoFactor(ctx, user); err != nil {
+		return nil, tracing.Fail(span, errors.Throw(err))
+	}
+	uc.auditUc.Record(ctx, constant.AuditAuthTwoFactorEnabled, constant.AuditTargetUser, user.ID, nil, nil)
+
+	return codes, nil
+}
+
+// VerifyTwoFactor is function used to complete a login with a code or a recovery code
+func (uc *Usecase) VerifyTwoFactor(
+	ctx context.Context,
+	token, code string,
+) (*entity.User, *entity.AuthToken, error) {
+	ctx, span := tracing.Start(ctx, "auth.VerifyTwoFactor")
+	defer span.End()
+
+	key := twoFactorChallengePrefix + utils.SHA256Hash(token)
+	b, err := uc.cm.Get(ctx, key)
+	if err != nil {
+		return nil, nil, tracing.Fail(span, errors.ErrTwoFactorChallengeInvalid.Trace())
+	}
+	challenge := entity.TwoFactorChallenge{}
+	if err := json.Unmarshal(b, &challenge); err != nil {
+		return nil, nil, tracing.Fail(span, errors.ErrTwoFactorChallengeInvalid.Wrap(err))
+	}
+
+	// Throttle attempts of the user
+	throttleKey := twoFactorThrottleKey(challenge.UserID)
+	if blocked, err := uc.throttleSvc.Blocked(ctx, throttleKey, challenge.Session.IP); err != nil {
+		return nil, nil, tracing.Fail(span, errors.Throw(err))
+	} else if blocked > 0 {
+		uc.events.Record(gateway.AuthLoginBlocked)
+		return nil, nil, tracing.Fail(span, errors.ErrAuthThrottleLogin.Trace())
+	}
+
+	user, err := uc.repo.FindCredentials(ctx, challenge.UserID)
+	if err != nil {
+		return nil, nil, tracing.Fail(span, errors.Throw(err))
+	}
+
+	valid, err := uc.verifyTwoFactorCode(ctx, user, code)
+	if err != nil {
+		return nil, nil, tracing.Fail(span, errors.Throw(err))
+	}
+	if !valid {
+		uc.failLogin(ctx, user, throttleKey, challenge.Session.IP)
+		return nil, nil, tracing.Fail(span, errors.ErrTwoFactorInvalidCode.Trace())
+	}
+
+	// The challenge can be used only once
+	if err := uc.cm.Del(ctx, key); err != nil {
+		return nil, nil, tracing.Fail(span, errors.Throw(err))
+	}
+	if err := uc.throttleSvc.Clear(ctx, throttleKey); err != nil {
+		return nil, nil, tracing.Fail(span, errors.Throw(err))
+	}
+
+	authToken, err := uc.startSession(ctx, user, &challenge.Session)
+	if err != nil {
+		return nil, nil, tracing.Fail(span, errors.Throw(err))
+	}
+
+	return user, authToken, nil
+}
+
+// createTwoFactorChallenge stores a pending login and returns its token
+func (uc *Usecase) createTwoFactorChallenge(
+	ctx context.Context,
+	user *entity.User,
+	ss *entity.Session,
+) (*entity.TwoFactorChallenge, error) {
+	token, err := utils.RandString(constant.RefreshTokenLength)
+	if err != nil {
+		return nil, errors.ErrInternalServerError.Wrap(err)
+	}
+
+	challenge := &entity.TwoFactorChallenge{
+		Token:     token,
+		UserID:    user.ID,
+		Session:   *ss,
+		ExpiresAt: time.Now().Add(constant.TwoFactorChallengeLifetime).Unix(),
+	}
+	b, err := json.Marshal(challenge)
+	if err != nil {
+		return nil, errors.ErrInternalServerError.Wrap(err)
+	}
+
+	key := twoFactorChallengePrefix + utils.SHA256Hash(token)
+	if err := uc.cm.Set(ctx, key, b, constant.TwoFactorChallengeLifetime); err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	return challenge, nil
+}
+
+// verifyTwoFactorCode checks a TOTP code, falling back to consuming a recovery code
+func (uc *Usecase) verifyTwoFactorCode(ctx context.Context, user *entity.User, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+
+	if totp.Validate(user.TwoFactorSecret, code, time.Now()) {
+		// Reject a code replayed within its validity window, only the first of concurrent logins marks it
+		usedKey := fmt.Sprintf("%s%d_%s", twoFactorUsedPrefix, user.ID, code)
+		fresh, err := uc.cm.SetNX(ctx, usedKey, true, constant.TwoFactorCodeUsedDuration)
+		if err != nil {
+			return false, errors.Throw(err)
+		}
+
+		return fresh, nil
+	}
+
+	hash := utils.SHA256Hash(code)
+	if !slices.Contains(user.TwoFactorRecoveryCodes, hash) {
+		return false, nil
+	}
+
+	// Only the first of concurrent logins consumes the code
+	consumed, err := uc.repo.ConsumeRecoveryCode(ctx, user.ID, hash)
+	if err != nil {
+		return false, errors.Throw(err)
+	}
+	if consumed {
+		user.TwoFactorRecoveryCodes = slices.DeleteFunc(slices.Clone(user.TwoFactorRecoveryCodes), func(h string) bool {
+			return h == hash
+		})
+	}
+
+	return consumed, nil
+}
+
+// generateRecoveryCodes returns recovery codes and their hashes
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, constant.TwoFactorRecoveryCodeCount)
+	hashes := make([]string, 0, constant.TwoFactorRecoveryCodeCount)
+	for range constant.TwoFactorRecoveryCodeCount {
+		code, err := utils.RandString(constant.TwoFactorRecoveryCodeLength)
+		if err != nil {
+			return nil, nil, errors.ErrInternalServerError.Wrap(err)
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, utils.SHA256Hash(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// twoFactorThrottleKey is the throttled account of the codes of the user, it is apart from the email so a valid
+// password does not reset the failed codes
+func twoFactorThrottleKey(userID uint) string {
+	return fmt.Sprintf("two_factor_%d", userID)
+}
@@ -0,0 +1,57 @@
+package auth_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/usecase/auth"
+	"go-app/pkg/totp"
+	"go-app/pkg/utils"
+)
+
+func TestVerifyTwoFactorCodeOnce(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := totp.GenerateCode(secret, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := newFixture(t)
+	f.userRepo.recoveryCodes = []string{utils.SHA256Hash("recovery-1"), utils.SHA256Hash("recovery-2")}
+
+	// Concurrent logins with the same code, only one of them is let in
+	for _, c := range []string{code, "recovery-1"} {
+		var valid atomic.Int32
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Go(func() {
+				user := &entity.User{ID: 1, TwoFactorSecret: secret, TwoFactorRecoveryCodes: []string{
+					utils.SHA256Hash("recovery-1"), utils.SHA256Hash("recovery-2"),
+				}}
+				ok, err := auth.VerifyTwoFactorCode(f.uc, ctx, user, c)
+				if err != nil {
+					t.Error(err)
+				}
+				if ok {
+					valid.Add(1)
+				}
+			})
+		}
+		wg.Wait()
+		if valid.Load() != 1 {
+			t.Errorf("got the code %s accepted %d times, once expected", c, valid.Load())
+		}
+	}
+
+	if len(f.userRepo.recoveryCodes) != 1 || f.userRepo.recoveryCodes[0] != utils.SHA256Hash("recovery-2") {
+		t.Errorf("got the recovery codes %v, the unused one expected", f.userRepo.recoveryCodes)
+	}
+}
@@ -3,34 +3,72 @@ package auth
 import (
 	"go-app/internal/domain/gateway"
 	"go-app/internal/domain/repository"
+	"go-app/internal/usecase/audit"
 )
 
 var (
 	tokenLength = 10
 )
 
+// Policy is the configurable behaviour of auth flows
+type Policy struct {
+	// DefaultRoleID is the role given to registered users
+	DefaultRoleID uint
+	// EmailVerification is one of constant.EmailVerification*
+	EmailVerification string
+	// VerifyURL is the page the verification link points to
+	VerifyURL string
+}
+
 // Usecase ...
 type Usecase struct {
+	policy      Policy
 	jwtSvc      gateway.JWTService
 	throttleSvc gateway.ThrottleService
-	mailSvc     gateway.MailService
+	jobQueue    gateway.JobQueue
+	signSvc     gateway.SignatureService
+	cm          gateway.Cache
+	events      gateway.AuthEvents
+	transactor  repository.Transactor
 	repo        repository.UserRepository
 	pwRepo      repository.PasswordResetRepository
+	rtRepo      repository.RefreshTokenRepository
+	sessionRepo repository.SessionRepository
+	permRepo    repository.PermissionRepository
+	auditUc     *audit.Usecase
 }
 
 // NewUsecase will create new an userUsecase object representation of domain.Usecase interface
 func NewUsecase(
+	policy Policy,
 	jwtSvc gateway.JWTService,
 	throttleSvc gateway.ThrottleService,
-	mailSvc gateway.MailService,
+	jobQueue gateway.JobQueue,
+	signSvc gateway.SignatureService,
+	cm gateway.Cache,
+	events gateway.AuthEvents,
+	transactor repository.Transactor,
 	repo repository.UserRepository,
 	pwRepo repository.PasswordResetRepository,
+	rtRepo repository.RefreshTokenRepository,
+	sessionRepo repository.SessionRepository,
+	permRepo repository.PermissionRepository,
+	auditUc *audit.Usecase,
 ) *Usecase {
 	return &Usecase{
+		policy:      policy,
 		jwtSvc:      jwtSvc,
 		throttleSvc: throttleSvc,
-		mailSvc:     mailSvc,
+		jobQueue:    jobQueue,
+		signSvc:     signSvc,
+		cm:          cm,
+		events:      events,
+		transactor:  transactor,
 		repo:        repo,
 		pwRepo:      pwRepo,
+		rtRepo:      rtRepo,
+		sessionRepo: sessionRepo,
+		permRepo:    permRepo,
+		auditUc:     auditUc,
 	}
 }
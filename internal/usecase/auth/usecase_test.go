@@ -0,0 +1,278 @@
+package auth_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-app/internal/adapter/gateway/cache"
+	"go-app/internal/adapter/gateway/service"
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/domain/repository"
+	"go-app/internal/infrastructure/config"
+	"go-app/internal/usecase/audit"
+	"go-app/internal/usecase/auth"
+	"go-app/pkg/errors"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// fakeRefreshTokenRepository keeps the refresh tokens in memory, the id of a token is its index plus one
+type fakeRefreshTokenRepository struct {
+	repository.RefreshTokenRepository
+
+	tokens []*entity.RefreshToken
+}
+
+func (rp *fakeRefreshTokenRepository) Store(_ context.Context, rt *entity.RefreshToken) error {
+	rt.ID = uint(len(rp.tokens) + 1)
+	stored := *rt
+	rp.tokens = append(rp.tokens, &stored)
+
+	return nil
+}
+
+func (rp *fakeRefreshTokenRepository) FindByToken(_ context.Context, token string) (*entity.RefreshToken, error) {
+	for _, rt := range rp.tokens {
+		if rt.Token == token {
+			found := *rt
+			return &found, nil
+		}
+	}
+
+	return nil, errors.ErrAuthRefreshTokenInvalid.Trace()
+}
+
+func (rp *fakeRefreshTokenRepository) Revoke(_ context.Context, id uint) (bool, error) {
+	rt := rp.tokens[id-1]
+	if rt.RevokedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	rt.RevokedAt = &now
+
+	return true, nil
+}
+
+func (rp *fakeRefreshTokenRepository) RevokeFamily(_ context.Context, familyID string) error {
+	now := time.Now()
+	for _, rt := range rp.tokens {
+		if rt.FamilyID == familyID && rt.RevokedAt == nil {
+			rt.RevokedAt = &now
+		}
+	}
+
+	return nil
+}
+
+// family returns the tokens of the family
+func (rp *fakeRefreshTokenRepository) family(familyID string) []*entity.RefreshToken {
+	items := []*entity.RefreshToken{}
+	for _, rt := range rp.tokens {
+		if rt.FamilyID == familyID {
+			items = append(items, rt)
+		}
+	}
+
+	return items
+}
+
+// fakeSessionRepository keeps the sessions in memory
+type fakeSessionRepository struct {
+	repository.SessionRepository
+
+	sessions map[string]*entity.Session
+}
+
+func (rp *fakeSessionRepository) FetchActiveByUser(
+	_ context.Context,
+	userID uint,
+	since time.Time,
+) ([]entity.Session, error) {
+	items := []entity.Session{}
+	for _, ss := range rp.sessions {
+		if ss.UserID == userID && ss.RevokedAt == nil && !ss.LastSeenAt.Before(since) {
+			items = append(items, *ss)
+		}
+	}
+	slices.SortFunc(items, func(a, b entity.Session) int { return strings.Compare(a.ID, b.ID) })
+
+	return items, nil
+}
+
+func (rp *fakeSessionRepository) Find(_ context.Context, id string) (*entity.Session, error) {
+	ss, ok := rp.sessions[id]
+	if !ok {
+		return nil, errors.ErrNotFound.Trace()
+	}
+	found := *ss
+
+	return &found, nil
+}
+
+func (rp *fakeSessionRepository) Store(_ context.Context, ss *entity.Session) error {
+	stored := *ss
+	rp.sessions[ss.ID] = &stored
+
+	return nil
+}
+
+func (rp *fakeSessionRepository) Touch(_ context.Context, id, ip string) (bool, error) {
+	ss, ok := rp.sessions[id]
+	if !ok || ss.RevokedAt != nil {
+		return false, nil
+	}
+	ss.IP = ip
+	ss.LastSeenAt = time.Now()
+
+	return true, nil
+}
+
+func (rp *fakeSessionRepository) Revoke(_ context.Context, id string) error {
+	if ss, ok := rp.sessions[id]; ok && ss.RevokedAt == nil {
+		now := time.Now()
+		ss.RevokedAt = &now
+	}
+
+	return nil
+}
+
+// revoked reports whether the session is revoked
+func (rp *fakeSessionRepository) revoked(id string) bool {
+	return rp.sessions[id].RevokedAt != nil
+}
+
+// fakeUserRepository finds the users 1 and 2 and keeps the hashes of the recovery codes, consumed one at a time
+// as the conditional update does
+type fakeUserRepository struct {
+	repository.UserRepository
+
+	mu            sync.Mutex
+	recoveryCodes []string
+}
+
+func (rp *fakeUserRepository) ConsumeRecoveryCode(_ context.Context, _ uint, hash string) (bool, error) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	i := slices.Index(rp.recoveryCodes, hash)
+	if i < 0 {
+		return false, nil
+	}
+	rp.recoveryCodes = slices.Delete(rp.recoveryCodes, i, i+1)
+
+	return true, nil
+}
+
+func (*fakeUserRepository) Find(_ context.Context, id uint) (*entity.User, error) {
+	if id != 1 && id != 2 {
+		return nil, errors.ErrNotFound.Trace()
+	}
+
+	return &entity.User{ID: id, Name: "user", Email: "user@example.com", RoleID: 1}, nil
+}
+
+// fakePermissionRepository grants no permission
+type fakePermissionRepository struct {
+	repository.PermissionRepository
+}
+
+func (fakePermissionRepository) FetchByRole(context.Context, uint) ([]entity.Permission, error) {
+	return nil, nil
+}
+
+// fakeAuditRepository discards the audit logs
+type fakeAuditRepository struct {
+	repository.AuditRepository
+}
+
+func (fakeAuditRepository) Store(context.Context, *entity.AuditLog) error {
+	return nil
+}
+
+// fakeAuthEvents keeps the recorded events
+type fakeAuthEvents struct {
+	events []string
+}
+
+func (ev *fakeAuthEvents) Record(event string) {
+	ev.events = append(ev.events, event)
+}
+
+// fixture is an auth usecase of the fakes, the session s1 and s2 belong to the user 1 and s3 to the user 2, the
+// session s4 of the user 1 is revoked
+type fixture struct {
+	uc          *auth.Usecase
+	keys        *service.JWTKeySet
+	jwtSvc      gateway.JWTService
+	userRepo    *fakeUserRepository
+	rtRepo      *fakeRefreshTokenRepository
+	sessionRepo *fakeSessionRepository
+	events      *fakeAuthEvents
+}
+
+// newFixture returns a new fixture
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	keys, err := service.NewJWTKeySet(config.JWT{}, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	f := &fixture{
+		keys:     keys,
+		jwtSvc:   service.NewJWTService(keys, cache.NewMemoryStore(0)),
+		userRepo: &fakeUserRepository{},
+		rtRepo:   &fakeRefreshTokenRepository{},
+		sessionRepo: &fakeSessionRepository{sessions: map[string]*entity.Session{
+			"s1": {ID: "s1", UserID: 1, LastSeenAt: now},
+			"s2": {ID: "s2", UserID: 1, LastSeenAt: now},
+			"s3": {ID: "s3", UserID: 2, LastSeenAt: now},
+			"s4": {ID: "s4", UserID: 1, LastSeenAt: now, RevokedAt: &now},
+		}},
+		events: &fakeAuthEvents{},
+	}
+	f.uc = auth.NewUsecase(
+		auth.Policy{},
+		f.jwtSvc,
+		nil,
+		nil,
+		nil,
+		cache.NewMemoryStore(0),
+		f.events,
+		nil,
+		f.userRepo,
+		nil,
+		f.rtRepo,
+		f.sessionRepo,
+		fakePermissionRepository{},
+		audit.NewUsecase(fakeAuditRepository{}),
+	)
+
+	return f
+}
+
+// accessToken returns an access token of the session
+func (f *fixture) accessToken(t *testing.T, sessionID string) string {
+	t.Helper()
+	ss := f.sessionRepo.sessions[sessionID]
+	token, _, err := f.jwtSvc.GenerateToken(context.Background(), &entity.User{ID: ss.UserID}, sessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
+// decode returns the user of the access token, an error once the token is revoked
+func (f *fixture) decode(raw string) (*entity.User, error) {
+	token, err := jwt.ParseWithClaims(raw, new(service.CustomClaims), f.keys.Keyfunc)
+	if err != nil {
+		return nil, errors.ErrUnauthenticated.Wrap(err)
+	}
+
+	return f.jwtSvc.Decode(context.Background(), token)
+}
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/event"
+	"go-app/internal/domain/repository"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/internal/usecase/audit"
+	"go-app/internal/usecase/job"
+	"go-app/internal/usecase/outbox"
+	"go-app/pkg/errors"
+	"go-app/pkg/i18n"
+)
+
+// VerifyEmail is function used to verify email with the signed link
+func (uc *Usecase) VerifyEmail(ctx context.Context, id uint, expires int64, signature string) error {
+	ctx, span := tracing.Start(ctx, "auth.VerifyEmail")
+	defer span.End()
+
+	if time.Now().Unix() > expires {
+		return tracing.Fail(span, errors.ErrAuthInvalidateSignature.Trace())
+	}
+
+	user, err := uc.repo.Find(ctx, id)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound.Trace()) {
+			return tracing.Fail(span, errors.ErrAuthInvalidateSignature.Wrap(err))
+		}
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	// The email is part of the payload so the link is invalid once the email changes
+	if !uc.signSvc.Verify(verificationPayload(user, expires), signature) {
+		return tracing.Fail(span, errors.ErrAuthInvalidateSignature.Trace())
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	err = uc.transactor.Transaction(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		if err := repos.User().VerifyEmail(ctx, user.ID); err != nil {
+			return errors.Throw(err)
+		}
+
+		return outbox.Enqueue(ctx, repos.Outbox(), event.EmailVerified{UserID: user.ID, Email: user.Email})
+	})
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	actorCtx := audit.WithActorID(ctx, user.ID)
+	uc.auditUc.Record(actorCtx, constant.AuditAuthEmailVerified, constant.AuditTargetUser, user.ID, nil, nil)
+
+	return nil
+}
+
+// ResendVerificationEmail is function used to send the verification link again,
+// unknown or verified emails are ignored to avoid leaking accounts
+func (uc *Usecase) ResendVerificationEmail(ctx context.Context, email string) error {
+	ctx, span := tracing.Start(ctx, "auth.ResendVerificationEmail")
+	defer span.End()
+
+	user, err := uc.repo.FindByQuery(ctx, entity.User{Email: email})
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound.Trace()) {
+			return nil
+		}
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	if err := uc.SendVerificationEmail(ctx, user); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	return nil
+}
+
+// SendVerificationEmail sends the signed verification link to the user
+func (uc *Usecase) SendVerificationEmail(ctx context.Context, user *entity.User) error {
+	expires := time.Now().Add(constant.EmailVerificationLifetime).Unix()
+
+	link, err := url.Parse(uc.policy.VerifyURL)
+	if err != nil {
+		return errors.ErrInternalServerError.Wrap(err)
+	}
+	q := link.Query()
+	q.Set("id", strconv.FormatUint(uint64(user.ID), 10))
+	q.Set("expires", strconv.FormatInt(expires, 10))
+	q.Set("signature", uc.signSvc.Sign(verificationPayload(user, expires)))
+	link.RawQuery = q.Encode()
+
+	// Send email from the worker
+	mail := job.SendMail{
+		Template: constant.MailTemplateVerifyEmail,
+		Locale:   i18n.Locale(ctx),
+		Data: map[string]any{
+			"Link":      link.String(),
+			"ExpiresIn": int(constant.EmailVerificationLifetime / time.Hour),
+		},
+		To: []string{user.Email},
+	}
+	if err := job.Dispatch(ctx, uc.jobQueue, mail); err != nil {
+		return errors.Throw(err)
+	}
+
+	return nil
+}
+
+// verificationPayload is the signed content of a verification link
+func verificationPayload(user *entity.User, expires int64) string {
+	return fmt.Sprintf("verify-email|%d|%s|%d", user.ID, user.Email, expires)
+}
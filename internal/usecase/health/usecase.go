@@ -0,0 +1,134 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/constant"
+	"go-app/pkg/errors"
+)
+
+// check is a registered health checker
+type check struct {
+	name     string
+	checker  gateway.HealthChecker
+	required bool
+}
+
+// Usecase is the registry of the health checks of the dependencies, gateways register their check with
+// Register, a required check down makes the application not ready
+type Usecase struct {
+	mu      sync.RWMutex
+	timeout time.Duration
+	checks  []check
+}
+
+// NewUsecase will create new an Usecase object, timeout bounds every check, zero falls back to the default
+func NewUsecase(timeout time.Duration) *Usecase {
+	if timeout <= 0 {
+		timeout = constant.HealthCheckTimeout
+	}
+
+	return &Usecase{
+		timeout: timeout,
+	}
+}
+
+// Register adds the check of the dependency name, a check registered again under the same name replaces it
+func (uc *Usecase) Register(name string, checker gateway.HealthChecker, required bool) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	for i := range uc.checks {
+		if uc.checks[i].name == name {
+			uc.checks[i] = check{name: name, checker: checker, required: required}
+			return
+		}
+	}
+	uc.checks = append(uc.checks, check{name: name, checker: checker, required: required})
+}
+
+// Live reports the process is serving, no dependency is checked so an outage of one does not restart it
+func (*Usecase) Live() entity.HealthReport {
+	return entity.HealthReport{
+		Status: constant.HealthStatusUp,
+		Checks: []entity.HealthCheck{},
+	}
+}
+
+// Ready runs every check concurrently, each bounded by the timeout, the checks are reported in the order
+// they were registered
+func (uc *Usecase) Ready(ctx context.Context) entity.HealthReport {
+	uc.mu.RLock()
+	checks := append([]check(nil), uc.checks...)
+	uc.mu.RUnlock()
+
+	results := make([]entity.HealthCheck, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Go(func() {
+			results[i] = uc.run(ctx, c)
+		})
+	}
+	wg.Wait()
+
+	report := entity.HealthReport{
+		Status: constant.HealthStatusUp,
+		Checks: results,
+	}
+	for _, res := range results {
+		if res.Status == constant.HealthStatusUp {
+			continue
+		}
+		if res.Required {
+			report.Status = constant.HealthStatusDown
+			break
+		}
+		report.Status = constant.HealthStatusDegraded
+	}
+
+	return report
+}
+
+// run checks the dependency within the timeout, a checker ignoring ctx is given up at the timeout
+func (uc *Usecase) run(ctx context.Context, c check) entity.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- c.checker.Check(ctx)
+	}()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	res := entity.HealthCheck{
+		Name:     c.name,
+		Status:   constant.HealthStatusUp,
+		Required: c.required,
+		Latency:  time.Since(start),
+	}
+	if err != nil {
+		res.Status = constant.HealthStatusDown
+		res.Error = describe(err)
+	}
+
+	return res
+}
+
+// describe returns the message of err followed by its cause
+func describe(err error) string {
+	var be *errors.BaseError
+	if errors.As(err, &be) && be.Unwrap() != nil {
+		return be.Message + " " + be.Unwrap().Error()
+	}
+
+	return err.Error()
+}
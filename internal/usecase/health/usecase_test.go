@@ -0,0 +1,101 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/usecase/health"
+	pkgerrors "go-app/pkg/errors"
+)
+
+var (
+	up   = gateway.HealthCheckFunc(func(context.Context) error { return nil })
+	down = gateway.HealthCheckFunc(func(context.Context) error {
+		return pkgerrors.ErrRedisConnection.Wrap(errors.New("connection refused"))
+	})
+)
+
+func TestReady(t *testing.T) {
+	t.Parallel()
+
+	type registration struct {
+		checker  gateway.HealthChecker
+		required bool
+	}
+	expectedResults := []struct {
+		checks   []registration
+		status   string
+		statuses []string
+	}{
+		{nil, constant.HealthStatusUp, []string{}},
+		{[]registration{{up, true}, {up, false}}, constant.HealthStatusUp, []string{"up", "up"}},
+		// An optional dependency down degrades the application
+		{[]registration{{up, true}, {down, false}}, constant.HealthStatusDegraded, []string{"up", "down"}},
+		// A required dependency down makes it not ready, whatever the optional ones are
+		{[]registration{{down, false}, {down, true}, {up, false}}, constant.HealthStatusDown, []string{"down", "down", "up"}},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		uc := health.NewUsecase(time.Second)
+		for i, c := range testExpected.checks {
+			uc.Register(string(rune('a'+i)), c.checker, c.required)
+		}
+
+		report := uc.Ready(context.Background())
+		if report.Status != testExpected.status || len(report.Checks) != len(testExpected.statuses) {
+			t.Errorf("#%d got %+v, %s expected", testNumber, report, testExpected.status)
+			continue
+		}
+		for i, c := range report.Checks {
+			if c.Name != string(rune('a'+i)) || c.Status != testExpected.statuses[i] ||
+				c.Required != testExpected.checks[i].required {
+				t.Errorf("#%d got the check %+v, %s expected", testNumber, c, testExpected.statuses[i])
+			}
+			if c.Status == constant.HealthStatusDown && c.Error != pkgerrors.ErrRedisConnection.Message+" connection refused" {
+				t.Errorf("#%d got the error %q", testNumber, c.Error)
+			}
+		}
+	}
+}
+
+func TestReadyRegisterReplaces(t *testing.T) {
+	t.Parallel()
+	uc := health.NewUsecase(time.Second)
+	uc.Register("redis", down, true)
+	uc.Register("redis", up, false)
+
+	if report := uc.Ready(context.Background()); report.Status != constant.HealthStatusUp || len(report.Checks) != 1 {
+		t.Errorf("got %+v, the check replaced expected", report)
+	}
+}
+
+func TestReadyTimeout(t *testing.T) {
+	t.Parallel()
+	const timeout = time.Millisecond * 50
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	// The checker ignores ctx and hangs until the test ends
+	hung := gateway.HealthCheckFunc(func(context.Context) error {
+		<-release
+		return nil
+	})
+	uc := health.NewUsecase(timeout)
+	uc.Register("smtp", hung, false)
+	uc.Register("postgres", up, true)
+
+	start := time.Now()
+	report := uc.Ready(context.Background())
+	if elapsed := time.Since(start); elapsed > timeout*4 {
+		t.Errorf("got the report after %v, the timeout %v expected", elapsed, timeout)
+	}
+	c := report.Checks[0]
+	if report.Status != constant.HealthStatusDegraded || c.Status != constant.HealthStatusDown ||
+		!strings.Contains(c.Error, context.DeadlineExceeded.Error()) || c.Latency < timeout {
+		t.Errorf("got %+v, the hung check down at the timeout expected", report)
+	}
+}
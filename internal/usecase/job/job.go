@@ -0,0 +1,68 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/pkg/errors"
+)
+
+// Payload is the typed content of a job, JobType routes it to its handler
+type Payload interface {
+	JobType() string
+}
+
+// Option customizes a dispatched job
+type Option func(job *gateway.Job)
+
+// Delay runs the job after d
+func Delay(d time.Duration) Option {
+	return func(job *gateway.Job) {
+		job.RunAt = time.Now().Add(d)
+	}
+}
+
+// MaxAttempts overrides the max attempts of the worker for the job
+func MaxAttempts(n int) Option {
+	return func(job *gateway.Job) {
+		job.MaxAttempts = n
+	}
+}
+
+// Dispatch will push the payload to the queue to be handled by a worker, the job continues the trace of ctx
+func Dispatch(ctx context.Context, queue gateway.JobQueue, payload Payload, opts ...Option) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return errors.ErrInternalServerError.Wrap(err)
+	}
+
+	job := &gateway.Job{
+		Type:    payload.JobType(),
+		Payload: data,
+		Trace:   tracing.Inject(ctx),
+	}
+	for _, opt := range opts {
+		opt(job)
+	}
+	if err := queue.Enqueue(ctx, job); err != nil {
+		return errors.Throw(err)
+	}
+
+	return nil
+}
+
+// Handle registers a typed handler on the worker for the jobs of type T
+func Handle[T Payload](w *Worker, h func(ctx context.Context, payload T) error) {
+	var zero T
+	w.Register(zero.JobType(), func(ctx context.Context, job *gateway.Job) error {
+		var payload T
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return errors.ErrInternalServerError.Wrap(err)
+		}
+
+		return h(ctx, payload)
+	})
+}
@@ -0,0 +1,39 @@
+package job
+
+import (
+	"context"
+
+	"go-app/internal/domain/gateway"
+	"go-app/pkg/errors"
+)
+
+// SendMail is the job sending an email, the Template rendered in Locale with Data when it is set,
+// the plain Subject and Body otherwise
+type SendMail struct {
+	Template string         `json:"template,omitempty"`
+	Locale   string         `json:"locale,omitempty"`
+	Data     map[string]any `json:"data,omitempty"`
+	Subject  string         `json:"subject,omitempty"`
+	Body     string         `json:"body,omitempty"`
+	To       []string       `json:"to"`
+}
+
+// JobType .-
+func (SendMail) JobType() string { return "mail.send" }
+
+// SendMailHandler returns the handler sending the emails with mailSvc
+func SendMailHandler(mailSvc gateway.MailService) func(ctx context.Context, payload SendMail) error {
+	return func(ctx context.Context, payload SendMail) error {
+		var err error
+		if payload.Template != "" {
+			err = mailSvc.SendTemplate(ctx, payload.Template, payload.Locale, payload.Data, payload.To)
+		} else {
+			err = mailSvc.Send(ctx, payload.Subject, payload.Body, payload.To)
+		}
+		if err != nil {
+			return errors.Throw(err)
+		}
+
+		return nil
+	}
+}
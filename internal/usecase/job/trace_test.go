@@ -0,0 +1,94 @@
+package job_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-app/internal/adapter/gateway/queue"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/config"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/internal/usecase/job"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The carrier of a job crosses Redis, the attempts continue the trace of the span which dispatched the job
+func TestWorkerContinuesTrace(t *testing.T) {
+	if _, err := tracing.Setup(context.Background(), config.Tracing{}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	srv := miniredis.RunT(t)
+	rd := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { _ = rd.Close() })
+	q := &recordingQueue{
+		JobQueue: queue.NewRedisQueue(rd, "trace", time.Minute, 0),
+		acked:    make(chan gateway.Job, 10),
+		dead:     make(chan gateway.Job, 10),
+	}
+	w := job.NewWorker(job.Policy{Concurrency: 1, RetryBackoff: backoff}, q)
+	handled := make(chan trace.SpanContext, 2)
+	var calls atomic.Int32
+	job.Handle(w, func(ctx context.Context, p ping) error {
+		handled <- trace.SpanContextFromContext(ctx)
+		if calls.Add(1) == 1 && p.Name == "traced" {
+			return errors.New("timeout")
+		}
+		return nil
+	})
+	run(t, w)
+
+	ctx, parent := tracing.Start(context.Background(), "dispatch")
+	if err := job.Dispatch(ctx, q, ping{Name: "traced"}); err != nil {
+		t.Fatal(err)
+	}
+	parent.End()
+	acked := receive(t, q.acked)
+	if acked.Trace["traceparent"] == "" {
+		t.Fatalf("got the carrier %v, a traceparent expected", acked.Trace)
+	}
+
+	// Each attempt is a consumer span child of the dispatching span, the failed one records its error
+	expectedResults := []codes.Code{codes.Error, codes.Unset}
+	attempts := []sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		if span.Name() == "job test.ping" && span.Parent().TraceID() == parent.SpanContext().TraceID() {
+			attempts = append(attempts, span)
+		}
+	}
+	if len(attempts) != len(expectedResults) {
+		t.Fatalf("got %d spans of the job, %d expected", len(attempts), len(expectedResults))
+	}
+	for testNumber, testExpected := range expectedResults {
+		span := attempts[testNumber]
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() || !span.Parent().IsRemote() ||
+			span.SpanKind() != trace.SpanKindConsumer || span.Status().Code != testExpected {
+			t.Errorf("#%d got the span %v of parent %v status %v, a consumer child of %v with %v expected",
+				testNumber, span.SpanContext().SpanID(), span.Parent().SpanID(), span.Status(),
+				parent.SpanContext().SpanID(), testExpected)
+		}
+		if got := <-handled; got.SpanID() != span.SpanContext().SpanID() {
+			t.Errorf("#%d got the handler in the span %v, %v expected", testNumber, got.SpanID(), span.SpanContext().SpanID())
+		}
+	}
+
+	// A job dispatched without a span carries no trace
+	if err := job.Dispatch(context.Background(), q, ping{}); err != nil {
+		t.Fatal(err)
+	}
+	if acked := receive(t, q.acked); acked.Trace != nil {
+		t.Errorf("got the carrier %v, none expected", acked.Trace)
+	}
+}
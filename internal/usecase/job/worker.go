@@ -0,0 +1,151 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go-app/internal/domain/gateway"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/pkg/logger"
+	"go-app/pkg/utils"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Handler processes a job, a returned error schedules a retry
+type Handler func(ctx context.Context, job *gateway.Job) error
+
+// Policy of the worker, zero values fall back to the constant defaults
+type Policy struct {
+	Concurrency  int
+	MaxAttempts  int
+	RetryBackoff time.Duration
+	Timeout      time.Duration
+}
+
+// Worker reserves the jobs of the queue and runs their handler
+type Worker struct {
+	policy   Policy
+	queue    gateway.JobQueue
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+// NewWorker will create new a Worker of the queue
+func NewWorker(policy Policy, queue gateway.JobQueue) *Worker {
+	if policy.Concurrency <= 0 {
+		policy.Concurrency = constant.JobConcurrency
+	}
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = constant.JobMaxAttempts
+	}
+	if policy.RetryBackoff <= 0 {
+		policy.RetryBackoff = constant.JobRetryBackoff
+	}
+	if policy.Timeout <= 0 {
+		policy.Timeout = constant.JobTimeout
+	}
+
+	return &Worker{
+		policy:   policy,
+		queue:    queue,
+		handlers: map[string]Handler{},
+	}
+}
+
+// Register sets the handler of the jobs of jobType
+func (w *Worker) Register(jobType string, h Handler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.handlers[jobType] = h
+}
+
+// Run will process the jobs until ctx is done, then it waits for the jobs in progress to finish
+func (w *Worker) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for range w.policy.Concurrency {
+		wg.Go(func() {
+			for ctx.Err() == nil {
+				job, err := w.queue.Reserve(ctx, constant.JobReserveWait)
+				if err != nil {
+					if ctx.Err() == nil {
+						logger.ErrorContext(ctx, "job reserve", "error", err)
+						time.Sleep(constant.JobReserveWait)
+					}
+					continue
+				}
+				if job != nil {
+					w.process(context.WithoutCancel(ctx), job)
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
+
+// process runs the handler of the job and acknowledges, retries or fails it, the span of the attempt is a child
+// of the span which dispatched the job
+func (w *Worker) process(ctx context.Context, job *gateway.Job) {
+	ctx = logger.WithRequestID(ctx, job.ID)
+	ctx, span := tracing.Start(tracing.Extract(ctx, job.Trace), "job "+job.Type,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("job.id", job.ID),
+			attribute.String("job.type", job.Type),
+			attribute.Int("job.attempt", job.Attempts+1),
+		),
+	)
+	err := w.call(ctx, job)
+	tracing.End(span, err)
+	if err == nil {
+		if err := w.queue.Ack(ctx, job); err != nil {
+			logger.ErrorContext(ctx, "job ack", "type", job.Type, "error", err)
+		}
+		return
+	}
+
+	job.Attempts++
+	job.LastError = err.Error()
+	maxAttempts := w.policy.MaxAttempts
+	if job.MaxAttempts > 0 {
+		maxAttempts = job.MaxAttempts
+	}
+	if job.Attempts >= maxAttempts {
+		logger.ErrorContext(ctx, "job dead", "type", job.Type, "attempts", job.Attempts, "error", err)
+		if err := w.queue.Fail(ctx, job); err != nil {
+			logger.ErrorContext(ctx, "job fail", "type", job.Type, "error", err)
+		}
+		return
+	}
+
+	logger.WarnContext(ctx, "job retry", "type", job.Type, "attempts", job.Attempts, "error", err)
+	runAt := time.Now().Add(utils.Backoff(w.policy.RetryBackoff, constant.JobMaxRetryBackoff, job.Attempts))
+	if err := w.queue.Retry(ctx, job, runAt); err != nil {
+		logger.ErrorContext(ctx, "job retry", "type", job.Type, "error", err)
+	}
+}
+
+// call runs the handler with the timeout of the policy, a panic or an unknown type is an error
+func (w *Worker) call(ctx context.Context, job *gateway.Job) (err error) {
+	w.mu.RLock()
+	h, ok := w.handlers[job.Type]
+	w.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("no handler for job type %q", job.Type)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job handler panic: %v", r)
+		}
+	}()
+	ctx, cancel := context.WithTimeout(ctx, w.policy.Timeout)
+	defer cancel()
+
+	return h(ctx, job)
+}
@@ -0,0 +1,240 @@
+package job_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-app/internal/adapter/gateway/queue"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/usecase/job"
+)
+
+const backoff = time.Millisecond * 20
+
+// ping is the payload of the jobs of the tests
+type ping struct {
+	Name string `json:"name"`
+}
+
+func (ping) JobType() string { return "test.ping" }
+
+// recordingQueue is a memory queue recording the acknowledged, retried and failed jobs
+type recordingQueue struct {
+	gateway.JobQueue
+
+	mu      sync.Mutex
+	delays  []time.Duration
+	acked   chan gateway.Job
+	dead    chan gateway.Job
+	retried atomic.Int32
+}
+
+func newRecordingQueue() *recordingQueue {
+	return &recordingQueue{
+		JobQueue: queue.NewMemoryQueue(time.Minute, 0),
+		acked:    make(chan gateway.Job, 10),
+		dead:     make(chan gateway.Job, 10),
+	}
+}
+
+func (q *recordingQueue) Ack(ctx context.Context, j *gateway.Job) error {
+	q.acked <- *j
+
+	return q.JobQueue.Ack(ctx, j)
+}
+
+func (q *recordingQueue) Retry(ctx context.Context, j *gateway.Job, runAt time.Time) error {
+	q.mu.Lock()
+	q.delays = append(q.delays, time.Until(runAt))
+	q.mu.Unlock()
+	q.retried.Add(1)
+
+	return q.JobQueue.Retry(ctx, j, runAt)
+}
+
+func (q *recordingQueue) Fail(ctx context.Context, j *gateway.Job) error {
+	q.dead <- *j
+
+	return q.JobQueue.Fail(ctx, j)
+}
+
+// run runs the worker until the test ends
+func run(t *testing.T, w *job.Worker) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		w.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+// receive returns the next job of ch, it fails the test after 5 seconds
+func receive(t *testing.T, ch chan gateway.Job) gateway.Job {
+	t.Helper()
+	select {
+	case j := <-ch:
+		return j
+	case <-time.After(time.Second * 5):
+		t.Fatal("no job received")
+		return gateway.Job{}
+	}
+}
+
+func TestWorkerRetryAndDead(t *testing.T) {
+	t.Parallel()
+	q := newRecordingQueue()
+	w := job.NewWorker(job.Policy{Concurrency: 1, MaxAttempts: 3, RetryBackoff: backoff}, q)
+	var calls atomic.Int32
+	job.Handle(w, func(_ context.Context, _ ping) error {
+		calls.Add(1)
+		return errors.New("unreachable")
+	})
+	run(t, w)
+
+	if err := job.Dispatch(context.Background(), q, ping{Name: "dead"}); err != nil {
+		t.Fatal(err)
+	}
+	dead := receive(t, q.dead)
+	if dead.Attempts != 3 || dead.LastError != "unreachable" || calls.Load() != 3 {
+		t.Errorf("got %+v after %d calls, dead after 3 attempts expected", dead, calls.Load())
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	expectedResults := []time.Duration{backoff, backoff * 2}
+	if len(q.delays) != len(expectedResults) {
+		t.Fatalf("got %d retries, %d expected", len(q.delays), len(expectedResults))
+	}
+	for testNumber, testExpected := range expectedResults {
+		if got := q.delays[testNumber]; got > testExpected || got < testExpected-time.Millisecond*5 {
+			t.Errorf("#%d got a retry after %v, %v expected", testNumber, got, testExpected)
+		}
+	}
+}
+
+func TestWorkerMaxAttemptsOfJob(t *testing.T) {
+	t.Parallel()
+	q := newRecordingQueue()
+	w := job.NewWorker(job.Policy{Concurrency: 1, MaxAttempts: 5, RetryBackoff: backoff}, q)
+	w.Register("test.unknown", func(_ context.Context, _ *gateway.Job) error {
+		panic("broken handler")
+	})
+	run(t, w)
+
+	if err := job.Dispatch(context.Background(), q, ping{}, job.MaxAttempts(1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Enqueue(context.Background(), &gateway.Job{Type: "test.unknown", MaxAttempts: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedResults := map[string]string{
+		"test.ping":    `no handler for job type "test.ping"`,
+		"test.unknown": "job handler panic: broken handler",
+	}
+	for range expectedResults {
+		dead := receive(t, q.dead)
+		if dead.Attempts != 1 || dead.LastError != expectedResults[dead.Type] {
+			t.Errorf("got %+v, dead at once with %q expected", dead, expectedResults[dead.Type])
+		}
+	}
+	if retried := q.retried.Load(); retried != 0 {
+		t.Errorf("got %d retries, none expected", retried)
+	}
+}
+
+func TestWorkerRetrySucceeds(t *testing.T) {
+	t.Parallel()
+	q := newRecordingQueue()
+	w := job.NewWorker(job.Policy{Concurrency: 1, RetryBackoff: backoff}, q)
+	var calls atomic.Int32
+	job.Handle(w, func(_ context.Context, _ ping) error {
+		if calls.Add(1) == 1 {
+			return errors.New("timeout")
+		}
+		return nil
+	})
+	run(t, w)
+
+	if err := job.Dispatch(context.Background(), q, ping{}); err != nil {
+		t.Fatal(err)
+	}
+	if acked := receive(t, q.acked); acked.Attempts != 1 || calls.Load() != 2 {
+		t.Errorf("got %+v after %d calls, acknowledged at the second call expected", acked, calls.Load())
+	}
+}
+
+func TestWorkerDelay(t *testing.T) {
+	t.Parallel()
+	q := newRecordingQueue()
+	w := job.NewWorker(job.Policy{Concurrency: 1}, q)
+	handled := make(chan time.Time, 1)
+	job.Handle(w, func(_ context.Context, _ ping) error {
+		handled <- time.Now()
+		return nil
+	})
+	run(t, w)
+
+	const delay = time.Millisecond * 100
+	start := time.Now()
+	if err := job.Dispatch(context.Background(), q, ping{}, job.Delay(delay)); err != nil {
+		t.Fatal(err)
+	}
+	receive(t, q.acked)
+	if at := <-handled; at.Sub(start) < delay {
+		t.Errorf("got the job handled after %v, %v expected", at.Sub(start), delay)
+	}
+}
+
+func TestWorkerDrain(t *testing.T) {
+	t.Parallel()
+	q := newRecordingQueue()
+	w := job.NewWorker(job.Policy{Concurrency: 2}, q)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var cancelled atomic.Bool
+	job.Handle(w, func(ctx context.Context, _ ping) error {
+		close(started)
+		<-release
+		// The job in progress keeps its context when the worker stops
+		cancelled.Store(ctx.Err() != nil)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		w.Run(ctx)
+		close(done)
+	}()
+	if err := job.Dispatch(context.Background(), q, ping{}); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	cancel()
+
+	select {
+	case <-done:
+		t.Fatal("got the worker stopped with a job in progress")
+	case <-time.After(time.Millisecond * 50):
+	}
+	close(release)
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("got the worker still running after the job in progress")
+	}
+	receive(t, q.acked)
+	if cancelled.Load() {
+		t.Error("got the context of the job in progress cancelled")
+	}
+}
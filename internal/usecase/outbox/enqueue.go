@@ -0,0 +1,31 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/event"
+	"go-app/internal/domain/repository"
+	"go-app/pkg/errors"
+)
+
+// Enqueue will store the events in the outbox, repo must belong to the transaction of the change
+// so the events are published only when the change is committed
+func Enqueue(ctx context.Context, repo repository.OutboxRepository, events ...event.Event) error {
+	for _, e := range events {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return errors.ErrInternalServerError.Wrap(err)
+		}
+		msg := &entity.OutboxMessage{
+			EventName: e.EventName(),
+			Payload:   payload,
+		}
+		if err := repo.Store(ctx, msg); err != nil {
+			return errors.Throw(err)
+		}
+	}
+
+	return nil
+}
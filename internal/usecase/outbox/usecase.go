@@ -0,0 +1,135 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/event"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/domain/repository"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/pkg/errors"
+	"go-app/pkg/logger"
+	"go-app/pkg/utils"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Policy of the dispatcher, zero values fall back to the constant defaults
+type Policy struct {
+	BatchSize    int
+	PollInterval time.Duration
+	MaxAttempts  int
+	RetryBackoff time.Duration
+}
+
+// Usecase dispatches the outbox to the publisher
+type Usecase struct {
+	policy    Policy
+	publisher gateway.EventPublisher
+	repo      repository.OutboxRepository
+}
+
+// NewUsecase will create new an Usecase object representation of entity.Usecase interface
+func NewUsecase(policy Policy, publisher gateway.EventPublisher, repo repository.OutboxRepository) *Usecase {
+	if policy.BatchSize <= 0 {
+		policy.BatchSize = constant.OutboxBatchSize
+	}
+	if policy.PollInterval <= 0 {
+		policy.PollInterval = constant.OutboxPollInterval
+	}
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = constant.OutboxMaxAttempts
+	}
+	if policy.RetryBackoff <= 0 {
+		policy.RetryBackoff = constant.OutboxRetryBackoff
+	}
+
+	return &Usecase{
+		policy:    policy,
+		publisher: publisher,
+		repo:      repo,
+	}
+}
+
+// Run will dispatch the outbox until ctx is done, the batch in progress is finished first
+func (uc *Usecase) Run(ctx context.Context) {
+	for {
+		n, err := uc.Dispatch(context.WithoutCancel(ctx))
+		if err != nil {
+			logger.ErrorContext(ctx, "outbox dispatch", "error", err)
+		}
+		// A full batch means more messages are probably due
+		if err == nil && n == uc.policy.BatchSize {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(uc.policy.PollInterval):
+		}
+	}
+}
+
+// Dispatch will publish a batch of due messages and return how many were processed. The batch is claimed in a
+// short transaction and every message is published outside of it, then marked on its own, the delivery is at
+// least once: a message is published again when its dispatcher dies before marking it
+func (uc *Usecase) Dispatch(ctx context.Context) (int, error) {
+	// Messages stay claimed until the last one of the batch is published
+	lease := time.Duration(uc.policy.BatchSize)*constant.OutboxPublishTimeout + constant.OutboxClaimMargin
+	msgs, err := uc.repo.Claim(ctx, uc.policy.BatchSize, lease)
+	if err != nil {
+		return 0, errors.Throw(err)
+	}
+
+	for i := range msgs {
+		if err := uc.deliver(ctx, &msgs[i]); err != nil {
+			return i, errors.Throw(err)
+		}
+	}
+
+	return len(msgs), nil
+}
+
+// deliver publishes the message and records the result, failed attempts are retried with an
+// exponential backoff until the max attempts is reached
+func (uc *Usecase) deliver(ctx context.Context, msg *entity.OutboxMessage) error {
+	env := event.Envelope{
+		ID:         msg.ID,
+		Name:       msg.EventName,
+		Payload:    msg.Payload,
+		OccurredAt: msg.CreatedAt,
+		Attempt:    msg.Attempts + 1,
+	}
+
+	// Every publication starts a trace, the handlers of the subscribers are its children
+	pubCtx, span := tracing.Start(ctx, "outbox "+msg.EventName,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attribute.Int64("outbox.id", int64(msg.ID)), attribute.Int("outbox.attempt", env.Attempt)),
+	)
+	pubCtx, cancel := context.WithTimeout(pubCtx, constant.OutboxPublishTimeout)
+	pubErr := uc.publisher.Publish(pubCtx, env)
+	cancel()
+	tracing.End(span, pubErr)
+	if pubErr == nil {
+		return uc.repo.MarkPublished(ctx, msg.ID)
+	}
+
+	attempts := msg.Attempts + 1
+	if attempts >= uc.policy.MaxAttempts {
+		logger.ErrorContext(ctx, "outbox message dead", "event", msg.EventName, "id", msg.ID, "error", pubErr)
+		return uc.repo.MarkFailed(ctx, msg.ID, attempts, pubErr.Error())
+	}
+	logger.WarnContext(ctx, "outbox message retry", "event", msg.EventName, "id", msg.ID, "error", pubErr)
+
+	runAt := time.Now().Add(utils.Backoff(uc.policy.RetryBackoff, constant.OutboxMaxRetryBackoff, attempts))
+
+	return uc.repo.MarkRetry(ctx, msg.ID, attempts, runAt, pubErr.Error())
+}
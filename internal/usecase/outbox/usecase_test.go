@@ -0,0 +1,208 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/event"
+	"go-app/internal/domain/repository"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/usecase/outbox"
+)
+
+const backoff = time.Second
+
+// fakeOutboxRepository keeps the messages in memory, Claim returns the due messages and postpones them by lease
+type fakeOutboxRepository struct {
+	repository.OutboxRepository
+
+	mu       sync.Mutex
+	messages map[uint]*entity.OutboxMessage
+}
+
+func (rp *fakeOutboxRepository) Claim(
+	_ context.Context,
+	limit int,
+	lease time.Duration,
+) ([]entity.OutboxMessage, error) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	msgs := []entity.OutboxMessage{}
+	for id := uint(1); id <= uint(len(rp.messages)); id++ {
+		msg := rp.messages[id]
+		if len(msgs) == limit || msg.PublishedAt != nil || msg.FailedAt != nil || msg.AvailableAt.After(time.Now()) {
+			continue
+		}
+		msg.AvailableAt = time.Now().Add(lease)
+		msgs = append(msgs, *msg)
+	}
+
+	return msgs, nil
+}
+
+func (rp *fakeOutboxRepository) MarkPublished(_ context.Context, id uint) error {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	now := time.Now()
+	rp.messages[id].PublishedAt = &now
+
+	return nil
+}
+
+func (rp *fakeOutboxRepository) MarkRetry(
+	_ context.Context,
+	id uint,
+	attempts int,
+	availableAt time.Time,
+	lastErr string,
+) error {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	msg := rp.messages[id]
+	msg.Attempts = attempts
+	msg.AvailableAt = availableAt
+	msg.LastError = lastErr
+
+	return nil
+}
+
+func (rp *fakeOutboxRepository) MarkFailed(_ context.Context, id uint, attempts int, lastErr string) error {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	now := time.Now()
+	msg := rp.messages[id]
+	msg.Attempts = attempts
+	msg.FailedAt = &now
+	msg.LastError = lastErr
+
+	return nil
+}
+
+// get returns a copy of the message
+func (rp *fakeOutboxRepository) get(id uint) entity.OutboxMessage {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	return *rp.messages[id]
+}
+
+// due makes the message due now, as when its backoff is over
+func (rp *fakeOutboxRepository) due(id uint) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	rp.messages[id].AvailableAt = time.Now()
+}
+
+// failingPublisher fails the events named in fails, it records the attempts of the envelopes it receives and
+// the lease left on their messages when a repository is set
+type failingPublisher struct {
+	fails    map[string]bool
+	attempts []int
+	repo     *fakeOutboxRepository
+	leases   []time.Duration
+}
+
+func (p *failingPublisher) Publish(_ context.Context, env event.Envelope) error {
+	p.attempts = append(p.attempts, env.Attempt)
+	if p.repo != nil {
+		p.leases = append(p.leases, time.Until(p.repo.get(env.ID).AvailableAt))
+	}
+	if p.fails[env.Name] {
+		return errors.New("broker unavailable")
+	}
+
+	return nil
+}
+
+// newRepository returns a repository of due messages of the events
+func newRepository(names ...string) *fakeOutboxRepository {
+	rp := &fakeOutboxRepository{messages: map[uint]*entity.OutboxMessage{}}
+	for i, name := range names {
+		id := uint(i + 1)
+		rp.messages[id] = &entity.OutboxMessage{ID: id, EventName: name, Payload: []byte(`{}`), AvailableAt: time.Now()}
+	}
+
+	return rp
+}
+
+// dispatch dispatches the due messages and checks how many were processed
+func dispatch(t *testing.T, uc *outbox.Usecase, expected int) {
+	t.Helper()
+	n, err := uc.Dispatch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != expected {
+		t.Fatalf("got %d messages dispatched, %d expected", n, expected)
+	}
+}
+
+func TestDispatchRetry(t *testing.T) {
+	t.Parallel()
+	repo := newRepository("user.registered", "role.deleted")
+	publisher := &failingPublisher{fails: map[string]bool{"role.deleted": true}}
+	uc := outbox.NewUsecase(outbox.Policy{MaxAttempts: 3, RetryBackoff: backoff}, publisher, repo)
+
+	dispatch(t, uc, 2)
+	if msg := repo.get(1); msg.PublishedAt == nil || msg.Attempts != 0 {
+		t.Errorf("got %+v, the message published expected", msg)
+	}
+
+	expectedResults := []time.Duration{backoff, backoff * 2}
+	for testNumber, testExpected := range expectedResults {
+		msg := repo.get(2)
+		wait := time.Until(msg.AvailableAt)
+		if msg.PublishedAt != nil || msg.FailedAt != nil || msg.Attempts != testNumber+1 ||
+			msg.LastError != "broker unavailable" || wait > testExpected || wait < testExpected-time.Millisecond*100 {
+			t.Errorf("#%d got %+v available in %v, %v expected", testNumber, msg, wait, testExpected)
+		}
+		// The message waits for its backoff
+		dispatch(t, uc, 0)
+		repo.due(2)
+		dispatch(t, uc, 1)
+	}
+
+	msg := repo.get(2)
+	if msg.FailedAt == nil || msg.Attempts != 3 || msg.LastError != "broker unavailable" {
+		t.Errorf("got %+v, the message dead after 3 attempts expected", msg)
+	}
+	repo.due(2)
+	dispatch(t, uc, 0)
+
+	expectedAttempts := []int{1, 1, 2, 3}
+	if len(publisher.attempts) != len(expectedAttempts) {
+		t.Fatalf("got attempts %v, %v expected", publisher.attempts, expectedAttempts)
+	}
+	for i, attempt := range expectedAttempts {
+		if publisher.attempts[i] != attempt {
+			t.Errorf("got attempts %v, %v expected", publisher.attempts, expectedAttempts)
+			break
+		}
+	}
+}
+
+func TestDispatchLease(t *testing.T) {
+	t.Parallel()
+	repo := newRepository("user.registered", "user.deleted", "role.deleted")
+	publisher := &failingPublisher{repo: repo}
+	uc := outbox.NewUsecase(outbox.Policy{BatchSize: 2}, publisher, repo)
+
+	dispatch(t, uc, 2)
+	dispatch(t, uc, 1)
+	dispatch(t, uc, 0)
+
+	// Every message of a batch stays claimed for the publications of the whole batch
+	lease := 2*constant.OutboxPublishTimeout + constant.OutboxClaimMargin
+	if len(publisher.leases) != 3 {
+		t.Fatalf("got %d publications, 3 expected", len(publisher.leases))
+	}
+	for i, left := range publisher.leases {
+		if left > lease || left < lease-time.Second {
+			t.Errorf("#%d got the message claimed for %v, %v expected", i, left, lease)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package permission
+
+import (
+	"context"
+	"fmt"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/repository"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/internal/usecase/audit"
+	"go-app/pkg/errors"
+	"go-app/pkg/logger"
+)
+
+// Usecase ...
+type Usecase struct {
+	repo     repository.PermissionRepository
+	roleRepo repository.RoleRepository
+	auditUc  *audit.Usecase
+}
+
+// NewUsecase will create new an Usecase object representation of entity.Usecase interface
+func NewUsecase(
+	repo repository.PermissionRepository,
+	roleRepo repository.RoleRepository,
+	auditUc *audit.Usecase,
+) *Usecase {
+	return &Usecase{
+		repo:     repo,
+		roleRepo: roleRepo,
+		auditUc:  auditUc,
+	}
+}
+
+// Fetch will fetch content from repo
+func (uc *Usecase) Fetch(c context.Context) ([]entity.Permission, error) {
+	c, span := tracing.Start(c, "permission.Fetch")
+	defer span.End()
+
+	items, err := uc.repo.Fetch(c)
+	if err != nil {
+		return nil, tracing.Fail(span, errors.Throw(err))
+	}
+
+	return items, nil
+}
+
+// Find will find content from repo
+func (uc *Usecase) Find(c context.Context, id uint) (*entity.Permission, error) {
+	c, span := tracing.Start(c, "permission.Find")
+	defer span.End()
+
+	item, err := uc.repo.Find(c, id)
+	if err != nil {
+		return nil, tracing.Fail(span, errors.Throw(err))
+	}
+
+	return item, nil
+}
+
+// Store will create content from repo
+func (uc *Usecase) Store(ctx context.Context, p *entity.Permission) error {
+	ctx, span := tracing.Start(ctx, "permission.Store")
+	defer span.End()
+
+	// Check exist by name
+	exists, err := uc.repo.CheckExists(ctx, entity.Permission{Name: p.Name}, nil)
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	if exists {
+		return tracing.Fail(span, errors.ErrPermissionExists.Trace())
+	}
+
+	if err := uc.repo.Store(ctx, p); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	uc.auditUc.Record(ctx, constant.AuditPermissionCreated, constant.AuditTargetPermission, p.ID, nil, p)
+
+	return nil
+}
+
+// Update will update content from repo
+func (uc *Usecase) Update(ctx context.Context, id uint, p *entity.Permission) error {
+	ctx, span := tracing.Start(ctx, "permission.Update")
+	defer span.End()
+
+	// Check exist by name
+	exists, err := uc.repo.CheckExists(ctx, entity.Permission{Name: p.Name}, &id)
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	if exists {
+		return tracing.Fail(span, errors.ErrPermissionExists.Trace())
+	}
+
+	before, err := uc.repo.Find(ctx, id)
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	p.ID = id
+	if err := uc.repo.Update(ctx, p); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	after, err := uc.repo.Find(ctx, id)
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	uc.auditUc.Record(ctx, constant.AuditPermissionUpdated, constant.AuditTargetPermission, id, before, after)
+
+	return nil
+}
+
+// Delete will delete content from repo
+func (uc *Usecase) Delete(c context.Context, id uint) error {
+	c, span := tracing.Start(c, "permission.Delete")
+	defer span.End()
+
+	before, err := uc.repo.Find(c, id)
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	if err := uc.repo.Delete(c, id); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	uc.auditUc.Record(c, constant.AuditPermissionDeleted, constant.AuditTargetPermission, id, before, nil)
+
+	return nil
+}
+
+// FetchByRole will fetch permissions granted to the role
+func (uc *Usecase) FetchByRole(ctx context.Context, roleID uint) ([]entity.Permission, error) {
+	ctx, span := tracing.Start(ctx, "permission.FetchByRole")
+	defer span.End()
+
+	if _, err := uc.roleRepo.Find(ctx, roleID); err != nil {
+		return nil, tracing.Fail(span, errors.Throw(err))
+	}
+
+	items, err := uc.repo.FetchByRole(ctx, roleID)
+	if err != nil {
+		return nil, tracing.Fail(span, errors.Throw(err))
+	}
+
+	return items, nil
+}
+
+// SyncRole will replace permissions granted to the role
+func (uc *Usecase) SyncRole(ctx context.Context, roleID uint, ids []uint) error {
+	ctx, span := tracing.Start(ctx, "permission.SyncRole")
+	defer span.End()
+
+	if _, err := uc.roleRepo.Find(ctx, roleID); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	if err := uc.checkExist(ctx, ids); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	before, err := uc.rolePermissionNames(ctx, roleID)
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	if err := uc.repo.SyncRole(ctx, roleID, ids); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	uc.recordRolePermissions(ctx, roleID, before)
+
+	return nil
+}
+
+// AttachRole will grant the permission to the role
+func (uc *Usecase) AttachRole(ctx context.Context, roleID, id uint) error {
+	ctx, span := tracing.Start(ctx, "permission.AttachRole")
+	defer span.End()
+
+	if _, err := uc.roleRepo.Find(ctx, roleID); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	if _, err := uc.repo.Find(ctx, id); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	before, err := uc.rolePermissionNames(ctx, roleID)
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	if err := uc.repo.AttachRole(ctx, roleID, id); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	uc.recordRolePermissions(ctx, roleID, before)
+
+	return nil
+}
+
+// DetachRole will revoke the permission from the role
+func (uc *Usecase) DetachRole(ctx context.Context, roleID, id uint) error {
+	ctx, span := tracing.Start(ctx, "permission.DetachRole")
+	defer span.End()
+
+	before, err := uc.rolePermissionNames(ctx, roleID)
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	if err := uc.repo.DetachRole(ctx, roleID, id); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	uc.recordRolePermissions(ctx, roleID, before)
+
+	return nil
+}
+
+// checkExist returns ErrPermissionUnknown listing the ids which are not permissions, the grants are left unchanged
+func (uc *Usecase) checkExist(ctx context.Context, ids []uint) error {
+	items, err := uc.repo.Fetch(ctx)
+	if err != nil {
+		return errors.Throw(err)
+	}
+
+	known := make(map[uint]bool, len(items))
+	for i := range items {
+		known[items[i].ID] = true
+	}
+	unknown := []uint{}
+	for _, id := range ids {
+		if !known[id] {
+			unknown = append(unknown, id)
+		}
+	}
+	if len(unknown) > 0 {
+		return errors.ErrPermissionUnknown.Wrap(fmt.Errorf("permission ids %v do not exist", unknown))
+	}
+
+	return nil
+}
+
+// rolePermissions is the audited state of the permissions of a role
+type rolePermissions struct {
+	Permissions []string `json:"permissions"`
+}
+
+// rolePermissionNames returns the names of permissions granted to the role
+func (uc *Usecase) rolePermissionNames(ctx context.Context, roleID uint) (*rolePermissions, error) {
+	items, err := uc.repo.FetchByRole(ctx, roleID)
+	if err != nil {
+		return nil, errors.Throw(err)
+	}
+
+	names := []string{}
+	for i := range items {
+		names = append(names, items[i].Name)
+	}
+
+	return &rolePermissions{Permissions: names}, nil
+}
+
+// recordRolePermissions records the change of permissions granted to the role, the change is already
+// applied so failures are only logged
+func (uc *Usecase) recordRolePermissions(ctx context.Context, roleID uint, before *rolePermissions) {
+	after, err := uc.rolePermissionNames(ctx, roleID)
+	if err != nil {
+		logger.ErrorContext(ctx, "audit log not recorded", "action", constant.AuditRolePermissionsUpdated, "error", err)
+		return
+	}
+	uc.auditUc.Record(ctx, constant.AuditRolePermissionsUpdated, constant.AuditTargetRole, roleID, before, after)
+}
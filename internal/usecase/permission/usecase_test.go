@@ -0,0 +1,114 @@
+package permission_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/repository"
+	"go-app/internal/usecase/audit"
+	"go-app/internal/usecase/permission"
+	"go-app/pkg/errors"
+)
+
+// fakePermissionRepository keeps the permissions and the grants of the roles in memory
+type fakePermissionRepository struct {
+	repository.PermissionRepository
+
+	permissions []entity.Permission
+	grants      map[uint][]uint
+}
+
+func (rp *fakePermissionRepository) Fetch(context.Context) ([]entity.Permission, error) {
+	return rp.permissions, nil
+}
+
+func (rp *fakePermissionRepository) FetchByRole(_ context.Context, roleID uint) ([]entity.Permission, error) {
+	items := []entity.Permission{}
+	for _, id := range rp.grants[roleID] {
+		for _, p := range rp.permissions {
+			if p.ID == id {
+				items = append(items, p)
+			}
+		}
+	}
+
+	return items, nil
+}
+
+func (rp *fakePermissionRepository) SyncRole(_ context.Context, roleID uint, ids []uint) error {
+	rp.grants[roleID] = ids
+
+	return nil
+}
+
+// fakeRoleRepository finds the role 1 only
+type fakeRoleRepository struct {
+	repository.RoleRepository
+}
+
+func (fakeRoleRepository) Find(_ context.Context, id uint) (*entity.Role, error) {
+	if id != 1 {
+		return nil, errors.ErrNotFound.Trace()
+	}
+
+	return &entity.Role{ID: id}, nil
+}
+
+// fakeAuditRepository discards the audit logs
+type fakeAuditRepository struct {
+	repository.AuditRepository
+}
+
+func (fakeAuditRepository) Store(context.Context, *entity.AuditLog) error {
+	return nil
+}
+
+func TestSyncRole(t *testing.T) {
+	t.Parallel()
+
+	expectedResults := []struct {
+		roleID uint
+		ids    []uint
+		err    error
+		grants []uint
+	}{
+		{1, []uint{2, 3}, nil, []uint{2, 3}},
+		{1, []uint{}, nil, []uint{}},
+		// The grants are left unchanged when an id is not a permission or the role does not exist
+		{1, []uint{2, 9}, errors.ErrPermissionUnknown.Trace(), []uint{1}},
+		{1, []uint{0}, errors.ErrPermissionUnknown.Trace(), []uint{1}},
+		{5, []uint{2}, errors.ErrNotFound.Trace(), []uint{1}},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		repo := &fakePermissionRepository{
+			permissions: []entity.Permission{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}},
+			grants:      map[uint][]uint{1: {1}, 5: {1}},
+		}
+		uc := permission.NewUsecase(repo, fakeRoleRepository{}, audit.NewUsecase(fakeAuditRepository{}))
+
+		err := uc.SyncRole(context.Background(), testExpected.roleID, testExpected.ids)
+		if (testExpected.err == nil && err != nil) || (testExpected.err != nil && !errors.Is(err, testExpected.err)) {
+			t.Errorf("#%d got %v, %v expected", testNumber, err, testExpected.err)
+		}
+		if grants := repo.grants[testExpected.roleID]; !reflect.DeepEqual(grants, testExpected.grants) {
+			t.Errorf("#%d got the grants %v, %v expected", testNumber, grants, testExpected.grants)
+		}
+	}
+}
+
+func TestSyncRoleUnknownDetail(t *testing.T) {
+	t.Parallel()
+	repo := &fakePermissionRepository{permissions: []entity.Permission{{ID: 1}}, grants: map[uint][]uint{}}
+	uc := permission.NewUsecase(repo, fakeRoleRepository{}, audit.NewUsecase(fakeAuditRepository{}))
+
+	// The unknown ids are named for the problem detail of the 422
+	err := uc.SyncRole(context.Background(), 1, []uint{1, 7, 8})
+	var be *errors.BaseError
+	if !errors.As(err, &be) || be.Status != errors.ErrPermissionUnknown.Status || be.Unwrap() == nil ||
+		be.Unwrap().Error() != "permission ids [7 8] do not exist" {
+		t.Errorf("got %v, the ids 7 and 8 named expected", err)
+	}
+}
@@ -4,37 +4,60 @@ import (
 	"context"
 
 	"go-app/internal/domain/entity"
+	"go-app/internal/domain/event"
 	"go-app/internal/domain/repository"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/internal/usecase/audit"
+	"go-app/internal/usecase/outbox"
 	"go-app/pkg/errors"
 )
 
 // Usecase ...
 type Usecase struct {
-	repo repository.RoleRepository
+	transactor repository.Transactor
+	repo       repository.RoleRepository
+	auditUc    *audit.Usecase
 }
 
 // NewUsecase will create new an Usecase object representation of entity.Usecase interface
-func NewUsecase(repo repository.RoleRepository) *Usecase {
+func NewUsecase(
+	transactor repository.Transactor,
+	repo repository.RoleRepository,
+	auditUc *audit.Usecase,
+) *Usecase {
 	return &Usecase{
-		repo: repo,
+		transactor: transactor,
+		repo:       repo,
+		auditUc:    auditUc,
 	}
 }
 
-// Fetch will fetch content from repo
-func (uc *Usecase) Fetch(c context.Context) ([]entity.Role, error) {
-	items, err := uc.repo.Fetch(c)
+// Fetch will fetch a page of content from repo
+func (uc *Usecase) Fetch(c context.Context, q repository.Query) ([]entity.Role, repository.Pagination, error) {
+	c, span := tracing.Start(c, "role.Fetch")
+	defer span.End()
+
+	if err := q.Normalize(repository.RoleQuerySpec); err != nil {
+		return nil, repository.Pagination{}, tracing.Fail(span, errors.Throw(err))
+	}
+
+	items, pg, err := uc.repo.Fetch(c, q)
 	if err != nil {
-		return nil, errors.Throw(err)
+		return nil, pg, tracing.Fail(span, errors.Throw(err))
 	}
 
-	return items, nil
+	return items, pg, nil
 }
 
 // Find will find content from repo
 func (uc *Usecase) Find(c context.Context, id uint) (*entity.Role, error) {
+	c, span := tracing.Start(c, "role.Find")
+	defer span.End()
+
 	item, err := uc.repo.Find(c, id)
 	if err != nil {
-		return nil, errors.Throw(err)
+		return nil, tracing.Fail(span, errors.Throw(err))
 	}
 
 	return item, nil
@@ -42,8 +65,25 @@ func (uc *Usecase) Find(c context.Context, id uint) (*entity.Role, error) {
 
 // Store will create content from repo
 func (uc *Usecase) Store(c context.Context, role *entity.Role) error {
-	if err := uc.repo.Store(c, role); err != nil {
-		return errors.Throw(err)
+	c, span := tracing.Start(c, "role.Store")
+	defer span.End()
+
+	err := uc.transactor.Transaction(c, func(ctx context.Context, repos repository.Repositories) error {
+		if err := repos.Role().Store(ctx, role); err != nil {
+			return errors.Throw(err)
+		}
+
+		created := event.RoleCreated{RoleID: role.ID, Name: role.Name, Slug: role.Slug}
+		if err := outbox.Enqueue(ctx, repos.Outbox(), created); err != nil {
+			return errors.Throw(err)
+		}
+
+		return uc.auditUc.RecordWith(
+			ctx, repos.Audit(), constant.AuditRoleCreated, constant.AuditTargetRole, role.ID, nil, role,
+		)
+	})
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
 	}
 
 	return nil
@@ -51,18 +91,41 @@ func (uc *Usecase) Store(c context.Context, role *entity.Role) error {
 
 // Update will update content from repo
 func (uc *Usecase) Update(ctx context.Context, id uint, r *entity.Role) error {
+	ctx, span := tracing.Start(ctx, "role.Update")
+	defer span.End()
+
 	// Check exist by name
 	exists, err := uc.repo.CheckExists(ctx, *r, &id)
 	if err != nil {
-		return errors.Throw(err)
+		return tracing.Fail(span, errors.Throw(err))
 	}
 	if exists {
-		return errors.ErrRoleExists.Trace()
+		return tracing.Fail(span, errors.ErrRoleExists.Trace())
 	}
 
-	r.ID = id
-	if err := uc.repo.Update(ctx, r); err != nil {
-		return errors.Throw(err)
+	err = uc.transactor.Transaction(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		before, err := repos.Role().Find(ctx, id)
+		if err != nil {
+			return errors.Throw(err)
+		}
+
+		r.ID = id
+		if err := repos.Role().Update(ctx, r); err != nil {
+			return errors.Throw(err)
+		}
+
+		// Columns not updated are read again to record only the real changes
+		after, err := repos.Role().Find(ctx, id)
+		if err != nil {
+			return errors.Throw(err)
+		}
+
+		return uc.auditUc.RecordWith(
+			ctx, repos.Audit(), constant.AuditRoleUpdated, constant.AuditTargetRole, id, before, after,
+		)
+	})
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
 	}
 
 	return nil
@@ -70,8 +133,27 @@ func (uc *Usecase) Update(ctx context.Context, id uint, r *entity.Role) error {
 
 // Delete will delete content from repo
 func (uc *Usecase) Delete(c context.Context, id uint) error {
-	if err := uc.repo.Delete(c, id); err != nil {
-		return errors.Throw(err)
+	c, span := tracing.Start(c, "role.Delete")
+	defer span.End()
+
+	before, err := uc.repo.Find(c, id)
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	err = uc.transactor.Transaction(c, func(ctx context.Context, repos repository.Repositories) error {
+		if err := repos.Role().Delete(ctx, id); err != nil {
+			return errors.Throw(err)
+		}
+
+		if err := outbox.Enqueue(ctx, repos.Outbox(), event.RoleDeleted{RoleID: id, Slug: before.Slug}); err != nil {
+			return errors.Throw(err)
+		}
+
+		return uc.auditUc.RecordWith(ctx, repos.Audit(), constant.AuditRoleDeleted, constant.AuditTargetRole, id, before, nil)
+	})
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
 	}
 
 	return nil
@@ -4,37 +4,65 @@ import (
 	"context"
 
 	"go-app/internal/domain/entity"
+	"go-app/internal/domain/event"
 	"go-app/internal/domain/repository"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/internal/usecase/audit"
+	"go-app/internal/usecase/auth"
+	"go-app/internal/usecase/outbox"
 	"go-app/pkg/errors"
+	"go-app/pkg/logger"
 )
 
 // Usecase ...
 type Usecase struct {
-	repo repository.UserRepository
+	transactor repository.Transactor
+	repo       repository.UserRepository
+	authUc     *auth.Usecase
+	auditUc    *audit.Usecase
 }
 
 // NewUsecase will create new an Usecase object representation of entity.Usecase interface
-func NewUsecase(repo repository.UserRepository) *Usecase {
+func NewUsecase(
+	transactor repository.Transactor,
+	repo repository.UserRepository,
+	authUc *auth.Usecase,
+	auditUc *audit.Usecase,
+) *Usecase {
 	return &Usecase{
-		repo: repo,
+		transactor: transactor,
+		repo:       repo,
+		authUc:     authUc,
+		auditUc:    auditUc,
 	}
 }
 
-// Fetch will fetch content from repo
-func (uc *Usecase) Fetch(c context.Context) ([]entity.User, error) {
-	items, err := uc.repo.Fetch(c)
+// Fetch will fetch a page of content from repo
+func (uc *Usecase) Fetch(c context.Context, q repository.Query) ([]entity.User, repository.Pagination, error) {
+	c, span := tracing.Start(c, "user.Fetch")
+	defer span.End()
+
+	if err := q.Normalize(repository.UserQuerySpec); err != nil {
+		return nil, repository.Pagination{}, tracing.Fail(span, errors.Throw(err))
+	}
+
+	items, pg, err := uc.repo.Fetch(c, q)
 	if err != nil {
-		return nil, errors.Throw(err)
+		return nil, pg, tracing.Fail(span, errors.Throw(err))
 	}
 
-	return items, nil
+	return items, pg, nil
 }
 
 // Find will find content from repo
 func (uc *Usecase) Find(c context.Context, id uint) (*entity.User, error) {
+	c, span := tracing.Start(c, "user.Find")
+	defer span.End()
+
 	item, err := uc.repo.Find(c, id)
 	if err != nil {
-		return nil, errors.Throw(err)
+		return nil, tracing.Fail(span, errors.Throw(err))
 	}
 
 	return item, nil
@@ -42,8 +70,29 @@ func (uc *Usecase) Find(c context.Context, id uint) (*entity.User, error) {
 
 // Store will create content from repo
 func (uc *Usecase) Store(c context.Context, user *entity.User) error {
-	if err := uc.repo.Store(c, user); err != nil {
-		return errors.Throw(err)
+	c, span := tracing.Start(c, "user.Store")
+	defer span.End()
+
+	err := uc.transactor.Transaction(c, func(ctx context.Context, repos repository.Repositories) error {
+		if err := repos.User().Store(ctx, user); err != nil {
+			return errors.Throw(err)
+		}
+
+		if err := outbox.Enqueue(ctx, repos.Outbox(), event.UserCreated{
+			UserID: user.ID,
+			Email:  user.Email,
+			Name:   user.Name,
+			RoleID: user.RoleID,
+		}); err != nil {
+			return errors.Throw(err)
+		}
+
+		return uc.auditUc.RecordWith(
+			ctx, repos.Audit(), constant.AuditUserCreated, constant.AuditTargetUser, user.ID, nil, user,
+		)
+	})
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
 	}
 
 	return nil
@@ -51,28 +100,71 @@ func (uc *Usecase) Store(c context.Context, user *entity.User) error {
 
 // FindByQuery is a function that returns a user filtered by query
 func (uc *Usecase) FindByQuery(ctx context.Context, q entity.User) (*entity.User, error) {
+	ctx, span := tracing.Start(ctx, "user.FindByQuery")
+	defer span.End()
+
 	item, err := uc.repo.FindByQuery(ctx, q)
 	if err != nil {
-		return nil, errors.Throw(err)
+		return nil, tracing.Fail(span, errors.Throw(err))
 	}
 
 	return item, nil
 }
 
-// Update will update content from repo
+// Update will update content from repo, a new email is verified again with a new verification link
 func (uc *Usecase) Update(ctx context.Context, id uint, u *entity.User) error {
+	ctx, span := tracing.Start(ctx, "user.Update")
+	defer span.End()
+
 	// Check exist by email
 	userByEmail := entity.User{Email: u.Email}
 	exists, err := uc.repo.CheckExists(ctx, userByEmail, &id)
 	if err != nil {
-		return errors.Throw(err)
+		return tracing.Fail(span, errors.Throw(err))
 	}
 	if exists {
-		return errors.ErrUserExistsByEmail.Trace()
+		return tracing.Fail(span, errors.ErrUserExistsByEmail.Trace())
+	}
+
+	var emailChanged bool
+	err = uc.transactor.Transaction(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		before, err := repos.User().Find(ctx, id)
+		if err != nil {
+			return errors.Throw(err)
+		}
+
+		u.ID = id
+		if err := repos.User().Update(ctx, u); err != nil {
+			return errors.Throw(err)
+		}
+
+		emailChanged = u.Email != before.Email
+		if emailChanged {
+			if err := repos.User().UnverifyEmail(ctx, id); err != nil {
+				return errors.Throw(err)
+			}
+		}
+
+		// Columns not updated are read again to record only the real changes
+		after, err := repos.User().Find(ctx, id)
+		if err != nil {
+			return errors.Throw(err)
+		}
+
+		return uc.auditUc.RecordWith(
+			ctx, repos.Audit(), constant.AuditUserUpdated, constant.AuditTargetUser, id, before, after,
+		)
+	})
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
 	}
-	u.ID = id
-	if err := uc.repo.Update(ctx, u); err != nil {
-		return errors.Throw(err)
+
+	// The user is committed so a failure only asks for a resend
+	if emailChanged {
+		u.EmailVerifiedAt = nil
+		if err := uc.authUc.SendVerificationEmail(ctx, u); err != nil {
+			logger.ErrorContext(ctx, "verification email not queued", "error", err)
+		}
 	}
 
 	return nil
@@ -80,8 +172,27 @@ func (uc *Usecase) Update(ctx context.Context, id uint, u *entity.User) error {
 
 // Delete will delete content from repo
 func (uc *Usecase) Delete(c context.Context, id uint) error {
-	if err := uc.repo.Delete(c, id); err != nil {
-		return errors.Throw(err)
+	c, span := tracing.Start(c, "user.Delete")
+	defer span.End()
+
+	before, err := uc.repo.Find(c, id)
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	err = uc.transactor.Transaction(c, func(ctx context.Context, repos repository.Repositories) error {
+		if err := repos.User().Delete(ctx, id); err != nil {
+			return errors.Throw(err)
+		}
+
+		if err := outbox.Enqueue(ctx, repos.Outbox(), event.UserDeleted{UserID: id, Email: before.Email}); err != nil {
+			return errors.Throw(err)
+		}
+
+		return uc.auditUc.RecordWith(ctx, repos.Audit(), constant.AuditUserDeleted, constant.AuditTargetUser, id, before, nil)
+	})
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
 	}
 
 	return nil
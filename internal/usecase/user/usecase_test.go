@@ -0,0 +1,132 @@
+package user_test
+
+import (
+	"context"
+	"testing"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/event"
+	"go-app/internal/domain/repository"
+	"go-app/internal/usecase/audit"
+	"go-app/internal/usecase/user"
+	"go-app/pkg/errors"
+)
+
+// fakeRepositories stage the writes of a transaction
+type fakeRepositories struct {
+	repository.Repositories
+
+	users    []entity.User
+	outbox   []entity.OutboxMessage
+	audits   []entity.AuditLog
+	auditErr error
+}
+
+func (r *fakeRepositories) User() repository.UserRepository {
+	return fakeUserRepository{repos: r}
+}
+
+func (r *fakeRepositories) Outbox() repository.OutboxRepository {
+	return fakeOutboxRepository{repos: r}
+}
+
+func (r *fakeRepositories) Audit() repository.AuditRepository {
+	return fakeAuditRepository{repos: r}
+}
+
+// fakeUserRepository stores the users in the repositories
+type fakeUserRepository struct {
+	repository.UserRepository
+
+	repos *fakeRepositories
+}
+
+func (rp fakeUserRepository) Store(_ context.Context, u *entity.User) error {
+	u.ID = uint(len(rp.repos.users) + 1)
+	rp.repos.users = append(rp.repos.users, *u)
+
+	return nil
+}
+
+// fakeOutboxRepository stores the messages in the repositories
+type fakeOutboxRepository struct {
+	repository.OutboxRepository
+
+	repos *fakeRepositories
+}
+
+func (rp fakeOutboxRepository) Store(_ context.Context, msg *entity.OutboxMessage) error {
+	rp.repos.outbox = append(rp.repos.outbox, *msg)
+
+	return nil
+}
+
+// fakeAuditRepository stores the audit logs in the repositories, or fails with auditErr
+type fakeAuditRepository struct {
+	repository.AuditRepository
+
+	repos *fakeRepositories
+}
+
+func (rp fakeAuditRepository) Store(_ context.Context, log *entity.AuditLog) error {
+	if rp.repos.auditErr != nil {
+		return rp.repos.auditErr
+	}
+	rp.repos.audits = append(rp.repos.audits, *log)
+
+	return nil
+}
+
+// fakeTransactor runs fn on staged repositories, the writes are committed when fn returns nil only
+type fakeTransactor struct {
+	committed fakeRepositories
+	auditErr  error
+}
+
+func (tx *fakeTransactor) Transaction(
+	ctx context.Context,
+	fn func(ctx context.Context, repos repository.Repositories) error,
+) error {
+	staged := &fakeRepositories{auditErr: tx.auditErr}
+	if err := fn(ctx, staged); err != nil {
+		return err
+	}
+	tx.committed.users = append(tx.committed.users, staged.users...)
+	tx.committed.outbox = append(tx.committed.outbox, staged.outbox...)
+	tx.committed.audits = append(tx.committed.audits, staged.audits...)
+
+	return nil
+}
+
+func TestStoreIsTransactional(t *testing.T) {
+	t.Parallel()
+
+	expectedResults := []struct {
+		auditErr error
+		writes   int
+	}{
+		{nil, 1},
+		// The user and its event are not committed when the audit log fails
+		{errors.ErrUnexpectedDBError.Trace(), 0},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		tx := &fakeTransactor{auditErr: testExpected.auditErr}
+		uc := user.NewUsecase(tx, nil, nil, audit.NewUsecase(nil))
+
+		err := uc.Store(context.Background(), &entity.User{Name: "ann", Email: "ann@example.com", RoleID: 2})
+		if !errors.Is(err, testExpected.auditErr) || (err == nil) != (testExpected.auditErr == nil) {
+			t.Errorf("#%d got %v, %v expected", testNumber, err, testExpected.auditErr)
+		}
+		committed := tx.committed
+		if len(committed.users) != testExpected.writes || len(committed.outbox) != testExpected.writes ||
+			len(committed.audits) != testExpected.writes {
+			t.Errorf("#%d got %d users, %d messages and %d audit logs committed, %d expected", testNumber,
+				len(committed.users), len(committed.outbox), len(committed.audits), testExpected.writes)
+		}
+		if testExpected.writes > 0 && committed.outbox[0].EventName != event.UserCreatedName {
+			t.Errorf("#%d got the event %s, %s expected", testNumber, committed.outbox[0].EventName,
+				event.UserCreatedName)
+		}
+	}
+}
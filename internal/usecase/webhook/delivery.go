@@ -0,0 +1,243 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/event"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/domain/repository"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/pkg/errors"
+	"go-app/pkg/logger"
+	"go-app/pkg/utils"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// payload is the body posted to webhooks
+type payload struct {
+	ID         uint            `json:"id"`
+	Event      string          `json:"event"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// HandleEvent will queue a delivery of the event for every webhook subscribed to it, it is
+// subscribed to the event bus and is idempotent as an event is queued once per webhook
+func (uc *Usecase) HandleEvent(ctx context.Context, env event.Envelope) error {
+	ctx, span := tracing.Start(ctx, "webhook.HandleEvent")
+	defer span.End()
+
+	webhooks, err := uc.repo.FetchByEvent(ctx, env.Name)
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(payload{ID: env.ID, Event: env.Name, OccurredAt: env.OccurredAt, Data: env.Payload})
+	if err != nil {
+		return tracing.Fail(span, errors.ErrInternalServerError.Wrap(err))
+	}
+	for i := range webhooks {
+		delivery := &entity.WebhookDelivery{
+			WebhookID: webhooks[i].ID,
+			EventID:   env.ID,
+			EventName: env.Name,
+			Payload:   body,
+			Status:    constant.WebhookDeliveryPending,
+		}
+		if err := uc.deliveryRepo.Store(ctx, delivery); err != nil {
+			return tracing.Fail(span, errors.Throw(err))
+		}
+	}
+
+	return nil
+}
+
+// Run will send the due deliveries until ctx is done, the batch in progress is finished first
+func (uc *Usecase) Run(ctx context.Context) {
+	for {
+		n, err := uc.Deliver(context.WithoutCancel(ctx))
+		if err != nil {
+			logger.ErrorContext(ctx, "webhook delivery", "error", err)
+		}
+		// A full batch means more deliveries are probably due
+		if err == nil && n == constant.WebhookBatchSize {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(constant.WebhookPollInterval):
+		}
+	}
+}
+
+// Deliver will send a batch of due deliveries and return how many were sent
+func (uc *Usecase) Deliver(ctx context.Context) (int, error) {
+	// Deliveries stay claimed until the last one of the batch is sent
+	deliveries, err := uc.deliveryRepo.Claim(ctx, constant.WebhookBatchSize, constant.WebhookClaimLease)
+	if err != nil {
+		return 0, errors.Throw(err)
+	}
+
+	webhooks := map[uint]*entity.Webhook{}
+	for i := range deliveries {
+		d := &deliveries[i]
+		w, ok := webhooks[d.WebhookID]
+		if !ok {
+			if w, err = uc.repo.Find(ctx, d.WebhookID); err != nil {
+				return i, errors.Throw(err)
+			}
+			webhooks[d.WebhookID] = w
+		}
+		if err := uc.send(ctx, w, d); err != nil {
+			return i, errors.Throw(err)
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// send posts the delivery, logs the attempt and schedules the next one on failure with an exponential
+// backoff, the delivery is dead after the max attempts or when the webhook is disabled
+func (uc *Usecase) send(ctx context.Context, w *entity.Webhook, d *entity.WebhookDelivery) error {
+	var res gateway.WebhookResponse
+	var sendErr error
+	if w.Active {
+		sendCtx, span := tracing.Start(ctx, "webhook "+d.EventName,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.Int64("webhook.id", int64(w.ID)),
+				attribute.Int64("webhook.delivery.id", int64(d.ID)),
+			),
+		)
+		res, sendErr = uc.webhookSvc.Send(sendCtx, gateway.WebhookRequest{
+			URL:        w.URL,
+			Secret:     w.Secret,
+			Event:      d.EventName,
+			DeliveryID: strconv.FormatUint(uint64(d.ID), 10),
+			Body:       d.Payload,
+		})
+		tracing.End(span, sendErr)
+	} else {
+		sendErr = fmt.Errorf("webhook %d is disabled", w.ID)
+	}
+
+	d.Attempts++
+	attempt := &entity.WebhookAttempt{
+		DeliveryID:   d.ID,
+		Attempt:      d.Attempts,
+		StatusCode:   res.StatusCode,
+		ResponseBody: res.Body,
+		Duration:     res.Duration,
+	}
+	d.LastStatusCode = res.StatusCode
+	d.LastError = ""
+	switch {
+	case sendErr == nil:
+		now := time.Now()
+		d.Status = constant.WebhookDeliverySucceeded
+		d.DeliveredAt = &now
+	case !w.Active || d.Attempts >= constant.WebhookMaxAttempts:
+		d.Status = constant.WebhookDeliveryDead
+		d.LastError = sendErr.Error()
+		logger.WarnContext(ctx, "webhook delivery dead", "webhook_id", w.ID, "delivery_id", d.ID, "error", sendErr)
+	default:
+		d.NextAttemptAt = time.Now().Add(
+			utils.Backoff(constant.WebhookRetryBackoff, constant.WebhookMaxRetryBackoff, d.Attempts),
+		)
+		d.LastError = sendErr.Error()
+	}
+	attempt.Error = d.LastError
+
+	if err := uc.deliveryRepo.StoreAttempt(ctx, attempt); err != nil {
+		return errors.Throw(err)
+	}
+	if err := uc.deliveryRepo.Update(ctx, d); err != nil {
+		return errors.Throw(err)
+	}
+
+	return nil
+}
+
+// FetchDeliveries will fetch a page of deliveries of the webhook
+func (uc *Usecase) FetchDeliveries(
+	c context.Context,
+	webhookID uint,
+	q repository.Query,
+) ([]entity.WebhookDelivery, repository.Pagination, error) {
+	c, span := tracing.Start(c, "webhook.FetchDeliveries")
+	defer span.End()
+
+	if err := q.Normalize(repository.WebhookDeliveryQuerySpec); err != nil {
+		return nil, repository.Pagination{}, tracing.Fail(span, errors.Throw(err))
+	}
+	if _, err := uc.repo.Find(c, webhookID); err != nil {
+		return nil, repository.Pagination{}, tracing.Fail(span, errors.Throw(err))
+	}
+
+	items, pg, err := uc.deliveryRepo.Fetch(c, webhookID, q)
+	if err != nil {
+		return nil, pg, tracing.Fail(span, errors.Throw(err))
+	}
+
+	return items, pg, nil
+}
+
+// FindDelivery will find the delivery of the webhook with the log of its attempts
+func (uc *Usecase) FindDelivery(
+	c context.Context,
+	webhookID, id uint,
+) (*entity.WebhookDelivery, []entity.WebhookAttempt, error) {
+	c, span := tracing.Start(c, "webhook.FindDelivery")
+	defer span.End()
+
+	delivery, err := uc.deliveryRepo.Find(c, webhookID, id)
+	if err != nil {
+		return nil, nil, tracing.Fail(span, errors.Throw(err))
+	}
+
+	attempts, err := uc.deliveryRepo.FetchAttempts(c, id)
+	if err != nil {
+		return nil, nil, tracing.Fail(span, errors.Throw(err))
+	}
+
+	return delivery, attempts, nil
+}
+
+// Redeliver will queue a dead delivery again for a new series of attempts
+func (uc *Usecase) Redeliver(c context.Context, webhookID, id uint) error {
+	c, span := tracing.Start(c, "webhook.Redeliver")
+	defer span.End()
+
+	delivery, err := uc.deliveryRepo.Find(c, webhookID, id)
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	if delivery.Status != constant.WebhookDeliveryDead {
+		return tracing.Fail(span, errors.ErrWebhookDeliveryNotDead.Trace())
+	}
+
+	delivery.Status = constant.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	if err := uc.deliveryRepo.Update(c, delivery); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	return nil
+}
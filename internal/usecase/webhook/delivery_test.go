@@ -0,0 +1,260 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/domain/repository"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/usecase/webhook"
+	"go-app/pkg/errors"
+	pkgwebhook "go-app/pkg/webhook"
+)
+
+// fakeWebhookRepository keeps the webhooks in a map
+type fakeWebhookRepository struct {
+	repository.WebhookRepository
+
+	webhooks map[uint]entity.Webhook
+}
+
+func (rp *fakeWebhookRepository) Find(_ context.Context, id uint) (*entity.Webhook, error) {
+	w, ok := rp.webhooks[id]
+	if !ok {
+		return nil, errors.ErrNotFound.Trace()
+	}
+
+	return &w, nil
+}
+
+// fakeDeliveryRepository keeps the deliveries and the attempts in memory, Claim returns the due pending deliveries
+type fakeDeliveryRepository struct {
+	repository.WebhookDeliveryRepository
+
+	mu         sync.Mutex
+	deliveries map[uint]entity.WebhookDelivery
+	attempts   []entity.WebhookAttempt
+}
+
+func (rp *fakeDeliveryRepository) Claim(
+	_ context.Context,
+	limit int,
+	lease time.Duration,
+) ([]entity.WebhookDelivery, error) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	claimed := []entity.WebhookDelivery{}
+	for id, d := range rp.deliveries {
+		if len(claimed) == limit || d.Status != constant.WebhookDeliveryPending || d.NextAttemptAt.After(time.Now()) {
+			continue
+		}
+		d.NextAttemptAt = time.Now().Add(lease)
+		rp.deliveries[id] = d
+		claimed = append(claimed, d)
+	}
+
+	return claimed, nil
+}
+
+func (rp *fakeDeliveryRepository) Update(_ context.Context, d *entity.WebhookDelivery) error {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	rp.deliveries[d.ID] = *d
+
+	return nil
+}
+
+func (rp *fakeDeliveryRepository) StoreAttempt(_ context.Context, a *entity.WebhookAttempt) error {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	rp.attempts = append(rp.attempts, *a)
+
+	return nil
+}
+
+// get returns the stored delivery
+func (rp *fakeDeliveryRepository) get(id uint) entity.WebhookDelivery {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	return rp.deliveries[id]
+}
+
+// due makes the delivery due now, as when its backoff is over
+func (rp *fakeDeliveryRepository) due(id uint) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	d := rp.deliveries[id]
+	d.NextAttemptAt = time.Now()
+	rp.deliveries[id] = d
+}
+
+// clientService sends with the client of the test server, NewClient refuses its loopback address
+type clientService struct {
+	client *pkgwebhook.Client
+}
+
+func (svc clientService) Send(ctx context.Context, req gateway.WebhookRequest) (gateway.WebhookResponse, error) {
+	res, err := svc.client.Send(ctx, pkgwebhook.Message{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		Event:      req.Event,
+		DeliveryID: req.DeliveryID,
+		Body:       req.Body,
+	})
+
+	return gateway.WebhookResponse{StatusCode: res.StatusCode, Body: res.Body, Duration: res.Duration}, err
+}
+
+// receiver is a test server answering with the statuses in turn, it records the delivery ids and whether a
+// signature was invalid
+type receiver struct {
+	mu          sync.Mutex
+	statuses    []int
+	deliveryIDs []string
+	unverified  bool
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	err := pkgwebhook.Verify("secret", req.Header.Get(pkgwebhook.SignatureHeader), body, time.Minute, time.Now())
+	r.unverified = r.unverified || err != nil
+	r.deliveryIDs = append(r.deliveryIDs, req.Header.Get(pkgwebhook.DeliveryHeader))
+	status := r.statuses[min(len(r.deliveryIDs), len(r.statuses))-1]
+	w.WriteHeader(status)
+}
+
+// newUsecase returns the usecase with a webhook posting to the receiver and a pending delivery of it
+func newUsecase(t *testing.T, r *receiver, active bool, attempts int) (*webhook.Usecase, *fakeDeliveryRepository) {
+	t.Helper()
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	repo := &fakeWebhookRepository{webhooks: map[uint]entity.Webhook{
+		1: {ID: 1, URL: srv.URL, Secret: "secret", Active: active},
+	}}
+	deliveryRepo := &fakeDeliveryRepository{deliveries: map[uint]entity.WebhookDelivery{
+		7: {
+			ID:            7,
+			WebhookID:     1,
+			EventName:     "user.registered",
+			Payload:       []byte(`{"id":1}`),
+			Status:        constant.WebhookDeliveryPending,
+			Attempts:      attempts,
+			NextAttemptAt: time.Now(),
+		},
+	}}
+	svc := clientService{client: &pkgwebhook.Client{HTTP: srv.Client(), UserAgent: "test"}}
+
+	return webhook.NewUsecase(svc, repo, deliveryRepo, nil), deliveryRepo
+}
+
+// deliver sends the due deliveries and checks how many were sent
+func deliver(t *testing.T, uc *webhook.Usecase, expected int) {
+	t.Helper()
+	n, err := uc.Deliver(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != expected {
+		t.Fatalf("got %d deliveries sent, %d expected", n, expected)
+	}
+}
+
+func TestDeliverRetries(t *testing.T) {
+	t.Parallel()
+	r := &receiver{statuses: []int{http.StatusServiceUnavailable, http.StatusInternalServerError, http.StatusOK}}
+	uc, deliveryRepo := newUsecase(t, r, true, 0)
+
+	expectedResults := []struct {
+		status  int
+		backoff time.Duration
+	}{
+		{http.StatusServiceUnavailable, constant.WebhookRetryBackoff},
+		{http.StatusInternalServerError, constant.WebhookRetryBackoff * 2},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		start := time.Now()
+		deliver(t, uc, 1)
+		d := deliveryRepo.get(7)
+		next := d.NextAttemptAt.Sub(start)
+		if d.Status != constant.WebhookDeliveryPending || d.Attempts != testNumber+1 ||
+			d.LastStatusCode != testExpected.status || d.LastError == "" ||
+			next < testExpected.backoff || next > testExpected.backoff+time.Second {
+			t.Errorf("#%d got %+v retried after %v, %v expected", testNumber, d, next, testExpected.backoff)
+		}
+		// The delivery waits for its backoff
+		deliver(t, uc, 0)
+		deliveryRepo.due(7)
+	}
+
+	deliver(t, uc, 1)
+	d := deliveryRepo.get(7)
+	if d.Status != constant.WebhookDeliverySucceeded || d.Attempts != 3 || d.DeliveredAt == nil || d.LastError != "" {
+		t.Errorf("got %+v, the delivery succeeded at the third attempt expected", d)
+	}
+	if len(deliveryRepo.attempts) != 3 || deliveryRepo.attempts[2].StatusCode != http.StatusOK ||
+		deliveryRepo.attempts[0].Error == "" {
+		t.Errorf("got attempts %+v", deliveryRepo.attempts)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.unverified || len(r.deliveryIDs) != 3 || r.deliveryIDs[0] != "7" || r.deliveryIDs[2] != "7" {
+		t.Errorf("got deliveries %v unverified %v, the signed delivery 7 every time expected", r.deliveryIDs, r.unverified)
+	}
+}
+
+func TestDeliverDead(t *testing.T) {
+	t.Parallel()
+
+	expectedResults := []struct {
+		active   bool
+		attempts int
+		requests int
+	}{
+		// The last attempt fails
+		{true, constant.WebhookMaxAttempts - 1, 1},
+		// A disabled webhook is not called
+		{false, 0, 0},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		r := &receiver{statuses: []int{http.StatusInternalServerError}}
+		uc, deliveryRepo := newUsecase(t, r, testExpected.active, testExpected.attempts)
+
+		deliver(t, uc, 1)
+		d := deliveryRepo.get(7)
+		if d.Status != constant.WebhookDeliveryDead || d.Attempts != testExpected.attempts+1 || d.LastError == "" {
+			t.Errorf("#%d got %+v, a dead delivery expected", testNumber, d)
+		}
+		deliveryRepo.due(7)
+		deliver(t, uc, 0)
+		r.mu.Lock()
+		if len(r.deliveryIDs) != testExpected.requests {
+			t.Errorf("#%d got %d requests, %d expected", testNumber, len(r.deliveryIDs), testExpected.requests)
+		}
+		r.mu.Unlock()
+	}
+}
+
+func TestStoreForbiddenURL(t *testing.T) {
+	t.Parallel()
+	uc := webhook.NewUsecase(nil, &fakeWebhookRepository{}, &fakeDeliveryRepository{}, nil)
+
+	for _, u := range []string{"http://localhost:8080/hook", "http://169.254.169.254/", "http://10.0.0.1/hook"} {
+		err := uc.Store(context.Background(), &entity.Webhook{URL: u, Events: []string{"user.registered"}})
+		if !errors.Is(err, errors.ErrWebhookForbiddenURL.Trace()) {
+			t.Errorf("%s got %v, ErrWebhookForbiddenURL expected", u, err)
+		}
+	}
+}
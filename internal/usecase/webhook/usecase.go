@@ -0,0 +1,154 @@
+package webhook
+
+import (
+	"context"
+	"slices"
+
+	"go-app/internal/domain/entity"
+	"go-app/internal/domain/event"
+	"go-app/internal/domain/gateway"
+	"go-app/internal/domain/repository"
+	"go-app/internal/infrastructure/constant"
+	"go-app/internal/infrastructure/tracing"
+	"go-app/internal/usecase/audit"
+	"go-app/pkg/errors"
+	"go-app/pkg/utils"
+	"go-app/pkg/webhook"
+)
+
+// Usecase ...
+type Usecase struct {
+	webhookSvc   gateway.WebhookService
+	repo         repository.WebhookRepository
+	deliveryRepo repository.WebhookDeliveryRepository
+	auditUc      *audit.Usecase
+}
+
+// NewUsecase will create new an Usecase object representation of entity.Usecase interface
+func NewUsecase(
+	webhookSvc gateway.WebhookService,
+	repo repository.WebhookRepository,
+	deliveryRepo repository.WebhookDeliveryRepository,
+	auditUc *audit.Usecase,
+) *Usecase {
+	return &Usecase{
+		webhookSvc:   webhookSvc,
+		repo:         repo,
+		deliveryRepo: deliveryRepo,
+		auditUc:      auditUc,
+	}
+}
+
+// Fetch will fetch a page of content from repo
+func (uc *Usecase) Fetch(c context.Context, q repository.Query) ([]entity.Webhook, repository.Pagination, error) {
+	c, span := tracing.Start(c, "webhook.Fetch")
+	defer span.End()
+
+	if err := q.Normalize(repository.WebhookQuerySpec); err != nil {
+		return nil, repository.Pagination{}, tracing.Fail(span, errors.Throw(err))
+	}
+
+	items, pg, err := uc.repo.Fetch(c, q)
+	if err != nil {
+		return nil, pg, tracing.Fail(span, errors.Throw(err))
+	}
+
+	return items, pg, nil
+}
+
+// Find will find content from repo
+func (uc *Usecase) Find(c context.Context, id uint) (*entity.Webhook, error) {
+	c, span := tracing.Start(c, "webhook.Find")
+	defer span.End()
+
+	item, err := uc.repo.Find(c, id)
+	if err != nil {
+		return nil, tracing.Fail(span, errors.Throw(err))
+	}
+
+	return item, nil
+}
+
+// Store will create content from repo, a secret is generated when it is empty
+func (uc *Usecase) Store(c context.Context, w *entity.Webhook) error {
+	c, span := tracing.Start(c, "webhook.Store")
+	defer span.End()
+
+	if err := validate(w); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	if w.Secret == "" {
+		secret, err := utils.RandString(constant.WebhookSecretLength)
+		if err != nil {
+			return tracing.Fail(span, errors.ErrInternalServerError.Wrap(err))
+		}
+		w.Secret = secret
+	}
+
+	if err := uc.repo.Store(c, w); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	uc.auditUc.Record(c, constant.AuditWebhookCreated, constant.AuditTargetWebhook, w.ID, nil, w)
+
+	return nil
+}
+
+// Update will update content from repo, the secret is kept when it is empty
+func (uc *Usecase) Update(ctx context.Context, id uint, w *entity.Webhook) error {
+	ctx, span := tracing.Start(ctx, "webhook.Update")
+	defer span.End()
+
+	if err := validate(w); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	before, err := uc.repo.Find(ctx, id)
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	w.ID = id
+	w.CreatedAt = before.CreatedAt
+	if w.Secret == "" {
+		w.Secret = before.Secret
+	}
+	if err := uc.repo.Update(ctx, w); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	uc.auditUc.Record(ctx, constant.AuditWebhookUpdated, constant.AuditTargetWebhook, id, before, w)
+
+	return nil
+}
+
+// Delete will delete content from repo
+func (uc *Usecase) Delete(c context.Context, id uint) error {
+	c, span := tracing.Start(c, "webhook.Delete")
+	defer span.End()
+
+	before, err := uc.repo.Find(c, id)
+	if err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+
+	if err := uc.repo.Delete(c, id); err != nil {
+		return tracing.Fail(span, errors.Throw(err))
+	}
+	uc.auditUc.Record(c, constant.AuditWebhookDeleted, constant.AuditTargetWebhook, id, before, nil)
+
+	return nil
+}
+
+// validate checks the url is public and the events can be subscribed to, the url is checked again at every
+// delivery as its name may resolve to another address
+func validate(w *entity.Webhook) error {
+	if err := webhook.CheckURL(w.URL); err != nil {
+		return errors.ErrWebhookForbiddenURL.Wrap(err)
+	}
+	for _, name := range w.Events {
+		if !slices.Contains(event.Names, name) {
+			return errors.ErrWebhookUnknownEvent.Trace()
+		}
+	}
+
+	return nil
+}
@@ -21,6 +21,10 @@ var (
 	ErrBadGateway = New(http.StatusBadGateway, 10006, "Bad gateway.")
 	// ErrUnprocessableEntity is returned when the request body is not valid
 	ErrUnprocessableEntity = New(http.StatusUnprocessableEntity, 10007, "Unprocessable entity.")
+	// ErrInvalidQuery is returned when the listing query sorts or filters by a field not allowed
+	ErrInvalidQuery = New(http.StatusBadRequest, 10008, "Invalid sort, filter or cursor.")
+	// ErrTooManyRequests is returned when the client exceeds a rate limit
+	ErrTooManyRequests = New(http.StatusTooManyRequests, 10009, "Too many requests.")
 
 	// JWT
 
@@ -30,6 +34,10 @@ var (
 	ErrJWTInvalidClaims = New(http.StatusBadRequest, 11001, "Failed to cast claims as jwt.MapClaims.")
 	// ErrJWTRevoke is returned when the user JWT token is revoked
 	ErrJWTRevoke = New(http.StatusBadRequest, 11002, "JWT token is revoked.")
+	// ErrJWTUnknownKey is returned when the JWT token is signed by a key not in the key set
+	ErrJWTUnknownKey = New(http.StatusUnauthorized, 11003, "JWT signing key is unknown.")
+	// ErrJWTInvalidKeySet is returned when the JWT keys configured can not be loaded
+	ErrJWTInvalidKeySet = New(http.StatusInternalServerError, 11004, "JWT key set is invalid.")
 
 	// Redis
 
@@ -51,6 +59,8 @@ var (
 		14000,
 		"Send email must specify at least one From address and one To address.",
 	)
+	// ErrSendEmailFailed is returned when the mail server or the driver can not deliver the email
+	ErrSendEmailFailed = New(http.StatusInternalServerError, 14001, "Send email failed.")
 
 	// Auth
 
@@ -66,6 +76,14 @@ var (
 	ErrAuthInvalidateToken = New(http.StatusBadRequest, 15004, "Invalid token forgot password.")
 	// ErrAuthThrottleLogin is returned when the user login is failed
 	ErrAuthThrottleLogin = New(http.StatusForbidden, 15005, "Too many login attempts. Please try again later.")
+	// ErrAuthRefreshTokenInvalid is returned when the refresh token is unknown or expired
+	ErrAuthRefreshTokenInvalid = New(http.StatusUnauthorized, 15006, "Refresh token is invalid or expired.")
+	// ErrAuthRefreshTokenReused is returned when a rotated refresh token is used again
+	ErrAuthRefreshTokenReused = New(http.StatusUnauthorized, 15007, "Refresh token has already been used.")
+	// ErrAuthEmailNotVerified is returned when the user email is not verified
+	ErrAuthEmailNotVerified = New(http.StatusForbidden, 15008, "Your email address is not verified.")
+	// ErrAuthInvalidateSignature is returned when the verification link is invalid or expired
+	ErrAuthInvalidateSignature = New(http.StatusBadRequest, 15009, "Invalid or expired verification link.")
 
 	// Role
 
@@ -76,4 +94,31 @@ var (
 
 	// ErrUserExistsByEmail is returned when the user already exists by email
 	ErrUserExistsByEmail = New(http.StatusBadRequest, 17000, "User already exists by email.")
+
+	// Permission
+
+	// ErrPermissionExists is returned when the permission already exists
+	ErrPermissionExists = New(http.StatusBadRequest, 18000, "Permission already exists.")
+	// ErrPermissionUnknown is returned when a permission granted to a role does not exist
+	ErrPermissionUnknown = New(http.StatusUnprocessableEntity, 18001, "Unknown permission.")
+
+	// TwoFactor
+
+	// ErrTwoFactorAlreadyEnabled is returned when the two factor authentication is already enabled
+	ErrTwoFactorAlreadyEnabled = New(http.StatusBadRequest, 19000, "Two factor authentication is already enabled.")
+	// ErrTwoFactorNotSetup is returned when the two factor authentication is not set up
+	ErrTwoFactorNotSetup = New(http.StatusBadRequest, 19001, "Two factor authentication is not set up.")
+	// ErrTwoFactorInvalidCode is returned when the two factor code is invalid
+	ErrTwoFactorInvalidCode = New(http.StatusBadRequest, 19002, "Invalid two factor authentication code.")
+	// ErrTwoFactorChallengeInvalid is returned when the two factor challenge token is invalid or expired
+	ErrTwoFactorChallengeInvalid = New(http.StatusUnauthorized, 19003, "Two factor challenge is invalid or expired.")
+
+	// Webhook
+
+	// ErrWebhookDeliveryNotDead is returned when a delivery which is pending or succeeded is redelivered
+	ErrWebhookDeliveryNotDead = New(http.StatusBadRequest, 20000, "Only dead webhook deliveries can be redelivered.")
+	// ErrWebhookUnknownEvent is returned when a webhook subscribes to an event which does not exist
+	ErrWebhookUnknownEvent = New(http.StatusUnprocessableEntity, 20001, "Unknown webhook event.")
+	// ErrWebhookForbiddenURL is returned when a webhook url is not a public http or https url
+	ErrWebhookForbiddenURL = New(http.StatusUnprocessableEntity, 20002, "Webhook URL must be a public http or https URL.")
 )
@@ -39,6 +39,11 @@ func As(err error, target any) bool {
 	return errors.As(err, target)
 }
 
+// Join returns an error wrapping the given errors, nil when all of them are nil
+func Join(errs ...error) error {
+	return errors.Join(errs...)
+}
+
 // Throw is constructor to create error object.
 func Throw(err error) *BaseError {
 	var bErr *BaseError
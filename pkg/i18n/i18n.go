@@ -0,0 +1,22 @@
+// Package i18n carries the preferred locale of a request through the context, the mails and the messages of the
+// invalid fields are localised in it
+package i18n
+
+import (
+	"context"
+)
+
+// localeKey is the context key of the locale
+type localeKey struct{}
+
+// WithLocale returns a copy of ctx carrying the preferred locale of the caller, like an Accept-Language value
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// Locale returns the locale carried by ctx, empty when none is set
+func Locale(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+
+	return locale
+}
@@ -0,0 +1,124 @@
+package jwk
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+)
+
+// Algorithms of asymmetric keys
+const (
+	// RS256 is RSASSA-PKCS1-v1_5 using SHA-256
+	RS256 = "RS256"
+	// EdDSA is Ed25519 signature
+	EdDSA = "EdDSA"
+)
+
+var (
+	errNoPEMBlock     = errors.New("jwk: no PEM block found")
+	errUnsupportedKey = errors.New("jwk: unsupported key type, RSA or Ed25519 expected")
+)
+
+// Key is an asymmetric key identified by ID, Private is nil when the key only verifies tokens
+type Key struct {
+	ID        string
+	Algorithm string
+	Private   any
+	Public    any
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// Set is a JSON Web Key Set
+type Set struct {
+	Keys []JWK `json:"keys"`
+}
+
+// LoadPEM reads the key of id from a PEM file
+func LoadPEM(id, path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePEM(id, data)
+}
+
+// ParsePEM parses a PKCS #8 or PKCS #1 private key, or a PKIX public key
+func ParsePEM(id string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errNoPEMBlock
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, errUnsupportedKey
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKey(id, parsed)
+}
+
+// NewKey returns the key of id from a RSA or Ed25519 private or public key
+func NewKey(id string, k any) (*Key, error) {
+	switch v := k.(type) {
+	case *rsa.PrivateKey:
+		return &Key{ID: id, Algorithm: RS256, Private: v, Public: &v.PublicKey}, nil
+	case *rsa.PublicKey:
+		return &Key{ID: id, Algorithm: RS256, Public: v}, nil
+	case ed25519.PrivateKey:
+		return &Key{ID: id, Algorithm: EdDSA, Private: v, Public: v.Public()}, nil
+	case ed25519.PublicKey:
+		return &Key{ID: id, Algorithm: EdDSA, Public: v}, nil
+	default:
+		return nil, errUnsupportedKey
+	}
+}
+
+// JWK returns the public part of the key
+func (k *Key) JWK() JWK {
+	res := JWK{Kid: k.ID, Use: "sig", Alg: k.Algorithm}
+	switch v := k.Public.(type) {
+	case *rsa.PublicKey:
+		res.Kty = "RSA"
+		res.N = encode(v.N.Bytes())
+		res.E = encode(big.NewInt(int64(v.E)).Bytes())
+	case ed25519.PublicKey:
+		res.Kty = "OKP"
+		res.Crv = "Ed25519"
+		res.X = encode(v)
+	}
+
+	return res
+}
+
+// encode is base64url without padding
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
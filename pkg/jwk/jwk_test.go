@@ -0,0 +1,116 @@
+package jwk_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"go-app/pkg/jwk"
+	"math/big"
+	"testing"
+)
+
+type ExpectedParseResult struct {
+	blockType string
+	der       func(t *testing.T) []byte
+	algorithm string
+	private   bool
+}
+
+func TestParsePEM(t *testing.T) {
+	t.Parallel()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkcs8 := func(k any) func(t *testing.T) []byte {
+		return func(t *testing.T) []byte {
+			t.Helper()
+			b, err := x509.MarshalPKCS8PrivateKey(k)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return b
+		}
+	}
+	pkix := func(k any) func(t *testing.T) []byte {
+		return func(t *testing.T) []byte {
+			t.Helper()
+			b, err := x509.MarshalPKIXPublicKey(k)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return b
+		}
+	}
+
+	expectedParseResults := []ExpectedParseResult{
+		{"RSA PRIVATE KEY", func(*testing.T) []byte { return x509.MarshalPKCS1PrivateKey(rsaKey) }, jwk.RS256, true},
+		{"PRIVATE KEY", pkcs8(rsaKey), jwk.RS256, true},
+		{"PUBLIC KEY", pkix(&rsaKey.PublicKey), jwk.RS256, false},
+		{"PRIVATE KEY", pkcs8(edKey), jwk.EdDSA, true},
+		{"PUBLIC KEY", pkix(edPub), jwk.EdDSA, false},
+	}
+
+	for testNumber, testExpected := range expectedParseResults {
+		data := pem.EncodeToMemory(&pem.Block{Type: testExpected.blockType, Bytes: testExpected.der(t)})
+		key, err := jwk.ParsePEM("kid", data)
+		if err != nil {
+			t.Fatalf("#%d unexpected error: %v", testNumber, err)
+		}
+		if key.Algorithm != testExpected.algorithm || (key.Private != nil) != testExpected.private {
+			t.Errorf("#%d got %s private=%v", testNumber, key.Algorithm, key.Private != nil)
+		}
+	}
+
+	if _, err := jwk.ParsePEM("kid", []byte("not a pem")); err == nil {
+		t.Error("expected error for invalid PEM")
+	}
+}
+
+func TestJWK(t *testing.T) {
+	t.Parallel()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := jwk.NewKey("rsa-1", rsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := key.JWK()
+	n, err := base64.RawURLEncoding.DecodeString(res.N)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Kty != "RSA" || res.Kid != "rsa-1" || res.Alg != jwk.RS256 || res.E != "AQAB" {
+		t.Errorf("unexpected jwk %+v", res)
+	}
+	if new(big.Int).SetBytes(n).Cmp(rsaKey.N) != 0 {
+		t.Error("modulus mismatch")
+	}
+
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err = jwk.NewKey("ed-1", edPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res = key.JWK()
+	if res.Kty != "OKP" || res.Crv != "Ed25519" || res.X != base64.RawURLEncoding.EncodeToString(edPub) {
+		t.Errorf("unexpected jwk %+v", res)
+	}
+}
@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// fieldsKey is the context key of fields
+type fieldsKey struct{}
+
+// fields are the request correlation values attached to records
+type fields struct {
+	requestID string
+	userID    uint
+	route     string
+}
+
+// contextHandler adds the fields of context to records
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle adds request_id, user_id and route when they are set on ctx, trace_id and span_id when ctx has a span
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if f, ok := ctx.Value(fieldsKey{}).(fields); ok {
+		if f.requestID != "" {
+			r.AddAttrs(slog.String("request_id", f.requestID))
+		}
+		if f.userID != 0 {
+			r.AddAttrs(slog.Uint64("user_id", uint64(f.userID)))
+		}
+		if f.route != "" {
+			r.AddAttrs(slog.String("route", f.route))
+		}
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()), slog.String("span_id", sc.SpanID().String()))
+	}
+
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs keeps the context handler on derived loggers
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup keeps the context handler on derived loggers
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// WithRequestID returns a copy of ctx logging the request id
+func WithRequestID(ctx context.Context, id string) context.Context {
+	f, _ := ctx.Value(fieldsKey{}).(fields)
+	f.requestID = id
+
+	return context.WithValue(ctx, fieldsKey{}, f)
+}
+
+// WithUserID returns a copy of ctx logging the authenticated user id
+func WithUserID(ctx context.Context, id uint) context.Context {
+	f, _ := ctx.Value(fieldsKey{}).(fields)
+	f.userID = id
+
+	return context.WithValue(ctx, fieldsKey{}, f)
+}
+
+// WithRoute returns a copy of ctx logging the route
+func WithRoute(ctx context.Context, route string) context.Context {
+	f, _ := ctx.Value(fieldsKey{}).(fields)
+	f.route = route
+
+	return context.WithValue(ctx, fieldsKey{}, f)
+}
+
+// RequestID returns the request id of ctx
+func RequestID(ctx context.Context) string {
+	f, _ := ctx.Value(fieldsKey{}).(fields)
+
+	return f.requestID
+}
@@ -1,70 +1,177 @@
 package logger
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"os"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// LevelTrace is more verbose than slog.LevelDebug
+const LevelTrace = slog.Level(-8)
+
+// Formats of the handler
+const (
+	FormatText = "text"
+	FormatJSON = "json"
 )
 
 var (
-	infoLog  *log.Logger
-	debugLog *log.Logger
-	errorLog *log.Logger
-	traceLog *log.Logger
+	level = new(slog.LevelVar)
+	base  = newLogger(os.Stdout, FormatText)
 )
 
-// Init initializes the loggers (call this in main)
+// Init initializes the logger with text output at info level (call this in main),
+// Configure applies the settings once the config is loaded
 func Init() {
-	infoLog = log.New(os.Stdout, "INFO: ", log.Ldate|log.Ltime)
-	debugLog = log.New(os.Stdout, "DEBUG: ", log.Ldate|log.Ltime)
-	errorLog = log.New(os.Stderr, "ERROR: ", log.Ldate|log.Ltime|log.Lshortfile)
-	traceLog = log.New(os.Stdout, "TRACE: ", log.Ldate|log.Ltime|log.Llongfile)
+	level.Set(slog.LevelInfo)
+	base = newLogger(os.Stdout, FormatText)
+	slog.SetDefault(base)
+}
+
+// Configure sets the level (trace, debug, info, warn or error) and the format (text or json)
+func Configure(lvl, format string) error {
+	l, err := ParseLevel(lvl)
+	if err != nil {
+		return err
+	}
+	if format != "" && format != FormatText && format != FormatJSON {
+		return fmt.Errorf("logger: unknown format %q", format)
+	}
+
+	level.Set(l)
+	base = newLogger(os.Stdout, format)
+	slog.SetDefault(base)
+
+	return nil
+}
+
+// SetOutput writes logs to w, it is used by tests
+func SetOutput(w io.Writer, format string) {
+	base = newLogger(w, format)
+}
+
+// Logger returns the underlying slog.Logger
+func Logger() *slog.Logger {
+	return base
+}
+
+// ParseLevel parses the name of a level, empty is info
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(s) {
+	case "trace":
+		return LevelTrace, nil
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, fmt.Errorf("logger: unknown level %q", s)
+	}
+}
+
+// newLogger returns a logger adding the fields of context to records
+func newLogger(w io.Writer, format string) *slog.Logger {
+	opts := &slog.HandlerOptions{
+		AddSource: true,
+		Level:     level,
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.LevelKey {
+				if l, ok := a.Value.Any().(slog.Level); ok && l == LevelTrace {
+					a.Value = slog.StringValue("TRACE")
+				}
+			}
+
+			return a
+		},
+	}
+
+	var h slog.Handler = slog.NewTextHandler(w, opts)
+	if format == FormatJSON {
+		h = slog.NewJSONHandler(w, opts)
+	}
+
+	return slog.New(&contextHandler{Handler: h})
+}
+
+// log writes the record with the source of the caller of the exported function
+func log(ctx context.Context, lvl slog.Level, msg string, args ...any) {
+	if !base.Enabled(ctx, lvl) {
+		return
+	}
+
+	const skip = 3
+	var pcs [1]uintptr
+	runtime.Callers(skip, pcs[:])
+	r := slog.NewRecord(time.Now(), lvl, msg, pcs[0])
+	r.Add(args...)
+	_ = base.Handler().Handle(ctx, r)
+}
+
+// InfoContext logs an informational message with fields of ctx and key-value pairs
+func InfoContext(ctx context.Context, msg string, args ...any) {
+	log(ctx, slog.LevelInfo, msg, args...)
+}
+
+// DebugContext logs a debug message with fields of ctx and key-value pairs
+func DebugContext(ctx context.Context, msg string, args ...any) {
+	log(ctx, slog.LevelDebug, msg, args...)
+}
+
+// WarnContext logs a warning message with fields of ctx and key-value pairs
+func WarnContext(ctx context.Context, msg string, args ...any) {
+	log(ctx, slog.LevelWarn, msg, args...)
+}
+
+// ErrorContext logs an error message with fields of ctx and key-value pairs
+func ErrorContext(ctx context.Context, msg string, args ...any) {
+	log(ctx, slog.LevelError, msg, args...)
 }
 
 // Info logs an informational message
 func Info(args ...interface{}) {
-	infoLog.Println(args...)
+	log(context.Background(), slog.LevelInfo, fmt.Sprint(args...))
 }
 
 // Debug logs a debug message
 func Debug(args ...interface{}) {
-	debugLog.Println(args...)
+	log(context.Background(), slog.LevelDebug, fmt.Sprint(args...))
 }
 
 // Error logs an error message with file:line
 func Error(args ...interface{}) {
-	if err := errorLog.Output(2, fmt.Sprint(args...)); err != nil {
-		fmt.Fprintf(os.Stderr, "failed to write error log: %v\n", err)
-	}
+	log(context.Background(), slog.LevelError, fmt.Sprint(args...))
 }
 
-// Trace logs a trace message with full file path
+// Trace logs a trace message
 func Trace(args ...interface{}) {
-	if err := traceLog.Output(2, fmt.Sprint(args...)); err != nil {
-		fmt.Fprintf(os.Stderr, "failed to write trace log: %v\n", err)
-	}
+	log(context.Background(), LevelTrace, fmt.Sprint(args...))
 }
 
 // Infof logs a formatted informational message
 func Infof(format string, args ...interface{}) {
-	infoLog.Printf(format, args...)
+	log(context.Background(), slog.LevelInfo, fmt.Sprintf(format, args...))
 }
 
 // Debugf logs a formatted debug message
 func Debugf(format string, args ...interface{}) {
-	debugLog.Printf(format, args...)
+	log(context.Background(), slog.LevelDebug, fmt.Sprintf(format, args...))
 }
 
 // Errorf logs a formatted error message with file:line
 func Errorf(format string, args ...interface{}) {
-	if err := errorLog.Output(2, fmt.Sprintf(format, args...)); err != nil {
-		fmt.Fprintf(os.Stderr, "failed to write error log: %v\n", err)
-	}
+	log(context.Background(), slog.LevelError, fmt.Sprintf(format, args...))
 }
 
-// Tracef logs a formatted trace message with full file path
+// Tracef logs a formatted trace message
 func Tracef(format string, args ...interface{}) {
-	if err := traceLog.Output(2, fmt.Sprintf(format, args...)); err != nil {
-		fmt.Fprintf(os.Stderr, "failed to write trace log: %v\n", err)
-	}
+	log(context.Background(), LevelTrace, fmt.Sprintf(format, args...))
 }
@@ -0,0 +1,125 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"go-app/pkg/logger"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type ExpectedLevelResult struct {
+	level   string
+	logged  bool
+	invalid bool
+}
+
+var expectedLevelResults = []ExpectedLevelResult{
+	{level: "trace", logged: true},
+	{level: "debug", logged: true},
+	{level: "info", logged: false},
+	{level: "error", logged: false},
+	{level: "verbose", invalid: true},
+}
+
+func TestConfigureLevel(t *testing.T) {
+	for testNumber, testExpected := range expectedLevelResults {
+		err := logger.Configure(testExpected.level, logger.FormatJSON)
+		if testExpected.invalid {
+			if err == nil {
+				t.Errorf("#%d expected error for level %q", testNumber, testExpected.level)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("#%d unexpected error: %v", testNumber, err)
+		}
+
+		buf := new(bytes.Buffer)
+		logger.SetOutput(buf, logger.FormatJSON)
+		logger.Debug("message")
+		if logged := buf.Len() > 0; logged != testExpected.logged {
+			t.Errorf("#%d level %q logged %v", testNumber, testExpected.level, logged)
+		}
+	}
+}
+
+func TestContextFields(t *testing.T) {
+	if err := logger.Configure("info", logger.FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	logger.SetOutput(buf, logger.FormatJSON)
+
+	ctx := logger.WithRequestID(context.Background(), "req-1")
+	ctx = logger.WithUserID(ctx, 7)
+	ctx = logger.WithRoute(ctx, "/api/users/:id")
+	logger.InfoContext(ctx, "handled", "status", 200)
+
+	record := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]any{
+		"msg":        "handled",
+		"level":      "INFO",
+		"request_id": "req-1",
+		"user_id":    float64(7),
+		"route":      "/api/users/:id",
+		"status":     float64(200),
+	}
+	for key, value := range expected {
+		if record[key] != value {
+			t.Errorf("%s\n+++ %v\n--- %v", key, record[key], value)
+		}
+	}
+	if logger.RequestID(ctx) != "req-1" {
+		t.Errorf("unexpected request id %q", logger.RequestID(ctx))
+	}
+
+	source, _ := record["source"].(map[string]any)
+	if file, _ := source["file"].(string); !bytes.HasSuffix([]byte(file), []byte("logger_test.go")) {
+		t.Errorf("source is not the caller: %v", source)
+	}
+}
+
+func TestTraceFields(t *testing.T) {
+	if err := logger.Configure("info", logger.FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	logger.SetOutput(buf, logger.FormatJSON)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{
+			0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36,
+		},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	logger.InfoContext(trace.ContextWithSpanContext(context.Background(), sc), "traced")
+	logger.InfoContext(context.Background(), "untraced")
+
+	expectedResults := []struct {
+		traceID any
+		spanID  any
+	}{
+		{"4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7"},
+		{nil, nil},
+	}
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != len(expectedResults) {
+		t.Fatalf("got %d records, %d expected", len(lines), len(expectedResults))
+	}
+	for testNumber, testExpected := range expectedResults {
+		record := map[string]any{}
+		if err := json.Unmarshal(lines[testNumber], &record); err != nil {
+			t.Fatal(err)
+		}
+		if record["trace_id"] != testExpected.traceID || record["span_id"] != testExpected.spanID {
+			t.Errorf("#%d got %v %v", testNumber, record["trace_id"], record["span_id"])
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// Package lru is a size bounded in-memory cache evicting the least recently used entries, entries expire
+// after their time to live
+package lru
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Cache is a LRU cache safe for concurrent use, a zero ttl never expires
+type Cache[V any] struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List
+	items map[string]*list.Element
+	now   func() time.Time
+}
+
+// entry is an element of the list
+type entry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+// New returns a cache of at most size entries, a size lower than 1 is unbounded
+func New[V any](size int) *Cache[V] {
+	return &Cache[V]{
+		size:  size,
+		ll:    list.New(),
+		items: map[string]*list.Element{},
+		now:   time.Now,
+	}
+}
+
+// WithClock replaces the clock of the cache, for tests
+func (c *Cache[V]) WithClock(now func() time.Time) *Cache[V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+
+	return c
+}
+
+// Get returns the value of key and marks it as recently used
+func (c *Cache[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.lookup(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.ll.MoveToFront(c.items[key])
+
+	return e.value, true
+}
+
+// Set stores the value of key for ttl
+func (c *Cache[V]) Set(key string, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.store(key, value, c.expiresAt(ttl))
+}
+
+// SetNX stores the value of key for ttl unless key exists, it reports whether the value was stored
+func (c *Cache[V]) SetNX(key string, value V, ttl time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.lookup(key); ok {
+		return false
+	}
+	c.store(key, value, c.expiresAt(ttl))
+
+	return true
+}
+
+// Update replaces the value of key by the result of fn atomically, the expiration of an existing key is
+// kept and ttl is used for a new one, nothing is stored when fn fails
+func (c *Cache[V]) Update(key string, ttl time.Duration, fn func(value V, ok bool) (V, error)) (V, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.lookup(key)
+	var old V
+	expiresAt := c.expiresAt(ttl)
+	if ok {
+		old = e.value
+		expiresAt = e.expiresAt
+	}
+	value, err := fn(old, ok)
+	if err != nil {
+		return old, err
+	}
+	c.store(key, value, expiresAt)
+
+	return value, nil
+}
+
+// TTL returns the remaining time to live of key, zero when it never expires
+func (c *Cache[V]) TTL(key string) (time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.lookup(key)
+	if !ok {
+		return 0, false
+	}
+	if e.expiresAt.IsZero() {
+		return 0, true
+	}
+
+	return e.expiresAt.Sub(c.now()), true
+}
+
+// Delete removes the keys
+func (c *Cache[V]) Delete(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := c.items[key]; ok {
+			c.remove(el)
+		}
+	}
+}
+
+// Purge removes every entry
+func (c *Cache[V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll.Init()
+	clear(c.items)
+}
+
+// Len returns the number of entries, the expired ones not removed yet included
+func (c *Cache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ll.Len()
+}
+
+// lookup returns the entry of key, an expired entry is removed
+func (c *Cache[V]) lookup(key string) (*entry[V], bool) {
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*entry[V])
+	if !e.expiresAt.IsZero() && !c.now().Before(e.expiresAt) {
+		c.remove(el)
+		return nil, false
+	}
+
+	return e, true
+}
+
+// store sets the entry of key as the most recently used, the least recently used are evicted over the size
+func (c *Cache[V]) store(key string, value V, expiresAt time.Time) {
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*entry[V])
+		e.value = value
+		e.expiresAt = expiresAt
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&entry[V]{key: key, value: value, expiresAt: expiresAt})
+	for c.size > 0 && c.ll.Len() > c.size {
+		c.remove(c.ll.Back())
+	}
+}
+
+// remove deletes the element
+func (c *Cache[V]) remove(el *list.Element) {
+	c.ll.Remove(el)
+	delete(c.items, el.Value.(*entry[V]).key)
+}
+
+// expiresAt returns the expiration of a ttl, zero for no expiration
+func (c *Cache[V]) expiresAt(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+
+	return c.now().Add(ttl)
+}
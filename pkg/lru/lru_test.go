@@ -0,0 +1,98 @@
+package lru_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go-app/pkg/lru"
+)
+
+func TestEviction(t *testing.T) {
+	t.Parallel()
+	c := lru.New[int](2)
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 0)
+	c.Get("a")
+	c.Set("c", 3, 0)
+
+	expectedResults := []struct {
+		key   string
+		value int
+		ok    bool
+	}{
+		{"a", 1, true},
+		{"b", 0, false},
+		{"c", 3, true},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		value, ok := c.Get(testExpected.key)
+		if value != testExpected.value || ok != testExpected.ok {
+			t.Errorf("#%d got %d %v, %d %v expected", testNumber, value, ok, testExpected.value, testExpected.ok)
+		}
+	}
+	if c.Len() != 2 {
+		t.Errorf("got len %d, 2 expected", c.Len())
+	}
+}
+
+func TestExpiration(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1700000000, 0)
+	c := lru.New[string](0).WithClock(func() time.Time { return now })
+	c.Set("session", "x", time.Minute)
+	c.Set("forever", "y", 0)
+
+	if ttl, ok := c.TTL("session"); !ok || ttl != time.Minute {
+		t.Errorf("got ttl %v %v", ttl, ok)
+	}
+	if ttl, ok := c.TTL("forever"); !ok || ttl != 0 {
+		t.Errorf("got ttl %v %v", ttl, ok)
+	}
+	if c.SetNX("session", "z", time.Minute) {
+		t.Error("SetNX replaced a live key")
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := c.Get("session"); ok {
+		t.Error("expired key returned")
+	}
+	if !c.SetNX("session", "z", time.Minute) {
+		t.Error("SetNX did not store an expired key")
+	}
+	if value, ok := c.Get("forever"); !ok || value != "y" {
+		t.Errorf("got %q %v", value, ok)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1700000000, 0)
+	c := lru.New[int](0).WithClock(func() time.Time { return now })
+	incr := func(value int, _ bool) (int, error) { return value + 1, nil }
+
+	for range 3 {
+		if _, err := c.Update("hits", time.Minute, incr); err != nil {
+			t.Fatal(err)
+		}
+		now = now.Add(10 * time.Second)
+	}
+	if value, _ := c.Get("hits"); value != 3 {
+		t.Errorf("got %d, 3 expected", value)
+	}
+	// the expiration of the first update is kept
+	if ttl, _ := c.TTL("hits"); ttl != 30*time.Second {
+		t.Errorf("got ttl %v, 30s expected", ttl)
+	}
+
+	errFail := errors.New("fail")
+	value, err := c.Update("hits", time.Minute, func(int, bool) (int, error) { return 0, errFail })
+	if !errors.Is(err, errFail) || value != 3 {
+		t.Errorf("got %d %v", value, err)
+	}
+	c.Delete("hits")
+	if _, ok := c.Get("hits"); ok {
+		t.Error("deleted key returned")
+	}
+}
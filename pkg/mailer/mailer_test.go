@@ -0,0 +1,184 @@
+package mailer_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"go-app/pkg/mailer"
+)
+
+var templates = fstest.MapFS{
+	"layout.txt":  {Data: []byte(`{{template "content" .}}` + "\n-- {{app}}")},
+	"layout.html": {Data: []byte(`<html><body>{{template "content" .}}</body></html>`)},
+	"en/welcome.txt": {
+		Data: []byte(`{{define "subject"}}Welcome {{.Name}}{{end}}{{define "content"}}Hi {{.Name}}{{end}}`),
+	},
+	"en/welcome.html": {Data: []byte(`{{define "content"}}<p>Hi {{.Name}}</p>{{end}}`)},
+	"vi/welcome.txt": {
+		Data: []byte(`{{define "subject"}}Chào mừng {{.Name}}{{end}}{{define "content"}}Chào {{.Name}}{{end}}`),
+	},
+	"en/text_only.txt":  {Data: []byte(`{{define "subject"}}Plain{{end}}{{define "content"}}Only text{{end}}`)},
+	"pt-BR/welcome.txt": {Data: []byte(`{{define "subject"}}Bem-vindo{{end}}{{define "content"}}Olá{{end}}`)},
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+	tpl, err := mailer.NewTemplates(templates, "en", map[string]any{"app": func() string { return "go-app" }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := map[string]string{"Name": "<Ann>"}
+
+	expectedResults := []struct {
+		name    string
+		locale  string
+		subject string
+		text    string
+		html    string
+	}{
+		{"welcome", "", "Welcome <Ann>", "Hi <Ann>\n-- go-app", "<html><body><p>Hi &lt;Ann&gt;</p></body></html>"},
+		{"welcome", "vi-VN,vi;q=0.9,en;q=0.8", "Chào mừng <Ann>", "Chào <Ann>\n-- go-app", ""},
+		{"welcome", "fr", "Welcome <Ann>", "Hi <Ann>\n-- go-app", "<html><body><p>Hi &lt;Ann&gt;</p></body></html>"},
+		{"welcome", "pt-BR", "Bem-vindo", "Olá\n-- go-app", ""},
+		{"text_only", "vi", "Plain", "Only text\n-- go-app", ""},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		msg, err := tpl.Render(testExpected.name, testExpected.locale, data)
+		if err != nil {
+			t.Errorf("#%d unexpected error %v", testNumber, err)
+			continue
+		}
+		if msg.Subject != testExpected.subject || msg.Text != testExpected.text || msg.HTML != testExpected.html {
+			t.Errorf("#%d got %q %q %q", testNumber, msg.Subject, msg.Text, msg.HTML)
+		}
+	}
+
+	if _, err := tpl.Render("missing", "en", data); !errors.Is(err, mailer.ErrUnknownTemplate) {
+		t.Errorf("got error %v, ErrUnknownTemplate expected", err)
+	}
+}
+
+func TestMessageBytes(t *testing.T) {
+	t.Parallel()
+	long := strings.Repeat("é", 60)
+	msg := &mailer.Message{
+		From:    "hello@example.com",
+		To:      []string{"ann@example.com", "bob@example.com"},
+		Subject: "Đặt lại mật khẩu",
+		Text:    "Hello = world\n" + long,
+		HTML:    `<p style="color:red">` + long + "</p>",
+	}
+	data, err := msg.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil || subject != msg.Subject {
+		t.Errorf("got subject %q %v", subject, err)
+	}
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("got content type %q %v", mediaType, err)
+	}
+
+	expectedParts := []struct {
+		contentType string
+		body        string
+	}{
+		{"text/plain; charset=\"utf-8\"", "Hello = world\r\n" + long},
+		{"text/html; charset=\"utf-8\"", msg.HTML},
+	}
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	for testNumber, testExpected := range expectedParts {
+		part, err := reader.NextPart()
+		if err != nil {
+			t.Fatalf("#%d unexpected error %v", testNumber, err)
+		}
+		body, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatalf("#%d unexpected error %v", testNumber, err)
+		}
+		if part.Header.Get("Content-Type") != testExpected.contentType || string(body) != testExpected.body {
+			t.Errorf("#%d got %q %q", testNumber, part.Header.Get("Content-Type"), body)
+		}
+	}
+	if _, err := reader.NextPart(); !errors.Is(err, io.EOF) {
+		t.Errorf("got error %v, EOF expected", err)
+	}
+	for line := range strings.SplitSeq(string(data), "\r\n") {
+		if len(line) > 78 {
+			t.Errorf("line longer than 78 characters %q", line)
+		}
+	}
+}
+
+func TestMessageAttachments(t *testing.T) {
+	t.Parallel()
+	attachment := bytes.Repeat([]byte{0, 1, 2, 254, 255}, 40)
+	msg := &mailer.Message{
+		From:        "hello@example.com",
+		To:          []string{"ann@example.com"},
+		Cc:          []string{"bob@example.com"},
+		Bcc:         []string{"audit@example.com"},
+		ReplyTo:     "support@example.com",
+		Subject:     "Invoice",
+		Text:        "Your invoice",
+		Attachments: []mailer.Attachment{{Filename: "hóa đơn.pdf", ContentType: "application/pdf", Data: attachment}},
+	}
+	data, err := msg.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Header.Get("Cc") != "bob@example.com" || parsed.Header.Get("Reply-To") != "support@example.com" {
+		t.Errorf("got headers %v", parsed.Header)
+	}
+	if bytes.Contains(data, []byte("audit@example.com")) {
+		t.Error("bcc written to the message")
+	}
+	if got := msg.Recipients(); len(got) != 3 || got[2] != "audit@example.com" {
+		t.Errorf("got recipients %v", got)
+	}
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("got content type %q %v", mediaType, err)
+	}
+
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	part, err := reader.NextPart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body, _ := io.ReadAll(part); string(body) != msg.Text {
+		t.Errorf("got text %q", body)
+	}
+	part, err = reader.NextPart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if part.FileName() != "hóa đơn.pdf" {
+		t.Errorf("got filename %q", part.FileName())
+	}
+	body, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, part))
+	if err != nil || !bytes.Equal(body, attachment) {
+		t.Errorf("got attachment %v %v", body, err)
+	}
+}
@@ -0,0 +1,185 @@
+// Package mailer renders localised mail templates and encodes them as MIME messages
+package mailer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// base64LineLength is the length of the lines of the base64 encoded attachments
+const base64LineLength = 76
+
+// Message is an email with a plain text, an optional html alternative and attachments, Bcc is never written
+// to the headers
+type Message struct {
+	From        string
+	To          []string
+	Cc          []string
+	Bcc         []string
+	ReplyTo     string
+	Subject     string
+	Text        string
+	HTML        string
+	Attachments []Attachment
+	Date        time.Time
+}
+
+// Attachment is a file attached to a message
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// entity is a MIME part, its header and the writer of its encoded body
+type entity struct {
+	header textproto.MIMEHeader
+	write  func(w io.Writer) error
+}
+
+// Recipients returns the addresses the message is delivered to, To, Cc and Bcc
+func (m *Message) Recipients() []string {
+	recipients := make([]string, 0, len(m.To)+len(m.Cc)+len(m.Bcc))
+	recipients = append(recipients, m.To...)
+	recipients = append(recipients, m.Cc...)
+
+	return append(recipients, m.Bcc...)
+}
+
+// Bytes encodes the message, the text and html parts are a multipart/alternative in a multipart/mixed with the
+// attachments when there are some, text is quoted-printable and attachments are base64
+func (m *Message) Bytes() ([]byte, error) {
+	date := m.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	buff := &bytes.Buffer{}
+	buff.WriteString("From: " + m.From + "\r\n")
+	buff.WriteString("To: " + strings.Join(m.To, ", ") + "\r\n")
+	if len(m.Cc) > 0 {
+		buff.WriteString("Cc: " + strings.Join(m.Cc, ", ") + "\r\n")
+	}
+	if m.ReplyTo != "" {
+		buff.WriteString("Reply-To: " + m.ReplyTo + "\r\n")
+	}
+	buff.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", m.Subject) + "\r\n")
+	buff.WriteString("Date: " + date.Format(time.RFC1123Z) + "\r\n")
+	buff.WriteString("MIME-Version: 1.0\r\n")
+
+	body := m.body()
+	if len(m.Attachments) > 0 {
+		parts := []entity{body}
+		for _, a := range m.Attachments {
+			parts = append(parts, attachmentEntity(a))
+		}
+		body = multipartEntity("mixed", parts...)
+	}
+	for _, key := range []string{"Content-Type", "Content-Transfer-Encoding"} {
+		if v := body.header.Get(key); v != "" {
+			buff.WriteString(key + ": " + v + "\r\n")
+		}
+	}
+	buff.WriteString("\r\n")
+	if err := body.write(buff); err != nil {
+		return nil, err
+	}
+
+	return buff.Bytes(), nil
+}
+
+// body returns the text part, or the text and html alternatives
+func (m *Message) body() entity {
+	if m.HTML == "" {
+		return textEntity("text/plain", m.Text)
+	}
+
+	return multipartEntity("alternative", textEntity("text/plain", m.Text), textEntity("text/html", m.HTML))
+}
+
+// textEntity is a quoted-printable utf-8 part
+func textEntity(contentType, body string) entity {
+	return entity{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {contentType + "; charset=\"utf-8\""},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		},
+		write: func(w io.Writer) error {
+			return writeQuotedPrintable(w, body)
+		},
+	}
+}
+
+// multipartEntity is a multipart/subtype of the parts, the boundary is folded on its own header line
+func multipartEntity(subtype string, parts ...entity) entity {
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+
+	return entity{
+		header: textproto.MIMEHeader{
+			"Content-Type": {"multipart/" + subtype + ";\r\n boundary=\"" + boundary + "\""},
+		},
+		write: func(w io.Writer) error {
+			mw := multipart.NewWriter(w)
+			if err := mw.SetBoundary(boundary); err != nil {
+				return err
+			}
+			for _, part := range parts {
+				pw, err := mw.CreatePart(part.header)
+				if err != nil {
+					return err
+				}
+				if err := part.write(pw); err != nil {
+					return err
+				}
+			}
+
+			return mw.Close()
+		},
+	}
+}
+
+// attachmentEntity is a base64 part of the attachment
+func attachmentEntity(a Attachment) entity {
+	contentType := a.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return entity{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(contentType, map[string]string{"name": a.Filename})},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename})},
+			"Content-Transfer-Encoding": {"base64"},
+		},
+		write: func(w io.Writer) error {
+			encoded := base64.StdEncoding.EncodeToString(a.Data)
+			for len(encoded) > 0 {
+				n := min(base64LineLength, len(encoded))
+				if _, err := io.WriteString(w, encoded[:n]+"\r\n"); err != nil {
+					return err
+				}
+				encoded = encoded[n:]
+			}
+
+			return nil
+		},
+	}
+}
+
+// writeQuotedPrintable writes body encoded as quoted-printable with CRLF line endings
+func writeQuotedPrintable(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	if _, err := qp.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n"))); err != nil {
+		return err
+	}
+
+	return qp.Close()
+}
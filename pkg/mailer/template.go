@@ -0,0 +1,135 @@
+package mailer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+
+	"golang.org/x/text/language"
+)
+
+const (
+	// layoutText is the shared layout of the plain text parts, it executes the "content" template
+	layoutText = "layout.txt"
+	// layoutHTML is the shared layout of the html parts, it executes the "content" template
+	layoutHTML = "layout.html"
+)
+
+// ErrUnknownTemplate is returned when no locale has a template of the name
+var ErrUnknownTemplate = errors.New("mailer: unknown template")
+
+// Templates are the mail templates of every locale, <locale>/<name>.txt defines the "subject" and the
+// "content" of the plain text part, the optional <locale>/<name>.html defines the "content" of the html part
+type Templates struct {
+	defaultLocale string
+	text          map[string]*texttemplate.Template
+	html          map[string]*htmltemplate.Template
+}
+
+// NewTemplates parses the layouts and the templates of fsys with funcs, defaultLocale is used when the
+// locale of a mail has no variant of the template
+func NewTemplates(fsys fs.FS, defaultLocale string, funcs map[string]any) (*Templates, error) {
+	layoutTxt, err := texttemplate.New(layoutText).Funcs(funcs).ParseFS(fsys, layoutText)
+	if err != nil {
+		return nil, err
+	}
+	layoutHTM, err := htmltemplate.New(layoutHTML).Funcs(funcs).ParseFS(fsys, layoutHTML)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Templates{
+		defaultLocale: defaultLocale,
+		text:          map[string]*texttemplate.Template{},
+		html:          map[string]*htmltemplate.Template{},
+	}
+	textFiles, err := fs.Glob(fsys, "*/*.txt")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range textFiles {
+		tpl, err := texttemplate.Must(layoutTxt.Clone()).ParseFS(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		if tpl.Lookup("subject") == nil {
+			return nil, fmt.Errorf("mailer: %s does not define a subject", file)
+		}
+		t.text[templateKey(file)] = tpl
+	}
+	htmlFiles, err := fs.Glob(fsys, "*/*.html")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range htmlFiles {
+		tpl, err := htmltemplate.Must(layoutHTM.Clone()).ParseFS(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		t.html[templateKey(file)] = tpl
+	}
+
+	return t, nil
+}
+
+// Render executes the template name in the best variant for locale, an Accept-Language value is accepted
+func (t *Templates) Render(name, locale string, data any) (*Message, error) {
+	var key string
+	for _, candidate := range t.locales(locale) {
+		if _, ok := t.text[candidate+"/"+name]; ok {
+			key = candidate + "/" + name
+			break
+		}
+	}
+	if key == "" {
+		return nil, fmt.Errorf("%w %q", ErrUnknownTemplate, name)
+	}
+
+	text := t.text[key]
+	subject := &bytes.Buffer{}
+	if err := text.ExecuteTemplate(subject, "subject", data); err != nil {
+		return nil, err
+	}
+	body := &bytes.Buffer{}
+	if err := text.ExecuteTemplate(body, layoutText, data); err != nil {
+		return nil, err
+	}
+	msg := &Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    body.String(),
+	}
+
+	if html, ok := t.html[key]; ok {
+		body := &bytes.Buffer{}
+		if err := html.ExecuteTemplate(body, layoutHTML, data); err != nil {
+			return nil, err
+		}
+		msg.HTML = body.String()
+	}
+
+	return msg, nil
+}
+
+// locales returns the locales to try in order, each preferred tag then its base language, then the default
+func (t *Templates) locales(locale string) []string {
+	var locales []string
+	tags, _, _ := language.ParseAcceptLanguage(locale)
+	for _, tag := range tags {
+		locales = append(locales, tag.String())
+		if base, conf := tag.Base(); conf != language.No {
+			locales = append(locales, base.String())
+		}
+	}
+
+	return append(locales, t.defaultLocale)
+}
+
+// templateKey returns <locale>/<name> of a template file
+func templateKey(file string) string {
+	return strings.TrimSuffix(file, path.Ext(file))
+}
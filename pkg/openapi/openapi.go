@@ -0,0 +1,323 @@
+// Package openapi builds an OpenAPI 3.1 document from the routes of an API, the schemas are derived from the
+// json, query and param tags of the request and response types and from their validate tags
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Version is the version of the OpenAPI specification of the documents
+const Version = "3.1.0"
+
+// MediaTypeJSON is the media type of the bodies by default
+const MediaTypeJSON = "application/json"
+
+// pathParam matches the parameters of a route path, :id and the * wildcard
+var pathParam = regexp.MustCompile(`:([^/]+)|\*`)
+
+// Document is an OpenAPI document
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+	Tags       []Tag                `json:"tags,omitempty"`
+
+	reflector *Reflector
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server is a base URL of the API
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// Tag groups operations
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// Components holds the schemas referenced by the operations and the security schemes
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme is a way to authenticate the requests
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// PathItem holds the operations of a path by method
+type PathItem struct {
+	Get     *Operation `json:"get,omitempty"`
+	Put     *Operation `json:"put,omitempty"`
+	Post    *Operation `json:"post,omitempty"`
+	Delete  *Operation `json:"delete,omitempty"`
+	Patch   *Operation `json:"patch,omitempty"`
+	Head    *Operation `json:"head,omitempty"`
+	Options *Operation `json:"options,omitempty"`
+}
+
+// Operation is a method of a path
+type Operation struct {
+	OperationID string                `json:"operationId,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter is a path, query or header parameter of an operation
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is the body of an operation by media type
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response is a response of an operation, its body by media type
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType is the schema of a body
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Route documents a route, Path uses the :param and * syntax of the router. Params is a value of a struct
+// whose param and query tags are the parameters, Body a value of the type of the JSON body. Security names the
+// security scheme required, empty for a public route
+type Route struct {
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	Tags        []string
+	Security    string
+	Params      any
+	Body        any
+	Responses   []RouteResponse
+}
+
+// RouteResponse documents a response of a route, Body is a value of the type of the body, nil for none, or a
+// OneOf of the alternatives. MediaType defaults to JSON, a string body describes a text or binary one
+type RouteResponse struct {
+	Status      string
+	Description string
+	Body        any
+	MediaType   string
+}
+
+// OneOf is a body which is one of the types of its values
+type OneOf []any
+
+// New returns a document describing the API of info
+func New(info Info) *Document {
+	return &Document{
+		OpenAPI:    Version,
+		Info:       info,
+		Paths:      map[string]*PathItem{},
+		Components: Components{Schemas: map[string]*Schema{}},
+		reflector:  NewReflector(),
+	}
+}
+
+// AddSecurityScheme declares the security scheme name
+func (d *Document) AddSecurityScheme(name string, scheme *SecurityScheme) {
+	if d.Components.SecuritySchemes == nil {
+		d.Components.SecuritySchemes = map[string]*SecurityScheme{}
+	}
+	d.Components.SecuritySchemes[name] = scheme
+}
+
+// AddRoute adds the operation of the route, the path parameters missing from Params are strings
+func (d *Document) AddRoute(r Route) {
+	path := Path(r.Path)
+	op := &Operation{
+		OperationID: operationID(r.Method, path),
+		Summary:     r.Summary,
+		Description: r.Description,
+		Tags:        r.Tags,
+		Responses:   map[string]*Response{},
+	}
+	for _, tag := range r.Tags {
+		if !slices.ContainsFunc(d.Tags, func(t Tag) bool { return t.Name == tag }) {
+			d.Tags = append(d.Tags, Tag{Name: tag})
+		}
+	}
+	if r.Security != "" {
+		op.Security = []map[string][]string{{r.Security: {}}}
+	}
+
+	if r.Params != nil {
+		op.Parameters = d.reflector.Parameters(r.Params)
+	}
+	for _, m := range pathParam.FindAllStringSubmatch(r.Path, -1) {
+		name := m[1]
+		if name == "" {
+			name = "path"
+		}
+		if !slices.ContainsFunc(op.Parameters, func(p *Parameter) bool { return p.In == "path" && p.Name == name }) {
+			op.Parameters = append(op.Parameters, &Parameter{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+	}
+	if r.Body != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{MediaTypeJSON: {Schema: d.reflector.Schema(r.Body)}},
+		}
+	}
+
+	for _, res := range r.Responses {
+		op.Responses[res.Status] = d.response(res)
+	}
+
+	item, ok := d.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		d.Paths[path] = item
+	}
+	switch r.Method {
+	case http.MethodGet:
+		item.Get = op
+	case http.MethodPut:
+		item.Put = op
+	case http.MethodPost:
+		item.Post = op
+	case http.MethodDelete:
+		item.Delete = op
+	case http.MethodPatch:
+		item.Patch = op
+	case http.MethodHead:
+		item.Head = op
+	case http.MethodOptions:
+		item.Options = op
+	}
+	d.Components.Schemas = d.reflector.Schemas
+}
+
+// Operation returns the operation of method on the path of the router, nil when it is not documented
+func (d *Document) Operation(method, path string) *Operation {
+	item, ok := d.Paths[Path(path)]
+	if !ok {
+		return nil
+	}
+	switch method {
+	case http.MethodGet:
+		return item.Get
+	case http.MethodPut:
+		return item.Put
+	case http.MethodPost:
+		return item.Post
+	case http.MethodDelete:
+		return item.Delete
+	case http.MethodPatch:
+		return item.Patch
+	case http.MethodHead:
+		return item.Head
+	case http.MethodOptions:
+		return item.Options
+	default:
+		return nil
+	}
+}
+
+// Path converts a path of the router to a path template, :id is {id} and the * wildcard is {path}
+func Path(path string) string {
+	return pathParam.ReplaceAllStringFunc(path, func(m string) string {
+		if m == "*" {
+			return "{path}"
+		}
+
+		return "{" + m[1:] + "}"
+	})
+}
+
+// response returns the response of a route
+func (d *Document) response(res RouteResponse) *Response {
+	description := res.Description
+	if description == "" {
+		description = statusText(res.Status)
+	}
+	out := &Response{Description: description}
+	if res.Body == nil {
+		return out
+	}
+
+	mediaType := res.MediaType
+	if mediaType == "" {
+		mediaType = MediaTypeJSON
+	}
+	var schema *Schema
+	switch body := res.Body.(type) {
+	case OneOf:
+		schema = &Schema{}
+		for _, v := range body {
+			schema.OneOf = append(schema.OneOf, d.reflector.Schema(v))
+		}
+	default:
+		schema = d.reflector.Schema(body)
+	}
+	out.Content = map[string]*MediaType{mediaType: {Schema: schema}}
+
+	return out
+}
+
+// operationID is the method and the words of the path in camel case, GET /users/{id} is getUsersId
+func operationID(method, path string) string {
+	id := strings.ToLower(method)
+	for word := range strings.FieldsFuncSeq(path, func(r rune) bool {
+		return r == '/' || r == '{' || r == '}' || r == '-' || r == '_' || r == '.'
+	}) {
+		id += strings.ToUpper(word[:1]) + word[1:]
+	}
+
+	return id
+}
+
+// statusText is the reason phrase of a status code, the default response is an error
+func statusText(status string) string {
+	if status == "default" {
+		return "Error"
+	}
+	code, err := strconv.Atoi(status)
+	if err != nil || http.StatusText(code) == "" {
+		return status
+	}
+
+	return http.StatusText(code)
+}
@@ -0,0 +1,175 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"go-app/pkg/openapi"
+)
+
+type page[T any] struct {
+	Data []T `json:"data"`
+}
+
+type item struct {
+	ID        uint            `json:"id"`
+	Name      string          `json:"name,omitempty"`
+	Parent    *item           `json:"parent"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type listQuery struct {
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+	Status string `query:"status" validate:"required,oneof=open closed"`
+}
+
+type itemRequest struct {
+	Email  string   `json:"email" validate:"required,email,max=255"`
+	Tags   []string `json:"tags" validate:"required,min=1,dive,required,max=20"`
+	Level  int      `json:"level" validate:"gte=1,lt=10"`
+	Active *bool    `json:"active"`
+	Secret string   `json:"-"`
+}
+
+func TestPath(t *testing.T) {
+	t.Parallel()
+	expectedResults := []struct {
+		path     string
+		expected string
+	}{
+		{"/api/users", "/api/users"},
+		{"/api/users/:id", "/api/users/{id}"},
+		{"/api/roles/:id/permissions/:permission", "/api/roles/{id}/permissions/{permission}"},
+		{"/api/docs/*", "/api/docs/{path}"},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		if got := openapi.Path(testExpected.path); got != testExpected.expected {
+			t.Errorf("#%d got %q, %q expected", testNumber, got, testExpected.expected)
+		}
+	}
+}
+
+func TestAddRoute(t *testing.T) {
+	t.Parallel()
+	doc := openapi.New(openapi.Info{Title: "test", Version: "1"})
+	doc.AddRoute(openapi.Route{
+		Method:    http.MethodGet,
+		Path:      "/items/:id",
+		Tags:      []string{"Items"},
+		Security:  "bearer",
+		Params:    listQuery{},
+		Responses: []openapi.RouteResponse{{Status: "200", Body: page[item]{}}},
+	})
+	doc.AddRoute(openapi.Route{
+		Method: http.MethodPost,
+		Path:   "/items",
+		Body:   itemRequest{},
+		Responses: []openapi.RouteResponse{
+			{Status: "201", Body: openapi.OneOf{item{}, page[item]{}}},
+			{Status: "204"},
+		},
+	})
+
+	get := doc.Operation(http.MethodGet, "/items/:id")
+	if get == nil || get.OperationID != "getItemsId" || len(get.Security) != 1 {
+		t.Fatalf("got %+v", get)
+	}
+	if doc.Operation(http.MethodDelete, "/items/:id") != nil {
+		t.Error("got an operation of an undocumented method")
+	}
+	expectedParameters := []struct {
+		name     string
+		in       string
+		required bool
+		enum     int
+	}{
+		{"page", "query", false, 0},
+		{"status", "query", true, 2},
+		{"id", "path", true, 0},
+	}
+	if len(get.Parameters) != len(expectedParameters) {
+		t.Fatalf("got %d parameters, %d expected", len(get.Parameters), len(expectedParameters))
+	}
+	for testNumber, testExpected := range expectedParameters {
+		p := get.Parameters[testNumber]
+		if p.Name != testExpected.name || p.In != testExpected.in || p.Required != testExpected.required ||
+			len(p.Schema.Enum) != testExpected.enum {
+			t.Errorf("#%d got %+v", testNumber, p)
+		}
+	}
+	if ref := get.Responses["200"].Content[openapi.MediaTypeJSON].Schema.Ref; ref != "#/components/schemas/pageitem" {
+		t.Errorf("got ref %q", ref)
+	}
+
+	post := doc.Operation(http.MethodPost, "/items")
+	if len(post.Responses["201"].Content[openapi.MediaTypeJSON].Schema.OneOf) != 2 {
+		t.Errorf("got %+v", post.Responses["201"])
+	}
+	if post.Responses["204"].Description != "No Content" || post.Responses["204"].Content != nil {
+		t.Errorf("got %+v", post.Responses["204"])
+	}
+	if len(doc.Tags) != 1 || doc.Tags[0].Name != "Items" {
+		t.Errorf("got tags %+v", doc.Tags)
+	}
+}
+
+func TestSchema(t *testing.T) {
+	t.Parallel()
+	doc := openapi.New(openapi.Info{Title: "test", Version: "1"})
+	doc.AddRoute(openapi.Route{
+		Method:    http.MethodPost,
+		Path:      "/items",
+		Body:      itemRequest{},
+		Responses: []openapi.RouteResponse{{Status: "200", Body: item{}}},
+	})
+
+	b, err := json.Marshal(doc.Components.Schemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schemas map[string]any
+	if err := json.Unmarshal(b, &schemas); err != nil {
+		t.Fatal(err)
+	}
+	expectedResults := []struct {
+		schema   string
+		property string
+		expected string
+	}{
+		{"item", "id", `{"minimum":0,"type":"integer"}`},
+		{"item", "parent", `{"oneOf":[{"$ref":"#/components/schemas/item"},{"type":"null"}]}`},
+		{"item", "payload", `{}`},
+		{"item", "created_at", `{"format":"date-time","type":"string"}`},
+		{"itemRequest", "email", `{"format":"email","maxLength":255,"type":"string"}`},
+		{"itemRequest", "tags", `{"items":{"maxLength":20,"type":"string"},"minItems":1,"type":"array"}`},
+		{"itemRequest", "level", `{"exclusiveMaximum":10,"format":"int64","minimum":1,"type":"integer"}`},
+		{"itemRequest", "active", `{"type":["boolean","null"]}`},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		properties := schemas[testExpected.schema].(map[string]any)["properties"].(map[string]any)
+		got, _ := json.Marshal(properties[testExpected.property])
+		if string(got) != testExpected.expected {
+			t.Errorf("#%d got %s, %s expected", testNumber, got, testExpected.expected)
+		}
+	}
+
+	expectedRequired := map[string][]any{
+		"item":        {"id", "payload", "created_at"},
+		"itemRequest": {"email", "tags"},
+	}
+	for schema, expected := range expectedRequired {
+		properties := schemas[schema].(map[string]any)
+		if !reflect.DeepEqual(properties["required"], expected) {
+			t.Errorf("%s got required %v, %v expected", schema, properties["required"], expected)
+		}
+		if _, ok := properties["properties"].(map[string]any)["Secret"]; ok {
+			t.Errorf("%s got a field left out of JSON", schema)
+		}
+	}
+}
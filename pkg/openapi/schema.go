@@ -0,0 +1,366 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Schema is a JSON schema of the 2020-12 dialect of OpenAPI 3.1, Type is a string or a list of strings
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 any                `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	ContentEncoding      string             `json:"contentEncoding,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+}
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+)
+
+// Reflector derives the schemas of Go types, the named structs are components referenced by name
+type Reflector struct {
+	Schemas map[string]*Schema
+
+	types map[string]reflect.Type
+}
+
+// NewReflector returns a reflector without components
+func NewReflector() *Reflector {
+	return &Reflector{Schemas: map[string]*Schema{}, types: map[string]reflect.Type{}}
+}
+
+// Schema returns the schema of the type of v
+func (r *Reflector) Schema(v any) *Schema {
+	return r.schema(reflect.TypeOf(v))
+}
+
+// Parameters returns the path and query parameters of the fields of the struct v with a param or a query tag,
+// the fields of the embedded structs included
+func (r *Reflector) Parameters(v any) []*Parameter {
+	var params []*Parameter
+	for f := range fields(reflect.TypeOf(v)) {
+		in, name := "query", f.Tag.Get("query")
+		if param := f.Tag.Get("param"); param != "" {
+			in, name = "path", param
+		}
+		if name == "" || name == "-" {
+			continue
+		}
+
+		t := f.Type
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		s := r.schema(t)
+		required := applyRules(s, t, f.Tag.Get("validate"))
+		params = append(params, &Parameter{Name: name, In: in, Required: required || in == "path", Schema: s})
+	}
+
+	return params
+}
+
+// schema returns the schema of t, a pointer is nullable
+func (r *Reflector) schema(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(r.schema(t.Elem()))
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		zero := 0.0
+		return &Schema{Type: "integer", Minimum: &zero}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", ContentEncoding: "base64"}
+		}
+		return &Schema{Type: "array", Items: r.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.object(t)
+		}
+		return r.component(t)
+	default:
+		return &Schema{}
+	}
+}
+
+// component registers the schema of the named struct t once and returns a reference to it
+func (r *Reflector) component(t reflect.Type) *Schema {
+	name := schemaName(t)
+	if other, ok := r.types[name]; ok && other != t {
+		// Two packages declare a struct of the same name
+		name = packageName(t) + "." + name
+	}
+	ref := &Schema{Ref: "#/components/schemas/" + name}
+	if _, ok := r.types[name]; ok {
+		return ref
+	}
+
+	// Registered before its fields so a struct referring to itself ends the recursion
+	r.types[name] = t
+	r.Schemas[name] = r.object(t)
+
+	return ref
+}
+
+// object returns the schema of the struct t, its properties are the fields encoded in JSON
+func (r *Reflector) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for f := range fields(t) {
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+
+		prop := r.schema(f.Type)
+		rules := f.Tag.Get("validate")
+		target := f.Type
+		if target.Kind() == reflect.Pointer {
+			target = target.Elem()
+		}
+		// A field is required when its rules say so, a field without rules when it is always encoded
+		required := applyRules(prop, target, rules)
+		if rules == "" {
+			required = !strings.Contains(opts, "omitempty") && f.Type.Kind() != reflect.Pointer
+		}
+		if required {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = prop
+	}
+
+	return s
+}
+
+// fields yields the exported fields of the struct t, the fields of its embedded structs without a json name
+// in its place
+func fields(t reflect.Type) func(yield func(reflect.StructField) bool) {
+	return func(yield func(reflect.StructField) bool) {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return
+		}
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if f.Anonymous && f.Tag.Get("json") == "" {
+				for embedded := range fields(f.Type) {
+					if !yield(embedded) {
+						return
+					}
+				}
+				continue
+			}
+			if !f.IsExported() {
+				continue
+			}
+			if !yield(f) {
+				return
+			}
+		}
+	}
+}
+
+// applyRules applies the validate rules to the schema of the type t and reports whether they require a value,
+// the rules following dive apply to the items. Rules without an equivalent are left out
+func applyRules(s *Schema, t reflect.Type, rules string) bool {
+	required := false
+	split := splitRules(rules)
+	for i, rule := range split {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = true
+		case "dive":
+			if s.Items != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+				applyRules(s.Items, t.Elem(), strings.Join(split[i+1:], ","))
+			}
+			return required
+		case "email":
+			s.Format = "email"
+		case "url", "uri", "http_url":
+			s.Format = "uri"
+		case "uuid", "uuid4":
+			s.Format = "uuid"
+		case "ipv4", "ipv6", "hostname":
+			s.Format = name
+		case "min", "gte":
+			setBound(s, t, param, true, false)
+		case "max", "lte":
+			setBound(s, t, param, false, false)
+		case "gt":
+			setBound(s, t, param, true, true)
+		case "lt":
+			setBound(s, t, param, false, true)
+		case "len":
+			setBound(s, t, param, true, false)
+			setBound(s, t, param, false, false)
+		case "oneof":
+			for value := range strings.FieldsSeq(param) {
+				s.Enum = append(s.Enum, enumValue(t, value))
+			}
+		}
+	}
+
+	return required
+}
+
+// splitRules returns the rules of a validate tag, the alternatives of a rule with | have no equivalent
+func splitRules(rules string) []string {
+	var out []string
+	for rule := range strings.SplitSeq(rules, ",") {
+		if rule != "" && !strings.Contains(rule, "|") {
+			out = append(out, rule)
+		}
+	}
+
+	return out
+}
+
+// setBound sets the lower or the upper bound param of the length, the items or the value by the kind of t, an
+// exclusive bound of a length is the next integer
+func setBound(s *Schema, t reflect.Type, param string, lower, exclusive bool) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	var minimum, maximum **int
+	switch t.Kind() {
+	case reflect.String:
+		minimum, maximum = &s.MinLength, &s.MaxLength
+	case reflect.Slice, reflect.Array, reflect.Map:
+		minimum, maximum = &s.MinItems, &s.MaxItems
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		switch {
+		case lower && exclusive:
+			s.ExclusiveMinimum = &n
+		case lower:
+			s.Minimum = &n
+		case exclusive:
+			s.ExclusiveMaximum = &n
+		default:
+			s.Maximum = &n
+		}
+		return
+	default:
+		return
+	}
+
+	length := int(n)
+	switch {
+	case lower && exclusive:
+		length++
+	case exclusive:
+		length--
+	}
+	if lower {
+		*minimum = &length
+	} else {
+		*maximum = &length
+	}
+}
+
+// enumValue returns the value of a oneof rule in the type of the field
+func enumValue(t reflect.Type, value string) any {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, err := strconv.ParseUint(value, 10, 64); err == nil {
+			return n
+		}
+	}
+
+	return value
+}
+
+// nullable returns the schema s accepting null
+func nullable(s *Schema) *Schema {
+	switch typ := s.Type.(type) {
+	case string:
+		s.Type = []string{typ, "null"}
+		return s
+	case nil:
+		if s.Ref == "" {
+			// The empty schema accepts null already
+			return s
+		}
+	}
+
+	return &Schema{OneOf: []*Schema{s, {Type: "null"}}}
+}
+
+// schemaName is the name of the struct t, the type arguments of a generic struct are appended to its name,
+// PaginatedResponse[dto.UserResponse] is PaginatedResponseUserResponse
+func schemaName(t reflect.Type) string {
+	name, args, ok := strings.Cut(t.Name(), "[")
+	if !ok {
+		return name
+	}
+	for arg := range strings.SplitSeq(strings.TrimSuffix(args, "]"), ",") {
+		arg = arg[strings.LastIndexAny(arg, "./")+1:]
+		name += strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, arg)
+	}
+
+	return name
+}
+
+// packageName is the last element of the package path of t
+func packageName(t reflect.Type) string {
+	path := t.PkgPath()
+
+	return path[strings.LastIndexByte(path, '/')+1:]
+}
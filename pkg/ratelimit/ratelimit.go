@@ -0,0 +1,47 @@
+// Package ratelimit is a token bucket rate limiter computed with the generic cell rate algorithm, the state of a
+// key is a single time, its theoretical arrival time, so it is stored atomically in memory or in redis
+package ratelimit
+
+import (
+	"time"
+)
+
+// Result is the outcome of a request, Remaining requests are allowed right after it, RetryAfter is the wait
+// before the next request is allowed when it is denied and ResetAfter the wait before the full limit is
+// available again
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	ResetAfter time.Duration
+}
+
+// Take spends a token of the bucket of limit tokens refilled over period for a request at now, tat is the
+// theoretical arrival time of the key, zero for a new key, and the new one is returned, it expires after
+// ResetAfter. limit and period must be positive, the interval between two tokens is truncated to whole
+// microseconds, the precision of the clock of redis
+func Take(now, tat time.Time, limit int, period time.Duration) (Result, time.Time) {
+	interval := (period / time.Duration(limit)).Truncate(time.Microsecond)
+	if tat.Before(now) {
+		tat = now
+	}
+
+	allowAt := tat.Add(interval - period)
+	if now.Before(allowAt) {
+		return Result{
+			Limit:      limit,
+			RetryAfter: allowAt.Sub(now),
+			ResetAfter: tat.Sub(now),
+		}, tat
+	}
+
+	tat = tat.Add(interval)
+
+	return Result{
+		Allowed:    true,
+		Limit:      limit,
+		Remaining:  min(limit-1, int(now.Sub(allowAt)/interval)),
+		ResetAfter: tat.Sub(now),
+	}, tat
+}
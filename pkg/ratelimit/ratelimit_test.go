@@ -0,0 +1,42 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"go-app/pkg/ratelimit"
+)
+
+func TestTake(t *testing.T) {
+	t.Parallel()
+	start := time.Unix(1700000000, 0)
+	var tat time.Time
+
+	expectedResults := []struct {
+		elapsed    time.Duration
+		allowed    bool
+		remaining  int
+		retryAfter time.Duration
+		resetAfter time.Duration
+	}{
+		{0, true, 2, 0, 20 * time.Second},
+		{0, true, 1, 0, 40 * time.Second},
+		{0, true, 0, 0, time.Minute},
+		{0, false, 0, 20 * time.Second, time.Minute},
+		{15 * time.Second, false, 0, 5 * time.Second, 45 * time.Second},
+		{20 * time.Second, true, 0, 0, time.Minute},
+		{2 * time.Minute, true, 2, 0, 20 * time.Second},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		var res ratelimit.Result
+		res, tat = ratelimit.Take(start.Add(testExpected.elapsed), tat, 3, time.Minute)
+		if res.Allowed != testExpected.allowed || res.Remaining != testExpected.remaining ||
+			res.RetryAfter != testExpected.retryAfter || res.ResetAfter != testExpected.resetAfter {
+			t.Errorf("#%d got %+v", testNumber, res)
+		}
+		if res.Limit != 3 {
+			t.Errorf("#%d got limit %d, 3 expected", testNumber, res.Limit)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //#nosec
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// secretSize is 160 bits as recommended by RFC 4226
+	secretSize = 20
+)
+
+var (
+	errInvalidSecret = errors.New("totp secret is not valid base32")
+	errInvalidOpts   = errors.New("totp period and digits must be greater than 0")
+
+	b32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// Opts is options used to generate and validate codes
+type Opts struct {
+	// Period is the time step in seconds
+	Period uint
+	// Digits is the length of the code
+	Digits int
+	// Skew is the number of periods before and after the current one that are accepted
+	Skew uint
+}
+
+// DefaultOpts is options compatible with Google Authenticator and most apps
+var DefaultOpts = Opts{
+	Period: 30,
+	Digits: 6,
+	Skew:   1,
+}
+
+// GenerateSecret returns a random base32 encoded secret
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return b32.EncodeToString(b), nil
+}
+
+// GenerateCode returns the code of the secret at the time with default options
+func GenerateCode(secret string, t time.Time) (string, error) {
+	return GenerateCodeCustom(secret, t, DefaultOpts)
+}
+
+// GenerateCodeCustom returns the code of the secret at the time
+func GenerateCodeCustom(secret string, t time.Time, opts Opts) (string, error) {
+	if opts.Period == 0 || opts.Digits <= 0 {
+		return "", errInvalidOpts
+	}
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return hotp(key, uint64(t.Unix())/uint64(opts.Period), opts.Digits), nil
+}
+
+// Validate reports whether the code is valid for the secret at the time with default options
+func Validate(secret, code string, t time.Time) bool {
+	valid, err := ValidateCustom(secret, code, t, DefaultOpts)
+
+	return err == nil && valid
+}
+
+// ValidateCustom reports whether the code is valid for the secret at the time
+func ValidateCustom(secret, code string, t time.Time, opts Opts) (bool, error) {
+	if opts.Period == 0 || opts.Digits <= 0 {
+		return false, errInvalidOpts
+	}
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return false, err
+	}
+	if len(code) != opts.Digits {
+		return false, nil
+	}
+
+	counter := uint64(t.Unix()) / uint64(opts.Period)
+	valid := false
+	for i := -int64(opts.Skew); i <= int64(opts.Skew); i++ {
+		c := int64(counter) + i
+		if c < 0 {
+			continue
+		}
+		// Compare every candidate to keep the time constant
+		if subtle.ConstantTimeCompare([]byte(hotp(key, uint64(c), opts.Digits)), []byte(code)) == 1 {
+			valid = true
+		}
+	}
+
+	return valid, nil
+}
+
+// URI returns the otpauth URI used to enroll the secret in authenticator apps
+func URI(issuer, account, secret string) string {
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(DefaultOpts.Digits))
+	v.Set("period", fmt.Sprint(DefaultOpts.Period))
+
+	return "otpauth://totp/" + label + "?" + v.Encode()
+}
+
+// hotp implements RFC 4226 with HMAC-SHA1
+func hotp(key []byte, counter uint64, digits int) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for range digits {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", digits, value%mod)
+}
+
+// decodeSecret decodes base32 secret, spaces and lower case are allowed
+func decodeSecret(secret string) ([]byte, error) {
+	s := strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	s = strings.TrimRight(s, "=")
+	key, err := b32.DecodeString(s)
+	if err != nil || len(key) == 0 {
+		return nil, errInvalidSecret
+	}
+
+	return key, nil
+}
@@ -0,0 +1,91 @@
+package totp_test
+
+import (
+	"go-app/pkg/totp"
+	"testing"
+	"time"
+)
+
+// secret is base32 of "12345678901234567890" from RFC 6238 Appendix B
+const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+type ExpectedCodeResult struct {
+	unix int64
+	code string
+}
+
+var expectedCodeResults = []ExpectedCodeResult{
+	{unix: 59, code: "94287082"},
+	{unix: 1111111109, code: "07081804"},
+	{unix: 1111111111, code: "14050471"},
+	{unix: 1234567890, code: "89005924"},
+	{unix: 2000000000, code: "69279037"},
+	{unix: 20000000000, code: "65353130"},
+}
+
+var rfcOpts = totp.Opts{Period: 30, Digits: 8}
+
+func TestGenerateCodeCustom(t *testing.T) {
+	t.Parallel()
+	for testNumber, testExpected := range expectedCodeResults {
+		result, err := totp.GenerateCodeCustom(secret, time.Unix(testExpected.unix, 0), rfcOpts)
+		if err != nil {
+			t.Fatalf("#%d unexpected error: %v", testNumber, err)
+		}
+
+		if result != testExpected.code {
+			t.Errorf("#%d (%d)\n+++ %s\n--- %s", testNumber, testExpected.unix, result, testExpected.code)
+		}
+	}
+}
+
+func TestValidateCustom(t *testing.T) {
+	t.Parallel()
+	for testNumber, testExpected := range expectedCodeResults {
+		at := time.Unix(testExpected.unix, 0)
+
+		if valid, _ := totp.ValidateCustom(secret, testExpected.code, at, rfcOpts); !valid {
+			t.Errorf("#%d (%d) expected code %s to be valid", testNumber, testExpected.unix, testExpected.code)
+		}
+
+		// The code of the previous period is rejected without skew
+		late := at.Add(time.Duration(rfcOpts.Period) * time.Second)
+		if valid, _ := totp.ValidateCustom(secret, testExpected.code, late, rfcOpts); valid {
+			t.Errorf("#%d (%d) expected code %s to be expired", testNumber, testExpected.unix, testExpected.code)
+		}
+	}
+}
+
+func TestValidateSkew(t *testing.T) {
+	t.Parallel()
+	newSecret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	code, err := totp.GenerateCode(newSecret, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !totp.Validate(newSecret, code, now.Add(30*time.Second)) {
+		t.Errorf("expected code of previous period to be accepted")
+	}
+	if totp.Validate(newSecret, code, now.Add(90*time.Second)) {
+		t.Errorf("expected code older than skew to be rejected")
+	}
+	if totp.Validate("not base32!", code, now) {
+		t.Errorf("expected invalid secret to be rejected")
+	}
+}
+
+func TestURI(t *testing.T) {
+	t.Parallel()
+	result := totp.URI("Go App", "admin@example.com", secret)
+	expected := "otpauth://totp/Go%20App:admin@example.com?algorithm=SHA1&digits=6&issuer=Go+App&period=30&secret=" + secret
+
+	if result != expected {
+		t.Errorf("(%s)\n+++ %s\n--- %s", "TestURI", result, expected)
+	}
+}
@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"time"
+)
+
+// Backoff is the exponential wait after the attempts failed, base after the first one doubled on every other
+// attempt up to limit
+func Backoff(base, limit time.Duration, attempts int) time.Duration {
+	d := base
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= limit {
+			return limit
+		}
+	}
+
+	return min(d, limit)
+}
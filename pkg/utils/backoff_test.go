@@ -0,0 +1,30 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"go-app/pkg/utils"
+)
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+	expectedResults := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{0, time.Second * 10},
+		{1, time.Second * 10},
+		{2, time.Second * 20},
+		{3, time.Second * 40},
+		{6, time.Second * 320},
+		{7, time.Minute * 10},
+		{1000, time.Minute * 10},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		if got := utils.Backoff(time.Second*10, time.Minute*10, testExpected.attempts); got != testExpected.expected {
+			t.Errorf("#%d got %v, %v expected", testNumber, got, testExpected.expected)
+		}
+	}
+}
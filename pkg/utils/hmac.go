@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// HMACSHA256 is a function to sign the string with the key using HMAC-SHA256 algorithm
+func HMACSHA256(key, s string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(s))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// HMACEqual compares two signatures in constant time
+func HMACEqual(a, b string) bool {
+	return hmac.Equal([]byte(a), []byte(b))
+}
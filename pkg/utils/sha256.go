@@ -0,0 +1,13 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// SHA256Hash is a function to hash the string using SHA-256 algorithm
+func SHA256Hash(s string) string {
+	hash := sha256.Sum256([]byte(s))
+
+	return hex.EncodeToString(hash[:])
+}
@@ -2,27 +2,63 @@ package validate
 
 import (
 	"errors"
+	"reflect"
 	"strings"
 
 	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/vi"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 	et "github.com/go-playground/validator/v10/translations/en"
+	vt "github.com/go-playground/validator/v10/translations/vi"
+	"golang.org/x/text/language"
 )
 
-var (
-	errTranslateNotFound = errors.New("translator not found")
-	translationOverride  = map[string]string{}
-)
+// fallbackLocale is the locale of the messages when no preferred locale has translations
+const fallbackLocale = "en"
 
 // CustomValidate is struct used to validate
 type CustomValidate struct {
 	validate *validator.Validate
+	uni      *ut.UniversalTranslator
+}
+
+// FieldError is the messages of the rules failed by a field, Field is its path in the JSON of the request
+type FieldError struct {
+	Field    string
+	Messages []string
+}
+
+// ValidationError is returned by Validate when fields break their rules, its message joins the messages of
+// the fields in English with ;
+type ValidationError struct {
+	errs validator.ValidationErrors
+	root reflect.Type
+	uni  *ut.UniversalTranslator
 }
 
-// NewValidate is function that return new validate
+// NewValidate is function that return new validate, the fields are named by their json, query or param tag
 func NewValidate() *CustomValidate {
-	return &CustomValidate{validate: validator.New()}
+	validate := validator.New()
+	validate.RegisterTagNameFunc(fieldName)
+
+	enLocale := en.New()
+	uni := ut.New(enLocale, enLocale, vi.New())
+	enTrans, _ := uni.GetTranslator("en")
+	_ = et.RegisterDefaultTranslations(validate, enTrans)
+	viTrans, _ := uni.GetTranslator("vi")
+	_ = vt.RegisterDefaultTranslations(validate, viTrans)
+
+	v := &CustomValidate{validate: validate, uni: uni}
+	v.RegisterTranslationOverride(map[string]string{
+		"required": "{0} must have a value!",
+		"email":    "{0} invalid email!",
+		"number":   "{0} is not number!",
+		"min":      "{0} is less than min!",
+		"max":      "{0} is greater than max!",
+	})
+
+	return v
 }
 
 // RegisterAlias is used to register list of alias
@@ -32,87 +68,121 @@ func (v *CustomValidate) RegisterAlias(list map[string]string) {
 	}
 }
 
-// RegisterTranslationOverride is used to register list of translation override
-func (*CustomValidate) RegisterTranslationOverride(list map[string]string) {
+// RegisterTranslationOverride is used to register list of translation override of the English messages, {0} is
+// the field and {1} the param of the rule
+func (v *CustomValidate) RegisterTranslationOverride(list map[string]string) {
+	trans, _ := v.uni.GetTranslator(fallbackLocale)
 	for tag, translation := range list {
-		translationOverride[tag] = translation
+		_ = v.validate.RegisterTranslation(tag, trans, func(ut ut.Translator) error {
+			return ut.Add(tag, translation, true)
+		}, func(ut ut.Translator, fe validator.FieldError) string {
+			t, _ := ut.T(tag, fe.Field(), fe.Param())
+
+			return t
+		})
 	}
 }
 
-// Validate is used to validate interface
+// Validate is used to validate interface, a *ValidationError lists the invalid fields
 func (v *CustomValidate) Validate(u interface{}) error {
-	translator := en.New()
-	uni := ut.New(translator, translator)
-	trans, found := uni.GetTranslator("en")
-	if !found {
-		return errTranslateNotFound
+	err := v.validate.Struct(u)
+	validationErrs := validator.ValidationErrors{}
+	if !errors.As(err, &validationErrs) {
+		return err
 	}
-	_ = et.RegisterDefaultTranslations(v.validate, trans)
 
-	_ = v.validate.RegisterTranslation("required", trans, func(ut ut.Translator) error {
-		return ut.Add("required", "{0} must have a value!", true)
-	}, func(ut ut.Translator, fe validator.FieldError) string {
-		t, _ := ut.T("required", fe.Field())
-
-		return t
-	})
-
-	_ = v.validate.RegisterTranslation("email", trans, func(ut ut.Translator) error {
-		return ut.Add("email", "{0} invalid email!", true)
-	}, func(ut ut.Translator, fe validator.FieldError) string {
-		t, _ := ut.T("email", fe.Field())
+	return &ValidationError{errs: validationErrs, root: reflect.TypeOf(u), uni: v.uni}
+}
 
-		return t
-	})
+// Error is error interface implementation.
+func (e *ValidationError) Error() string {
+	messages := []string{}
+	for _, field := range e.Fields(fallbackLocale) {
+		messages = append(messages, field.Messages...)
+	}
 
-	_ = v.validate.RegisterTranslation("number", trans, func(ut ut.Translator) error {
-		return ut.Add("number", "{0} is not number!", true)
-	}, func(ut ut.Translator, fe validator.FieldError) string {
-		t, _ := ut.T("number", fe.Field())
+	return strings.Join(messages, ";")
+}
 
-		return t
-	})
+// Fields returns the invalid fields in their order with their messages translated in the best locale for
+// locale, an Accept-Language value is accepted
+func (e *ValidationError) Fields(locale string) []FieldError {
+	trans := e.translator(locale)
+	fields := []FieldError{}
+	index := map[string]int{}
+	for _, fe := range e.errs {
+		path := fieldPath(e.root, fe)
+		i, ok := index[path]
+		if !ok {
+			i = len(fields)
+			index[path] = i
+			fields = append(fields, FieldError{Field: path})
+		}
+		fields[i].Messages = append(fields[i].Messages, fe.Translate(trans))
+	}
 
-	_ = v.validate.RegisterTranslation("min", trans, func(ut ut.Translator) error {
-		return ut.Add("min", "{0} is less than min!", true)
-	}, func(ut ut.Translator, fe validator.FieldError) string {
-		t, _ := ut.T("min", fe.Field())
+	return fields
+}
 
-		return t
-	})
+// translator returns the translator of the first preferred locale or its base language with translations,
+// the English one without
+func (e *ValidationError) translator(locale string) ut.Translator {
+	var locales []string
+	tags, _, _ := language.ParseAcceptLanguage(locale)
+	for _, tag := range tags {
+		locales = append(locales, strings.ReplaceAll(tag.String(), "-", "_"))
+		if base, conf := tag.Base(); conf != language.No {
+			locales = append(locales, base.String())
+		}
+	}
+	trans, _ := e.uni.FindTranslator(append(locales, fallbackLocale)...)
 
-	_ = v.validate.RegisterTranslation("max", trans, func(ut ut.Translator) error {
-		return ut.Add("max", "{0} is greater than max!", true)
-	}, func(ut ut.Translator, fe validator.FieldError) string {
-		t, _ := ut.T("max", fe.Field())
+	return trans
+}
 
-		return t
-	})
+// fieldName names a field by its json, query or param tag, empty for its Go name
+func fieldName(f reflect.StructField) string {
+	for _, key := range []string{"json", "query", "param"} {
+		name, _, _ := strings.Cut(f.Tag.Get(key), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
 
-	if len(translationOverride) > 0 {
-		for tag, translation := range translationOverride {
-			_ = v.validate.RegisterTranslation(tag, trans, func(ut ut.Translator) error {
-				return ut.Add(tag, translation, true)
-			}, func(ut ut.Translator, fe validator.FieldError) string {
-				t, _ := ut.T(tag, fe.Field(), fe.Param())
+	return ""
+}
 
-				return t
-			})
-		}
+// fieldPath returns the path of the field of fe in the JSON of the root struct, events[0] for an item, the
+// embedded structs are left out as encoding/json promotes their fields
+func fieldPath(root reflect.Type, fe validator.FieldError) string {
+	names := strings.Split(fe.Namespace(), ".")
+	structNames := strings.Split(fe.StructNamespace(), ".")
+	if len(names) != len(structNames) {
+		// A map key holding a dot
+		return fe.Field()
 	}
 
-	if err := v.validate.Struct(u); err != nil {
-		errArr := []string{}
-		validationErrs := validator.ValidationErrors{}
-		if errors.As(err, &validationErrs) {
-			for _, e := range validationErrs {
-				errArr = append(errArr, e.Translate(trans))
+	t := root
+	path := []string{}
+	for i := 1; i < len(names); i++ {
+		for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array ||
+			t.Kind() == reflect.Map {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Struct {
+			name, _, _ := strings.Cut(structNames[i], "[")
+			if f, ok := t.FieldByName(name); ok {
+				t = f.Type
+				if f.Anonymous && fieldName(f) == "" {
+					continue
+				}
 			}
 		}
-
-		errStr := strings.Join(errArr, ";")
-		return errors.New(errStr) //nolint:err113
+		path = append(path, names[i])
 	}
 
-	return nil
+	return strings.Join(path, ".")
 }
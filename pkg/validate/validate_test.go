@@ -0,0 +1,74 @@
+package validate_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"go-app/pkg/validate"
+)
+
+type listRequest struct {
+	Limit int `query:"limit" validate:"omitempty,max=100"`
+}
+
+type webhookRequest struct {
+	listRequest
+	URL    string   `json:"url" validate:"required,url"`
+	Events []string `json:"events" validate:"required,dive,required"`
+	Secret string   `json:"secret,omitempty" validate:"omitempty,min=16"`
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	v := validate.NewValidate()
+
+	if err := v.Validate(webhookRequest{URL: "https://example.com", Events: []string{"user.created"}}); err != nil {
+		t.Fatalf("got %v for a valid struct", err)
+	}
+
+	err := v.Validate(webhookRequest{listRequest: listRequest{Limit: 500}, Events: []string{"user.created", ""}})
+	var ve *validate.ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("got %T, *validate.ValidationError expected", err)
+	}
+	if err.Error() != "limit is greater than max!;url must have a value!;events[1] must have a value!" {
+		t.Errorf("got message %q", err.Error())
+	}
+
+	expectedResults := []struct {
+		locale   string
+		expected []validate.FieldError
+	}{
+		{
+			"",
+			[]validate.FieldError{
+				{Field: "limit", Messages: []string{"limit is greater than max!"}},
+				{Field: "url", Messages: []string{"url must have a value!"}},
+				{Field: "events[1]", Messages: []string{"events[1] must have a value!"}},
+			},
+		},
+		{
+			"vi-VN,vi;q=0.9,en;q=0.8",
+			[]validate.FieldError{
+				{Field: "limit", Messages: []string{"limit phải là 100 hoặc nhỏ hơn"}},
+				{Field: "url", Messages: []string{"url không được bỏ trống"}},
+				{Field: "events[1]", Messages: []string{"events[1] không được bỏ trống"}},
+			},
+		},
+		{
+			"fr-FR",
+			[]validate.FieldError{
+				{Field: "limit", Messages: []string{"limit is greater than max!"}},
+				{Field: "url", Messages: []string{"url must have a value!"}},
+				{Field: "events[1]", Messages: []string{"events[1] must have a value!"}},
+			},
+		},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		if got := ve.Fields(testExpected.locale); !reflect.DeepEqual(got, testExpected.expected) {
+			t.Errorf("#%d got %+v, %+v expected", testNumber, got, testExpected.expected)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// Package webhook signs and sends webhook payloads, receivers verify them with Verify
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// SignatureHeader carries the timestamp and the signature as t=<unix>,v1=<hex>
+	SignatureHeader = "X-Signature"
+	// EventHeader carries the name of the event
+	EventHeader = "X-Webhook-Event"
+	// DeliveryHeader carries the id of the delivery, stable across retries
+	DeliveryHeader = "X-Webhook-Delivery"
+
+	// responseBodyLimit is the number of bytes of the response kept in Result
+	responseBodyLimit = 1024
+)
+
+var (
+	// ErrForbiddenAddress is returned for a url or a connection to an address which is not public
+	ErrForbiddenAddress = errors.New("webhook: address is not public")
+	// ErrInvalidURL is returned for a url which is not an absolute http or https url
+	ErrInvalidURL = errors.New("webhook: invalid url")
+
+	errMalformedSignature = errors.New("webhook: malformed signature header")
+	errInvalidSignature   = errors.New("webhook: signature mismatch")
+	errExpiredSignature   = errors.New("webhook: timestamp outside tolerance")
+)
+
+// Message is a webhook request
+type Message struct {
+	URL        string
+	Secret     string
+	Event      string
+	DeliveryID string
+	Body       []byte
+}
+
+// Result is the response of the receiver
+type Result struct {
+	StatusCode int
+	Body       string
+	Duration   time.Duration
+}
+
+// Client sends signed messages
+type Client struct {
+	HTTP      *http.Client
+	UserAgent string
+}
+
+// sharedAddressSpace is the carrier grade NAT range, not public either
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// NewClient returns a client giving up after timeout, it only connects to public addresses so a receiver can not
+// reach the internal network, also through a redirect or a name resolving to a private address
+func NewClient(timeout time.Duration, userAgent string) *Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: dialControl}
+	transport, _ := http.DefaultTransport.(*http.Transport)
+	transport = transport.Clone()
+	// A proxy would be the only address checked
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &Client{
+		HTTP:      &http.Client{Timeout: timeout, Transport: transport},
+		UserAgent: userAgent,
+	}
+}
+
+// CheckURL checks the url is an absolute http or https url whose host is not a loopback, private or link local
+// address, a name is resolved at every connection and checked then
+func CheckURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrInvalidURL
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrForbiddenAddress
+	}
+	if ip, err := netip.ParseAddr(host); err == nil && !public(ip) {
+		return ErrForbiddenAddress
+	}
+
+	return nil
+}
+
+// dialControl refuses the connections to an address which is not public
+func dialControl(_, address string, _ syscall.RawConn) error {
+	addr, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !public(addr.Addr()) {
+		return ErrForbiddenAddress
+	}
+
+	return nil
+}
+
+// public reports whether ip is a global unicast address outside of the private and shared ranges
+func public(ip netip.Addr) bool {
+	ip = ip.Unmap()
+
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !sharedAddressSpace.Contains(ip)
+}
+
+// Send posts the signed message, an error is returned when the request fails or the status is not 2xx
+func (c *Client) Send(ctx context.Context, msg Message) (Result, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, msg.URL, bytes.NewReader(msg.Body))
+	if err != nil {
+		return Result{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.UserAgent)
+	req.Header.Set(EventHeader, msg.Event)
+	req.Header.Set(DeliveryHeader, msg.DeliveryID)
+	req.Header.Set(SignatureHeader, Sign(msg.Secret, time.Now(), msg.Body))
+
+	start := time.Now()
+	res, err := c.HTTP.Do(req)
+	if err != nil {
+		return Result{Duration: time.Since(start)}, err
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, responseBodyLimit))
+	result := Result{StatusCode: res.StatusCode, Body: string(body), Duration: time.Since(start)}
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return result, fmt.Errorf("webhook: unexpected status %d", res.StatusCode)
+	}
+
+	return result, nil
+}
+
+// Sign returns the signature header of body sent at ts, the signed content is "<unix>.<body>"
+func Sign(secret string, ts time.Time, body []byte) string {
+	unix := strconv.FormatInt(ts.Unix(), 10)
+
+	return "t=" + unix + ",v1=" + signature(secret, unix, body)
+}
+
+// Verify checks the signature header of body, the timestamp must be within tolerance of now
+func Verify(secret, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var unix, sig string
+	for _, part := range strings.Split(header, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return errMalformedSignature
+		}
+		switch k {
+		case "t":
+			unix = v
+		case "v1":
+			sig = v
+		}
+	}
+	ts, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil || sig == "" {
+		return errMalformedSignature
+	}
+
+	if d := now.Sub(time.Unix(ts, 0)); d > tolerance || d < -tolerance {
+		return errExpiredSignature
+	}
+	if !hmac.Equal([]byte(sig), []byte(signature(secret, unix, body))) {
+		return errInvalidSignature
+	}
+
+	return nil
+}
+
+// signature is the hex HMAC-SHA256 of the timestamp and body
+func signature(secret, unix string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
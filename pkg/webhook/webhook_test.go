@@ -0,0 +1,144 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-app/pkg/webhook"
+)
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+	body := []byte(`{"event":"user.registered"}`)
+	now := time.Unix(1700000000, 0)
+	header := webhook.Sign("secret", now, body)
+
+	expectedResults := []struct {
+		secret string
+		header string
+		body   []byte
+		now    time.Time
+		valid  bool
+	}{
+		{"secret", header, body, now, true},
+		{"secret", header, body, now.Add(4 * time.Minute), true},
+		{"other", header, body, now, false},
+		{"secret", header, []byte(`{"event":"user.deleted"}`), now, false},
+		{"secret", header, body, now.Add(10 * time.Minute), false},
+		{"secret", "v1=abc", body, now, false},
+		{"secret", "garbage", body, now, false},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		err := webhook.Verify(testExpected.secret, testExpected.header, testExpected.body, 5*time.Minute, testExpected.now)
+		if (err == nil) != testExpected.valid {
+			t.Errorf("#%d got error %v, valid %v expected", testNumber, err, testExpected.valid)
+		}
+	}
+}
+
+func TestClientSend(t *testing.T) {
+	t.Parallel()
+	received := make(chan error, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		err := webhook.Verify("secret", r.Header.Get(webhook.SignatureHeader), body, time.Minute, time.Now())
+		if r.Header.Get(webhook.EventHeader) != "user.registered" || r.Header.Get(webhook.DeliveryHeader) != "42" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		received <- err
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	client := &webhook.Client{HTTP: srv.Client(), UserAgent: "test"}
+	res, err := client.Send(context.Background(), webhook.Message{
+		URL:        srv.URL,
+		Secret:     "secret",
+		Event:      "user.registered",
+		DeliveryID: "42",
+		Body:       []byte(`{"id":1}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := <-received; err != nil {
+		t.Errorf("receiver rejected signature: %v", err)
+	}
+	if res.StatusCode != http.StatusOK || res.Body != "ok" {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
+
+func TestClientSendFailure(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client := &webhook.Client{HTTP: srv.Client(), UserAgent: "test"}
+	res, err := client.Send(context.Background(), webhook.Message{URL: srv.URL, Secret: "secret"})
+	if err == nil || res.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected failure with 503, got %+v %v", res, err)
+	}
+}
+
+func TestClientSendPrivate(t *testing.T) {
+	t.Parallel()
+	var received atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		received.Store(true)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := webhook.NewClient(time.Second, "test")
+	for _, u := range []string{srv.URL, strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)} {
+		_, err := client.Send(context.Background(), webhook.Message{URL: u})
+		if !errors.Is(err, webhook.ErrForbiddenAddress) {
+			t.Errorf("%s got %v, ErrForbiddenAddress expected", u, err)
+		}
+	}
+	if received.Load() {
+		t.Error("got the private receiver called")
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	t.Parallel()
+
+	expectedResults := []struct {
+		url string
+		err error
+	}{
+		{"https://hooks.example.com/receive", nil},
+		{"http://203.0.113.7:8080/hook", nil},
+		{"ftp://hooks.example.com", webhook.ErrInvalidURL},
+		{"/relative", webhook.ErrInvalidURL},
+		{"http://localhost:8080", webhook.ErrForbiddenAddress},
+		{"http://api.localhost.", webhook.ErrForbiddenAddress},
+		{"http://127.0.0.1", webhook.ErrForbiddenAddress},
+		{"http://[::1]:80", webhook.ErrForbiddenAddress},
+		{"http://10.1.2.3", webhook.ErrForbiddenAddress},
+		{"http://192.168.0.10", webhook.ErrForbiddenAddress},
+		{"http://169.254.169.254/latest/meta-data", webhook.ErrForbiddenAddress},
+		{"http://[fe80::1]", webhook.ErrForbiddenAddress},
+		{"http://[::ffff:10.0.0.1]", webhook.ErrForbiddenAddress},
+		{"http://100.64.0.1", webhook.ErrForbiddenAddress},
+		{"http://0.0.0.0", webhook.ErrForbiddenAddress},
+	}
+
+	for testNumber, testExpected := range expectedResults {
+		if err := webhook.CheckURL(testExpected.url); !errors.Is(err, testExpected.err) {
+			t.Errorf("#%d %s got %v, %v expected", testNumber, testExpected.url, err, testExpected.err)
+		}
+	}
+}